@@ -0,0 +1,180 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.3
+// source: api/v1/candles.proto
+
+package exchangepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CandlesService_GetCandles_FullMethodName    = "/exchange.api.v1.CandlesService/GetCandles"
+	CandlesService_StreamCandles_FullMethodName = "/exchange.api.v1.CandlesService/StreamCandles"
+)
+
+// CandlesServiceClient is the client API for CandlesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CandlesServiceClient interface {
+	// The candles of a pair starting within a time range, oldest first.
+	GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*GetCandlesResponse, error)
+	// Sends the latest candle of a pair, followed by every change to its
+	// candles, and the candle of each new interval as it starts.
+	StreamCandles(ctx context.Context, in *StreamCandlesRequest, opts ...grpc.CallOption) (CandlesService_StreamCandlesClient, error)
+}
+
+type candlesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCandlesServiceClient(cc grpc.ClientConnInterface) CandlesServiceClient {
+	return &candlesServiceClient{cc}
+}
+
+func (c *candlesServiceClient) GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*GetCandlesResponse, error) {
+	out := new(GetCandlesResponse)
+	err := c.cc.Invoke(ctx, CandlesService_GetCandles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candlesServiceClient) StreamCandles(ctx context.Context, in *StreamCandlesRequest, opts ...grpc.CallOption) (CandlesService_StreamCandlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CandlesService_ServiceDesc.Streams[0], CandlesService_StreamCandles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &candlesServiceStreamCandlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CandlesService_StreamCandlesClient interface {
+	Recv() (*Candle, error)
+	grpc.ClientStream
+}
+
+type candlesServiceStreamCandlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *candlesServiceStreamCandlesClient) Recv() (*Candle, error) {
+	m := new(Candle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CandlesServiceServer is the server API for CandlesService service.
+// All implementations must embed UnimplementedCandlesServiceServer
+// for forward compatibility
+type CandlesServiceServer interface {
+	// The candles of a pair starting within a time range, oldest first.
+	GetCandles(context.Context, *GetCandlesRequest) (*GetCandlesResponse, error)
+	// Sends the latest candle of a pair, followed by every change to its
+	// candles, and the candle of each new interval as it starts.
+	StreamCandles(*StreamCandlesRequest, CandlesService_StreamCandlesServer) error
+	mustEmbedUnimplementedCandlesServiceServer()
+}
+
+// UnimplementedCandlesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCandlesServiceServer struct {
+}
+
+func (UnimplementedCandlesServiceServer) GetCandles(context.Context, *GetCandlesRequest) (*GetCandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
+}
+func (UnimplementedCandlesServiceServer) StreamCandles(*StreamCandlesRequest, CandlesService_StreamCandlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCandles not implemented")
+}
+func (UnimplementedCandlesServiceServer) mustEmbedUnimplementedCandlesServiceServer() {}
+
+// UnsafeCandlesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CandlesServiceServer will
+// result in compilation errors.
+type UnsafeCandlesServiceServer interface {
+	mustEmbedUnimplementedCandlesServiceServer()
+}
+
+func RegisterCandlesServiceServer(s grpc.ServiceRegistrar, srv CandlesServiceServer) {
+	s.RegisterService(&CandlesService_ServiceDesc, srv)
+}
+
+func _CandlesService_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandlesServiceServer).GetCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandlesService_GetCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandlesServiceServer).GetCandles(ctx, req.(*GetCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandlesService_StreamCandles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamCandlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CandlesServiceServer).StreamCandles(m, &candlesServiceStreamCandlesServer{stream})
+}
+
+type CandlesService_StreamCandlesServer interface {
+	Send(*Candle) error
+	grpc.ServerStream
+}
+
+type candlesServiceStreamCandlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *candlesServiceStreamCandlesServer) Send(m *Candle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CandlesService_ServiceDesc is the grpc.ServiceDesc for CandlesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CandlesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "exchange.api.v1.CandlesService",
+	HandlerType: (*CandlesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCandles",
+			Handler:    _CandlesService_GetCandles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCandles",
+			Handler:       _CandlesService_StreamCandles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/v1/candles.proto",
+}
@@ -0,0 +1,614 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.3
+// source: api/v1/feed.proto
+
+package exchangepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FeedChannel int32
+
+const (
+	FeedChannel_FEED_CHANNEL_UNSPECIFIED FeedChannel = 0
+	// The updates of StreamOrderBook for pair, a snapshot first.
+	FeedChannel_BOOK FeedChannel = 1
+	// The trades of StreamTrades for pair.
+	FeedChannel_TRADES FeedChannel = 2
+	// The updates of StreamOrderUpdates for account_id.
+	FeedChannel_ORDERS FeedChannel = 3
+	// The candles of StreamCandles for pair and interval.
+	FeedChannel_CANDLES FeedChannel = 4
+)
+
+// Enum value maps for FeedChannel.
+var (
+	FeedChannel_name = map[int32]string{
+		0: "FEED_CHANNEL_UNSPECIFIED",
+		1: "BOOK",
+		2: "TRADES",
+		3: "ORDERS",
+		4: "CANDLES",
+	}
+	FeedChannel_value = map[string]int32{
+		"FEED_CHANNEL_UNSPECIFIED": 0,
+		"BOOK":                     1,
+		"TRADES":                   2,
+		"ORDERS":                   3,
+		"CANDLES":                  4,
+	}
+)
+
+func (x FeedChannel) Enum() *FeedChannel {
+	p := new(FeedChannel)
+	*p = x
+	return p
+}
+
+func (x FeedChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeedChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_feed_proto_enumTypes[0].Descriptor()
+}
+
+func (FeedChannel) Type() protoreflect.EnumType {
+	return &file_api_v1_feed_proto_enumTypes[0]
+}
+
+func (x FeedChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeedChannel.Descriptor instead.
+func (FeedChannel) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_feed_proto_rawDescGZIP(), []int{0}
+}
+
+type FeedRequest_Action int32
+
+const (
+	FeedRequest_FEED_ACTION_UNSPECIFIED FeedRequest_Action = 0
+	FeedRequest_SUBSCRIBE               FeedRequest_Action = 1
+	FeedRequest_UNSUBSCRIBE             FeedRequest_Action = 2
+)
+
+// Enum value maps for FeedRequest_Action.
+var (
+	FeedRequest_Action_name = map[int32]string{
+		0: "FEED_ACTION_UNSPECIFIED",
+		1: "SUBSCRIBE",
+		2: "UNSUBSCRIBE",
+	}
+	FeedRequest_Action_value = map[string]int32{
+		"FEED_ACTION_UNSPECIFIED": 0,
+		"SUBSCRIBE":               1,
+		"UNSUBSCRIBE":             2,
+	}
+)
+
+func (x FeedRequest_Action) Enum() *FeedRequest_Action {
+	p := new(FeedRequest_Action)
+	*p = x
+	return p
+}
+
+func (x FeedRequest_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeedRequest_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_feed_proto_enumTypes[1].Descriptor()
+}
+
+func (FeedRequest_Action) Type() protoreflect.EnumType {
+	return &file_api_v1_feed_proto_enumTypes[1]
+}
+
+func (x FeedRequest_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeedRequest_Action.Descriptor instead.
+func (FeedRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_feed_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// FeedRequest is sent by clients to start or stop receiving a channel.
+type FeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action  FeedRequest_Action `protobuf:"varint,1,opt,name=action,proto3,enum=exchange.api.v1.FeedRequest_Action" json:"action,omitempty"`
+	Channel FeedChannel        `protobuf:"varint,2,opt,name=channel,proto3,enum=exchange.api.v1.FeedChannel" json:"channel,omitempty"`
+	// For BOOK, TRADES and CANDLES.
+	Pair string `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	// For ORDERS.
+	AccountId string `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// For ORDERS, as in StreamOrderUpdatesRequest.
+	FromSequence uint64 `protobuf:"varint,5,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	// For ORDERS, the authentication metadata of the StreamOrderUpdates
+	// request with the account_id and from_sequence above.
+	ApiKey    string `protobuf:"bytes,6,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Timestamp int64  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Nonce     string `protobuf:"bytes,8,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Signature string `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	// For CANDLES.
+	Interval CandleInterval `protobuf:"varint,10,opt,name=interval,proto3,enum=exchange.api.v1.CandleInterval" json:"interval,omitempty"`
+}
+
+func (x *FeedRequest) Reset() {
+	*x = FeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_feed_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedRequest) ProtoMessage() {}
+
+func (x *FeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_feed_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedRequest.ProtoReflect.Descriptor instead.
+func (*FeedRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_feed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FeedRequest) GetAction() FeedRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return FeedRequest_FEED_ACTION_UNSPECIFIED
+}
+
+func (x *FeedRequest) GetChannel() FeedChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return FeedChannel_FEED_CHANNEL_UNSPECIFIED
+}
+
+func (x *FeedRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *FeedRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *FeedRequest) GetFromSequence() uint64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+func (x *FeedRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *FeedRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *FeedRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *FeedRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *FeedRequest) GetInterval() CandleInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return CandleInterval_CANDLE_INTERVAL_UNSPECIFIED
+}
+
+// FeedMessage is sent by the gateway for each update of the subscribed
+// channels, and to acknowledge or reject requests.
+type FeedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The channel and key of the subscription, as in the request.
+	Channel   FeedChannel    `protobuf:"varint,1,opt,name=channel,proto3,enum=exchange.api.v1.FeedChannel" json:"channel,omitempty"`
+	Pair      string         `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AccountId string         `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Interval  CandleInterval `protobuf:"varint,10,opt,name=interval,proto3,enum=exchange.api.v1.CandleInterval" json:"interval,omitempty"`
+	// Types that are assignable to Message:
+	//	*FeedMessage_Ack
+	//	*FeedMessage_Error
+	//	*FeedMessage_Book
+	//	*FeedMessage_Trade
+	//	*FeedMessage_Order
+	//	*FeedMessage_Candle
+	Message isFeedMessage_Message `protobuf_oneof:"message"`
+}
+
+func (x *FeedMessage) Reset() {
+	*x = FeedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_feed_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedMessage) ProtoMessage() {}
+
+func (x *FeedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_feed_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedMessage.ProtoReflect.Descriptor instead.
+func (*FeedMessage) Descriptor() ([]byte, []int) {
+	return file_api_v1_feed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FeedMessage) GetChannel() FeedChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return FeedChannel_FEED_CHANNEL_UNSPECIFIED
+}
+
+func (x *FeedMessage) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *FeedMessage) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *FeedMessage) GetInterval() CandleInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return CandleInterval_CANDLE_INTERVAL_UNSPECIFIED
+}
+
+func (m *FeedMessage) GetMessage() isFeedMessage_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *FeedMessage) GetAck() FeedRequest_Action {
+	if x, ok := x.GetMessage().(*FeedMessage_Ack); ok {
+		return x.Ack
+	}
+	return FeedRequest_FEED_ACTION_UNSPECIFIED
+}
+
+func (x *FeedMessage) GetError() string {
+	if x, ok := x.GetMessage().(*FeedMessage_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FeedMessage) GetBook() *OrderBookUpdate {
+	if x, ok := x.GetMessage().(*FeedMessage_Book); ok {
+		return x.Book
+	}
+	return nil
+}
+
+func (x *FeedMessage) GetTrade() *Trade {
+	if x, ok := x.GetMessage().(*FeedMessage_Trade); ok {
+		return x.Trade
+	}
+	return nil
+}
+
+func (x *FeedMessage) GetOrder() *OrderUpdate {
+	if x, ok := x.GetMessage().(*FeedMessage_Order); ok {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *FeedMessage) GetCandle() *Candle {
+	if x, ok := x.GetMessage().(*FeedMessage_Candle); ok {
+		return x.Candle
+	}
+	return nil
+}
+
+type isFeedMessage_Message interface {
+	isFeedMessage_Message()
+}
+
+type FeedMessage_Ack struct {
+	// Answers each request that succeeded.
+	Ack FeedRequest_Action `protobuf:"varint,4,opt,name=ack,proto3,enum=exchange.api.v1.FeedRequest_Action,oneof"`
+}
+
+type FeedMessage_Error struct {
+	// Why a request failed, or why the subscription ended. A subscription
+	// ending with an error must be subscribed again.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type FeedMessage_Book struct {
+	Book *OrderBookUpdate `protobuf:"bytes,6,opt,name=book,proto3,oneof"`
+}
+
+type FeedMessage_Trade struct {
+	Trade *Trade `protobuf:"bytes,7,opt,name=trade,proto3,oneof"`
+}
+
+type FeedMessage_Order struct {
+	Order *OrderUpdate `protobuf:"bytes,8,opt,name=order,proto3,oneof"`
+}
+
+type FeedMessage_Candle struct {
+	Candle *Candle `protobuf:"bytes,9,opt,name=candle,proto3,oneof"`
+}
+
+func (*FeedMessage_Ack) isFeedMessage_Message() {}
+
+func (*FeedMessage_Error) isFeedMessage_Message() {}
+
+func (*FeedMessage_Book) isFeedMessage_Message() {}
+
+func (*FeedMessage_Trade) isFeedMessage_Message() {}
+
+func (*FeedMessage_Order) isFeedMessage_Message() {}
+
+func (*FeedMessage_Candle) isFeedMessage_Message() {}
+
+var File_api_v1_feed_proto protoreflect.FileDescriptor
+
+var file_api_v1_feed_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x1a, 0x14, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x03, 0x0a, 0x0b, 0x46, 0x65, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6e,
+	0x6f, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x3b, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x45, 0x0a, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45,
+	0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42,
+	0x45, 0x10, 0x02, 0x22, 0xe2, 0x03, 0x0a, 0x0b, 0x46, 0x65, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x3b,
+	0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1f, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x37, 0x0a, 0x03, 0x61,
+	0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x03, 0x61, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x36, 0x0a, 0x04,
+	0x62, 0x6f, 0x6f, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x64, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x48, 0x00, 0x52, 0x05, 0x74,
+	0x72, 0x61, 0x64, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06, 0x63, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x5a, 0x0a, 0x0b, 0x46, 0x65, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x18, 0x46, 0x45, 0x45, 0x44, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4b, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x54, 0x52, 0x41, 0x44, 0x45, 0x53, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x53, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x41, 0x4e, 0x44, 0x4c,
+	0x45, 0x53, 0x10, 0x04, 0x42, 0x1c, 0x5a, 0x1a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v1_feed_proto_rawDescOnce sync.Once
+	file_api_v1_feed_proto_rawDescData = file_api_v1_feed_proto_rawDesc
+)
+
+func file_api_v1_feed_proto_rawDescGZIP() []byte {
+	file_api_v1_feed_proto_rawDescOnce.Do(func() {
+		file_api_v1_feed_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v1_feed_proto_rawDescData)
+	})
+	return file_api_v1_feed_proto_rawDescData
+}
+
+var file_api_v1_feed_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_v1_feed_proto_goTypes = []interface{}{
+	(FeedChannel)(0),        // 0: exchange.api.v1.FeedChannel
+	(FeedRequest_Action)(0), // 1: exchange.api.v1.FeedRequest.Action
+	(*FeedRequest)(nil),     // 2: exchange.api.v1.FeedRequest
+	(*FeedMessage)(nil),     // 3: exchange.api.v1.FeedMessage
+	(CandleInterval)(0),     // 4: exchange.api.v1.CandleInterval
+	(*OrderBookUpdate)(nil), // 5: exchange.api.v1.OrderBookUpdate
+	(*Trade)(nil),           // 6: exchange.api.v1.Trade
+	(*OrderUpdate)(nil),     // 7: exchange.api.v1.OrderUpdate
+	(*Candle)(nil),          // 8: exchange.api.v1.Candle
+}
+var file_api_v1_feed_proto_depIdxs = []int32{
+	1,  // 0: exchange.api.v1.FeedRequest.action:type_name -> exchange.api.v1.FeedRequest.Action
+	0,  // 1: exchange.api.v1.FeedRequest.channel:type_name -> exchange.api.v1.FeedChannel
+	4,  // 2: exchange.api.v1.FeedRequest.interval:type_name -> exchange.api.v1.CandleInterval
+	0,  // 3: exchange.api.v1.FeedMessage.channel:type_name -> exchange.api.v1.FeedChannel
+	4,  // 4: exchange.api.v1.FeedMessage.interval:type_name -> exchange.api.v1.CandleInterval
+	1,  // 5: exchange.api.v1.FeedMessage.ack:type_name -> exchange.api.v1.FeedRequest.Action
+	5,  // 6: exchange.api.v1.FeedMessage.book:type_name -> exchange.api.v1.OrderBookUpdate
+	6,  // 7: exchange.api.v1.FeedMessage.trade:type_name -> exchange.api.v1.Trade
+	7,  // 8: exchange.api.v1.FeedMessage.order:type_name -> exchange.api.v1.OrderUpdate
+	8,  // 9: exchange.api.v1.FeedMessage.candle:type_name -> exchange.api.v1.Candle
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_feed_proto_init() }
+func file_api_v1_feed_proto_init() {
+	if File_api_v1_feed_proto != nil {
+		return
+	}
+	file_api_v1_candles_proto_init()
+	file_api_v1_marketdata_proto_init()
+	file_api_v1_order_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_api_v1_feed_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_feed_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_v1_feed_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*FeedMessage_Ack)(nil),
+		(*FeedMessage_Error)(nil),
+		(*FeedMessage_Book)(nil),
+		(*FeedMessage_Trade)(nil),
+		(*FeedMessage_Order)(nil),
+		(*FeedMessage_Candle)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_v1_feed_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_v1_feed_proto_goTypes,
+		DependencyIndexes: file_api_v1_feed_proto_depIdxs,
+		EnumInfos:         file_api_v1_feed_proto_enumTypes,
+		MessageInfos:      file_api_v1_feed_proto_msgTypes,
+	}.Build()
+	File_api_v1_feed_proto = out.File
+	file_api_v1_feed_proto_rawDesc = nil
+	file_api_v1_feed_proto_goTypes = nil
+	file_api_v1_feed_proto_depIdxs = nil
+}
@@ -10,6 +10,7 @@ import (
 	v1 "exchange/api/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -28,21 +29,59 @@ const (
 	OrderRequest_MARKET                    OrderRequest_Type = 1
 	OrderRequest_LIMIT                     OrderRequest_Type = 2
 	OrderRequest_CANCEL                    OrderRequest_Type = 3
+	// Expires the resting orders of the market up to time, without an order.
+	OrderRequest_TICK OrderRequest_Type = 4
+	// Held until a trade at the stop price of the order.
+	OrderRequest_STOP OrderRequest_Type = 5
+	// The order and the first linked order, as one-cancels-other.
+	OrderRequest_OCO OrderRequest_Type = 6
+	// The order as the entry, and the linked orders as the take-profit limit
+	// and the stop-loss stop, activated as an OCO when the entry is done.
+	OrderRequest_BRACKET OrderRequest_Type = 7
+	// A limit order whose price follows the book, repriced by the engine.
+	OrderRequest_PEGGED OrderRequest_Type = 8
+	// Held until the trades reverse by the trail of the order.
+	OrderRequest_TRAILING_STOP OrderRequest_Type = 9
+	// Cancels the orders of the market selected by cancel_all, without an
+	// order.
+	OrderRequest_CANCEL_ALL OrderRequest_Type = 10
+	// Amends order, as last known by the sender, as given by amend.
+	OrderRequest_AMEND OrderRequest_Type = 11
+	// Applies the requests of batch in sequence, without an order.
+	OrderRequest_BATCH OrderRequest_Type = 12
 )
 
 // Enum value maps for OrderRequest_Type.
 var (
 	OrderRequest_Type_name = map[int32]string{
-		0: "ORDER_REQUEST_UNSPECIFIED",
-		1: "MARKET",
-		2: "LIMIT",
-		3: "CANCEL",
+		0:  "ORDER_REQUEST_UNSPECIFIED",
+		1:  "MARKET",
+		2:  "LIMIT",
+		3:  "CANCEL",
+		4:  "TICK",
+		5:  "STOP",
+		6:  "OCO",
+		7:  "BRACKET",
+		8:  "PEGGED",
+		9:  "TRAILING_STOP",
+		10: "CANCEL_ALL",
+		11: "AMEND",
+		12: "BATCH",
 	}
 	OrderRequest_Type_value = map[string]int32{
 		"ORDER_REQUEST_UNSPECIFIED": 0,
 		"MARKET":                    1,
 		"LIMIT":                     2,
 		"CANCEL":                    3,
+		"TICK":                      4,
+		"STOP":                      5,
+		"OCO":                       6,
+		"BRACKET":                   7,
+		"PEGGED":                    8,
+		"TRAILING_STOP":             9,
+		"CANCEL_ALL":                10,
+		"AMEND":                     11,
+		"BATCH":                     12,
 	}
 )
 
@@ -80,6 +119,16 @@ type OrderRequest struct {
 
 	Type  OrderRequest_Type `protobuf:"varint,1,opt,name=type,proto3,enum=exchange.engine.api.v1.OrderRequest_Type" json:"type,omitempty"`
 	Order *v1.Order         `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	// The time of a TICK request.
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// The orders linked to order by OCO and BRACKET requests.
+	Linked []*v1.Order `protobuf:"bytes,4,rep,name=linked,proto3" json:"linked,omitempty"`
+	// The filters of a CANCEL_ALL request.
+	CancelAll *v1.CancelAllOrdersRequest `protobuf:"bytes,5,opt,name=cancel_all,json=cancelAll,proto3" json:"cancel_all,omitempty"`
+	// The changes of an AMEND request.
+	Amend *v1.AmendOrderRequest `protobuf:"bytes,6,opt,name=amend,proto3" json:"amend,omitempty"`
+	// The requests of a BATCH request, other than TICK and BATCH requests.
+	Batch []*OrderRequest `protobuf:"bytes,7,rep,name=batch,proto3" json:"batch,omitempty"`
 }
 
 func (x *OrderRequest) Reset() {
@@ -128,6 +177,41 @@ func (x *OrderRequest) GetOrder() *v1.Order {
 	return nil
 }
 
+func (x *OrderRequest) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *OrderRequest) GetLinked() []*v1.Order {
+	if x != nil {
+		return x.Linked
+	}
+	return nil
+}
+
+func (x *OrderRequest) GetCancelAll() *v1.CancelAllOrdersRequest {
+	if x != nil {
+		return x.CancelAll
+	}
+	return nil
+}
+
+func (x *OrderRequest) GetAmend() *v1.AmendOrderRequest {
+	if x != nil {
+		return x.Amend
+	}
+	return nil
+}
+
+func (x *OrderRequest) GetBatch() []*OrderRequest {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
 var File_engine_api_v1_order_proto protoreflect.FileDescriptor
 
 var file_engine_api_v1_order_proto_rawDesc = []byte{
@@ -135,22 +219,49 @@ var file_engine_api_v1_order_proto_rawDesc = []byte{
 	0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x65, 0x78, 0x63,
 	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x61, 0x70, 0x69,
 	0x2e, 0x76, 0x31, 0x1a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65,
-	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc5, 0x01, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05,
-	0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x48, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd3, 0x04, 0x0a, 0x0c, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x64, 0x12, 0x46, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x12, 0x38,
+	0x0a, 0x05, 0x61, 0x6d, 0x65, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6d, 0x65, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x05, 0x61, 0x6d, 0x65, 0x6e, 0x64, 0x12, 0x3a, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x22, 0xb7, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
 	0x19, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x55,
 	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
 	0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x49, 0x4d, 0x49,
-	0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x10, 0x03, 0x42,
-	0x21, 0x5a, 0x1f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2f, 0x65, 0x6e, 0x67, 0x69,
-	0x6e, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x10, 0x03, 0x12,
+	0x08, 0x0a, 0x04, 0x54, 0x49, 0x43, 0x4b, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f,
+	0x50, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x43, 0x4f, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07,
+	0x42, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x45, 0x47,
+	0x47, 0x45, 0x44, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x52, 0x41, 0x49, 0x4c, 0x49, 0x4e,
+	0x47, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x09, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x41, 0x4e, 0x43,
+	0x45, 0x4c, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x0a, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4d, 0x45, 0x4e,
+	0x44, 0x10, 0x0b, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x0c, 0x42, 0x21,
+	0x5a, 0x1f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -168,18 +279,26 @@ func file_engine_api_v1_order_proto_rawDescGZIP() []byte {
 var file_engine_api_v1_order_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_engine_api_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_engine_api_v1_order_proto_goTypes = []interface{}{
-	(OrderRequest_Type)(0), // 0: exchange.engine.api.v1.OrderRequest.Type
-	(*OrderRequest)(nil),   // 1: exchange.engine.api.v1.OrderRequest
-	(*v1.Order)(nil),       // 2: exchange.api.v1.Order
+	(OrderRequest_Type)(0),            // 0: exchange.engine.api.v1.OrderRequest.Type
+	(*OrderRequest)(nil),              // 1: exchange.engine.api.v1.OrderRequest
+	(*v1.Order)(nil),                  // 2: exchange.api.v1.Order
+	(*timestamppb.Timestamp)(nil),     // 3: google.protobuf.Timestamp
+	(*v1.CancelAllOrdersRequest)(nil), // 4: exchange.api.v1.CancelAllOrdersRequest
+	(*v1.AmendOrderRequest)(nil),      // 5: exchange.api.v1.AmendOrderRequest
 }
 var file_engine_api_v1_order_proto_depIdxs = []int32{
 	0, // 0: exchange.engine.api.v1.OrderRequest.type:type_name -> exchange.engine.api.v1.OrderRequest.Type
 	2, // 1: exchange.engine.api.v1.OrderRequest.order:type_name -> exchange.api.v1.Order
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 2: exchange.engine.api.v1.OrderRequest.time:type_name -> google.protobuf.Timestamp
+	2, // 3: exchange.engine.api.v1.OrderRequest.linked:type_name -> exchange.api.v1.Order
+	4, // 4: exchange.engine.api.v1.OrderRequest.cancel_all:type_name -> exchange.api.v1.CancelAllOrdersRequest
+	5, // 5: exchange.engine.api.v1.OrderRequest.amend:type_name -> exchange.api.v1.AmendOrderRequest
+	1, // 6: exchange.engine.api.v1.OrderRequest.batch:type_name -> exchange.engine.api.v1.OrderRequest
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_engine_api_v1_order_proto_init() }
@@ -0,0 +1,122 @@
+package market
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+)
+
+// Amend changes the price and the total volume of a resting limit order, found
+// by the ID, side and price of o. A price of 0 keeps the price, and the volume
+// includes the volume already filled.
+//
+// Lowering the volume at the same price keeps the time priority of the order,
+// any other change moves it to the back of the queue of its price. It fires an
+// OrderAmended event with the new price and volume.
+//
+// Amendments that would leave the order without volume or match it right away
+// fire an AmendRejected event and leave the order as it was. Pegged orders only
+// have their volume amended.
+func (m *Market) Amend(o *order.Order, price uint64, volume uint64) error {
+	book, err := m.restingBook(o)
+	if err != nil {
+		return fmt.Errorf("Amend: %w", err)
+	}
+
+	resting, err := book.Order(o)
+	if err != nil {
+		m.rejectAmend(o, RejectedNotResting)
+		return fmt.Errorf("Amend: market %q, order %q, not resting: %w", m.pair, o.ID, InvalidOrderErr)
+	}
+	o = resting.Order
+
+	if price == 0 {
+		price = o.Price
+	}
+
+	if err := m.validateAmend(o, price, volume); err != nil {
+		return fmt.Errorf("Amend: %w", err)
+	}
+
+	left := volume - o.Filled
+	switch {
+	case price == o.Price && left == o.Volume:
+	case price == o.Price && left < o.Volume:
+		if err := book.Reduce(o, left); err != nil {
+			return fmt.Errorf("Amend: %w", err)
+		}
+	default:
+		if err := book.Delete(o); err != nil {
+			return fmt.Errorf("Amend: %w", err)
+		}
+
+		o.Price, o.Volume = price, left
+		if err := book.Insert(o); err != nil {
+			// The order already left the book, so it is cancelled.
+			m.forget(o.ID)
+			m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Reason: err.Error(), Timestamp: time.Now()}
+			m.react(o.ID, 0, true)
+			m.settle()
+			return fmt.Errorf("Amend: %w", err)
+		}
+	}
+
+	m.orderEvents <- &OrderEvent{Type: OrderAmended, OrderID: o.ID, Price: price, Volume: volume, Timestamp: time.Now()}
+	m.settle()
+
+	return nil
+}
+
+// validateAmend checks the amendment of a resting order, firing an
+// AmendRejected event if it is invalid.
+func (m *Market) validateAmend(o *order.Order, price uint64, volume uint64) error {
+	if o.Peg != nil && price != o.Price {
+		m.rejectAmend(o, RejectedAmendPegPrice)
+		return fmt.Errorf("market %q, order %q, pegged order price: %w", m.pair, o.ID, InvalidOrderErr)
+	}
+
+	if volume <= o.Filled {
+		m.rejectAmend(o, RejectedAmendVolume)
+		return fmt.Errorf("market %q, order %q, volume %d not above the filled volume %d: %w", m.pair, o.ID, volume, o.Filled, InvalidOrderErr)
+	}
+
+	if m.lotSize != 0 && volume%m.lotSize != 0 {
+		m.rejectAmend(o, RejectedVolumeLot)
+		return fmt.Errorf("market %q, order %q, volume %d off the lot size %d: %w", m.pair, o.ID, volume, m.lotSize, InvalidOrderErr)
+	}
+
+	if m.prices != nil {
+		if err := m.prices.Check(price); err != nil {
+			reason := RejectedPriceRange
+			if errors.Is(err, orderbook.PriceOffTickErr) {
+				reason = RejectedPriceTick
+			}
+
+			m.rejectAmend(o, reason)
+			return fmt.Errorf("market %q, order %q, %v: %w", m.pair, o.ID, err, InvalidOrderErr)
+		}
+	}
+
+	crossed := false
+	if o.Side == order.OrderBuy {
+		head := m.sellBook.HeadPrice()
+		crossed = head != 0 && price >= head
+	} else {
+		head := m.buyBook.HeadPrice()
+		crossed = head != 0 && price <= head
+	}
+
+	if crossed {
+		m.rejectAmend(o, RejectedAmendCrosses)
+		return fmt.Errorf("market %q, order %q, price %d crosses the opposite side: %w", m.pair, o.ID, price, InvalidOrderErr)
+	}
+
+	return nil
+}
+
+func (m *Market) rejectAmend(o *order.Order, reason string) {
+	m.orderEvents <- &OrderEvent{Type: AmendRejected, OrderID: o.ID, Reason: reason, Timestamp: time.Now()}
+}
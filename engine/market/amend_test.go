@@ -0,0 +1,179 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Amend(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name   string
+		price  uint64
+		volume uint64
+
+		// Filled before the amendment, out of a volume of 10.
+		filled uint64
+
+		wantOrderEvents []*market.OrderEvent
+		wantPrice       uint64
+		wantVolume      uint64
+
+		// The position of the order in the queue of its price, after the
+		// amendment, with another order of volume 5 at each price.
+		wantOrdersAhead int
+	}{
+		{
+			name:   "reduce_keeps_priority",
+			volume: 6,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderAmended, OrderID: "1", Price: 10, Volume: 6, Timestamp: time.Now()},
+			},
+			wantPrice:  10,
+			wantVolume: 6,
+		},
+		{
+			name:   "reduce_partially_filled",
+			volume: 6,
+			filled: 4,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderAmended, OrderID: "1", Price: 10, Volume: 6, Timestamp: time.Now()},
+			},
+			wantPrice:  10,
+			wantVolume: 2,
+		},
+		{
+			name:   "increase_loses_priority",
+			volume: 12,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderAmended, OrderID: "1", Price: 10, Volume: 12, Timestamp: time.Now()},
+			},
+			wantPrice:       10,
+			wantVolume:      12,
+			wantOrdersAhead: 1,
+		},
+		{
+			name:   "price",
+			price:  9,
+			volume: 10,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderAmended, OrderID: "1", Price: 9, Volume: 10, Timestamp: time.Now()},
+			},
+			wantPrice:       9,
+			wantVolume:      10,
+			wantOrdersAhead: 1,
+		},
+		{
+			name:   "volume_filled",
+			volume: 4,
+			filled: 4,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.AmendRejected, OrderID: "1", Reason: market.RejectedAmendVolume, Timestamp: time.Now()},
+			},
+			wantPrice:  10,
+			wantVolume: 6,
+		},
+		{
+			name:   "crosses",
+			price:  20,
+			volume: 10,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.AmendRejected, OrderID: "1", Reason: market.RejectedAmendCrosses, Timestamp: time.Now()},
+			},
+			wantPrice:  10,
+			wantVolume: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			o := &order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 10}
+			for _, maker := range []*order.Order{
+				o,
+				{Pair: pair, ID: "2", Price: 10, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "3", Price: 9, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "4", Price: 20, Side: order.OrderSell, Volume: 5},
+			} {
+				if err := m.InsertMakerOrder(maker); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", maker, err)
+				}
+			}
+
+			if tc.filled > 0 {
+				taker := &order.Order{Pair: pair, ID: "taker", Side: order.OrderSell, Volume: tc.filled}
+				if err := m.MatchTakerOrder(taker); err != nil {
+					t.Fatalf("MatchTakerOrder(%v) unexpected error: %v", taker, err)
+				}
+			}
+
+			tracker.reset()
+
+			// Amend only by the ID, side and price of the order.
+			err := m.Amend(&order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy}, tc.price, tc.volume)
+			if gotErr := err != nil; gotErr != (tc.wantOrderEvents[0].Type == market.AmendRejected) {
+				t.Errorf("Amend() unexpected error: %v", err)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("Amend() order events diff (-want, +got):\n%s", diff)
+			}
+
+			if o.Price != tc.wantPrice || o.Volume != tc.wantVolume {
+				t.Errorf("Amend() want price %d and volume %d, got %d and %d", tc.wantPrice, tc.wantVolume, o.Price, o.Volume)
+			}
+
+			position, err := m.QueuePosition(o)
+			if err != nil {
+				t.Fatalf("QueuePosition() unexpected error: %v", err)
+			}
+
+			if position.OrdersAhead != tc.wantOrdersAhead {
+				t.Errorf("QueuePosition() want %d orders ahead, got %d", tc.wantOrdersAhead, position.OrdersAhead)
+			}
+		})
+	}
+}
+
+func Test_Amend_NotResting(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	stop := &order.Order{Pair: pair, ID: "1", StopPrice: 10, Side: order.OrderBuy, Volume: 10}
+	if err := m.InsertStopOrder(stop); err != nil {
+		t.Fatalf("InsertStopOrder(%v) unexpected error: %v", stop, err)
+	}
+
+	tracker.reset()
+
+	if err := m.Amend(stop, 0, 5); err == nil {
+		t.Errorf("Amend(%v) want error, got nil", stop)
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.AmendRejected, OrderID: "1", Reason: market.RejectedNotResting, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("Amend() order events diff (-want, +got):\n%s", diff)
+	}
+}
@@ -5,8 +5,15 @@ import (
 	"time"
 )
 
-// Cancel removes an order from its corresponding book.
+// Cancel removes an order from its corresponding book, or a stop order or an
+// inactive bracket leg held by the market. Cancelling an order of a linked
+// group may cancel the rest of the group.
 func (m *Market) Cancel(o *order.Order) error {
+	if o != nil && m.cancelHeld(o) {
+		m.settle()
+		return nil
+	}
+
 	if err := m.validateOrder(o); err != nil {
 		return err
 	}
@@ -19,7 +26,37 @@ func (m *Market) Cancel(o *order.Order) error {
 	if err := book.Delete(o); err != nil {
 		return err
 	}
+	m.forget(o.ID)
 
 	m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Timestamp: time.Now()}
+
+	m.react(o.ID, 0, true)
+	m.settle()
+
 	return nil
 }
+
+// remove removes an order from wherever it rests in the market, its book or
+// the stop orders, and returns whether it was there.
+func (m *Market) remove(o *order.Order) bool {
+	if _, ok := m.stops(o.Side).remove(o.ID); !ok {
+		book := m.buyBook
+		if o.Side == order.OrderSell {
+			book = m.sellBook
+		}
+
+		if err := book.Delete(o); err != nil {
+			return false
+		}
+	}
+
+	m.forget(o.ID)
+	return true
+}
+
+// forget drops what the market tracks about an order that left it.
+func (m *Market) forget(orderID string) {
+	m.expiries.remove(orderID)
+	m.pegs.remove(orderID)
+	delete(m.trailing, orderID)
+}
@@ -1,7 +1,6 @@
 package market_test
 
 import (
-	"exchange/engine/market"
 	"exchange/engine/order"
 	"exchange/engine/testutils"
 	"testing"
@@ -52,28 +51,30 @@ func Benchmark_Cancel(b *testing.B) {
 	}
 
 	for _, tc := range testCases {
-		b.Run(tc.name, func(b *testing.B) {
-			b.ReportAllocs()
+		for _, benchmarkMarket := range benchmarkMarkets {
+			b.Run(tc.name+"/"+benchmarkMarket.name, func(b *testing.B) {
+				b.ReportAllocs()
 
-			for range b.N {
-				b.StopTimer()
+				for range b.N {
+					b.StopTimer()
 
-				m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+					m := benchmarkMarket.new(pair, tracker)
 
-				for _, o := range tc.orders {
-					if err := m.InsertMakerOrder(o); err != nil {
-						b.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+					for _, o := range tc.orders {
+						if err := m.InsertMakerOrder(o); err != nil {
+							b.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+						}
 					}
-				}
 
-				b.StartTimer()
+					b.StartTimer()
 
-				for _, o := range tc.orders {
-					if err := m.Cancel(o); err != nil {
-						b.Fatalf("Cancel(%v) unexpected error: %v", o, err)
+					for _, o := range tc.orders {
+						if err := m.Cancel(o); err != nil {
+							b.Fatalf("Cancel(%v) unexpected error: %v", o, err)
+						}
 					}
 				}
-			}
-		})
+			})
+		}
 	}
 }
@@ -95,7 +95,7 @@ func Test_Cancel(t *testing.T) {
 			cancel:  &order.Order{Pair: pair, Price: 10, Side: order.OrderBuy, Volume: 10},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, Timestamp: time.Now()},
+				{Type: market.OrderRejected, Reason: market.RejectedNoOrderID, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -103,7 +103,7 @@ func Test_Cancel(t *testing.T) {
 			cancel:  &order.Order{Pair: "USD/BTC", ID: "1", Price: 10, Side: order.OrderBuy, Volume: 10},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedDifferentPair, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -111,7 +111,7 @@ func Test_Cancel(t *testing.T) {
 			cancel:  &order.Order{Pair: pair, ID: "1", Price: 0, Side: order.OrderBuy, Volume: 10},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedZeroPrice, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -119,7 +119,7 @@ func Test_Cancel(t *testing.T) {
 			cancel:  &order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 0},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedZeroVolume, Timestamp: time.Now()},
 			},
 		},
 	}
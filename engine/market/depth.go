@@ -0,0 +1,15 @@
+package market
+
+import "time"
+
+// Depth returns a snapshot of up to n levels per side, best prices first.
+//
+// O(n)
+func (m *Market) Depth(n int) *DepthEvent {
+	return &DepthEvent{
+		Pair:      m.pair,
+		Bids:      m.buyBook.Depth(n),
+		Asks:      m.sellBook.Depth(n),
+		Timestamp: time.Now(),
+	}
+}
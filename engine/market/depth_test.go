@@ -0,0 +1,53 @@
+package market_test
+
+import (
+	"testing"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Depth(t *testing.T) {
+	pair := "USD/BTC"
+	tracker := newEventsTracker(100)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	orders := []*order.Order{
+		{ID: "1", Pair: pair, Price: 10, Volume: 1, Side: order.OrderBuy},
+		{ID: "2", Pair: pair, Price: 9, Volume: 2, Side: order.OrderBuy},
+		{ID: "3", Pair: pair, Price: 10, Volume: 3, Side: order.OrderBuy},
+		{ID: "4", Pair: pair, Price: 8, Volume: 4, Side: order.OrderBuy},
+		{ID: "5", Pair: pair, Price: 11, Volume: 5, Side: order.OrderSell},
+		{ID: "6", Pair: pair, Price: 12, Volume: 6, Side: order.OrderSell},
+	}
+	for _, o := range orders {
+		if err := m.InsertMakerOrder(o); err != nil {
+			t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	want := &market.DepthEvent{
+		Pair: pair,
+		Bids: []orderbook.Level{
+			{Price: 10, Volume: 4, Orders: 2},
+			{Price: 9, Volume: 2, Orders: 1},
+		},
+		Asks: []orderbook.Level{
+			{Price: 11, Volume: 5, Orders: 1},
+			{Price: 12, Volume: 6, Orders: 1},
+		},
+	}
+
+	got := m.Depth(2)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(market.DepthEvent{}, "Timestamp")); diff != "" {
+		t.Errorf("Depth(2) diff (-want, +got):\n%s", diff)
+	}
+
+	if got.Timestamp.IsZero() {
+		t.Errorf("Depth(2) timestamp is zero")
+	}
+}
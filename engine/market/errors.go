@@ -5,3 +5,41 @@ import "errors"
 var (
 	InvalidOrderErr = errors.New("invalid order")
 )
+
+// Reasons given in OrderRejected events, meant to be shown to users.
+const (
+	RejectedNoOrderID     = "missing order ID"
+	RejectedDifferentPair = "order pair does not match the market"
+	RejectedZeroVolume    = "volume must be positive"
+	RejectedVolumeLot     = "volume is not a multiple of the lot size"
+	RejectedZeroPrice     = "price must be positive"
+	RejectedPriceRange    = "price is outside of the market range"
+	RejectedPriceTick     = "price is not a multiple of the tick size"
+	RejectedExpired       = "order expiry has already passed"
+	RejectedZeroStopPrice = "stop price must be positive"
+	RejectedLinkedOrder   = "a linked order was rejected"
+	RejectedCrossedLegs   = "linked orders would match each other"
+	RejectedBracketSide   = "bracket exit orders must be on the opposite side of the entry"
+	RejectedDuplicateLeg  = "linked orders must have different IDs"
+	RejectedNoPeg         = "pegged order without a peg"
+	RejectedNoPegPrice    = "no price to peg the order to"
+	RejectedInvalidTrail  = "trail must be either a positive amount or a percentage below 100%"
+	RejectedNoLastPrice   = "no trade price to trail yet"
+)
+
+// Reasons given in AmendRejected events, besides the price reasons of
+// OrderRejected events.
+const (
+	RejectedNotResting    = "only resting limit orders can be amended"
+	RejectedAmendVolume   = "volume must be above the filled volume"
+	RejectedAmendCrosses  = "amended price would match the opposite side"
+	RejectedAmendPegPrice = "the price of a pegged order follows the book"
+)
+
+// Reasons given in OrderCancelled events when the market cancels an order,
+// meant to be shown to users.
+const (
+	CancelledLinkedOrder  = "a linked order was filled or cancelled"
+	CancelledBracketEntry = "the bracket entry order was not filled"
+	CancelledMassCancel   = "cancelled with other orders at once"
+)
@@ -2,6 +2,7 @@ package market
 
 import (
 	"exchange/engine/order"
+	"exchange/engine/orderbook"
 	"time"
 )
 
@@ -33,6 +34,9 @@ type MatchEvent struct {
 	// The ID of the taker order.
 	TakerOrderID string
 
+	// The side of the taker order, the opposite of the maker order's side.
+	TakerSide order.OrderSide
+
 	// The match type of the taker order.
 	TakerMatchType order.MatchType
 
@@ -68,6 +72,27 @@ const (
 
 	// An taker order could not be fulfilled due to market insolvency.
 	TakerOrderUnfulfilled
+
+	// A resting order reached its expiry time and was removed from the book.
+	OrderExpired
+
+	// A stop order, or a leg of a bracket waiting for its entry order, was
+	// accepted and is held by the market outside of the books.
+	OrderPending
+
+	// A stop order reached its stop price and entered the market.
+	OrderTriggered
+
+	// A resting pegged order was moved to a new price, following the book, or
+	// a resting order was amended.
+	OrderAmended
+
+	// A mass cancel request was executed. The OrderID is the ID of the request,
+	// after the OrderCancelled events of each of the orders it cancelled.
+	MassCancelled
+
+	// An amendment was rejected, leaving the order as it was.
+	AmendRejected
 )
 
 // OrderEvent signals events related to order movements.
@@ -80,6 +105,38 @@ type OrderEvent struct {
 	// timeline.
 	OrderID string
 
+	// Why the order or its amendment was rejected, or why it was cancelled by
+	// the market instead of a user. Only set for OrderRejected, AmendRejected
+	// and OrderCancelled events.
+	Reason string
+
+	// The price of the order, only set for the OrderAmended events and the
+	// MakerOrderInserted events of pegged orders, whose price is set by the
+	// market.
+	Price uint64
+
+	// The total volume of an amended order, filled volume included. Only set
+	// for the OrderAmended events of amendments.
+	Volume uint64
+
+	// The number of orders cancelled, only set for MassCancelled events.
+	Cancelled int
+
 	// The time of the event.
 	Timestamp time.Time
 }
+
+// DepthEvent is a snapshot of the best levels of both sides of a market.
+type DepthEvent struct {
+	// The market pair name.
+	Pair string
+
+	// The buy side levels, from the highest price to the lowest.
+	Bids []orderbook.Level
+
+	// The sell side levels, from the lowest price to the highest.
+	Asks []orderbook.Level
+
+	// The time of the snapshot.
+	Timestamp time.Time
+}
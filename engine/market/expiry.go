@@ -0,0 +1,65 @@
+package market
+
+import (
+	"fmt"
+	"time"
+
+	"exchange/engine/order"
+)
+
+// newExpirySchedule returns a heap of resting orders by expiry time.
+func newExpirySchedule() *orderHeap {
+	return newOrderHeap(func(a, b *order.Order) bool {
+		return a.ExpiresAt.Before(b.ExpiresAt)
+	})
+}
+
+// Expire removes the resting and stop orders expiring at or before now, firing
+// an OrderExpired event for each one, and returns how many expired. Orders are
+// expired by expiry time, and in the sequence they were inserted for the same
+// expiry.
+//
+// The market has no clock of its own, so the same sequence of orders and
+// calls to Expire always produces the same events. Orders inserted later with
+// an expiry at or before now are rejected.
+//
+// O(k log n), where k is the number of orders expired.
+func (m *Market) Expire(now time.Time) int {
+	if now.After(m.expiredUntil) {
+		m.expiredUntil = now
+	}
+
+	expired := 0
+	for {
+		o, ok := m.expiries.peek()
+		if !ok || o.ExpiresAt.After(now) {
+			break
+		}
+
+		if !m.remove(o) {
+			// Every scheduled order rests in the market, unscheduled as soon
+			// as it is filled or cancelled.
+			m.expiries.remove(o.ID)
+			continue
+		}
+
+		m.orderEvents <- &OrderEvent{Type: OrderExpired, OrderID: o.ID, Timestamp: time.Now()}
+		m.react(o.ID, 0, true)
+		expired++
+	}
+
+	m.settle()
+
+	return expired
+}
+
+// validateExpiry rejects orders expiring at or before the latest time given to
+// Expire.
+func (m *Market) validateExpiry(o *order.Order) error {
+	if !o.ExpiresAt.IsZero() && !o.ExpiresAt.After(m.expiredUntil) {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedExpired, Timestamp: time.Now()}
+		return fmt.Errorf("market %q, order %q, expired at %v: %w", m.pair, o.ID, o.ExpiresAt, InvalidOrderErr)
+	}
+
+	return nil
+}
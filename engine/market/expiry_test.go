@@ -0,0 +1,192 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Expire(t *testing.T) {
+	pair := "USD/GBP"
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		setup            []*order.Order
+		cancel           []*order.Order
+		takers           []*order.Order
+		expire           time.Time
+		wantExpired      int
+		wantOrderEvents  []*market.OrderEvent
+		wantVolumeEvents []*market.VolumeEvent
+	}{
+		{
+			name: "expires_due_orders_in_expiry_order",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start.Add(2 * time.Hour)},
+				{Pair: pair, ID: "2", Price: 11, Side: order.OrderSell, Volume: 5, ExpiresAt: start.Add(time.Hour)},
+				{Pair: pair, ID: "3", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start.Add(3 * time.Hour)},
+			},
+			expire:      start.Add(2 * time.Hour),
+			wantExpired: 2,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderExpired, OrderID: "2", Timestamp: time.Now()},
+				{Type: market.OrderExpired, OrderID: "1", Timestamp: time.Now()},
+			},
+			wantVolumeEvents: []*market.VolumeEvent{
+				{Pair: pair, Side: order.OrderSell, Price: 11, Volume: 0, Timestamp: time.Now()},
+				{Pair: pair, Side: order.OrderBuy, Price: 10, Volume: 5, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "same_expiry_in_insertion_order",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start},
+				{Pair: pair, ID: "2", Price: 9, Side: order.OrderBuy, Volume: 5, ExpiresAt: start},
+				{Pair: pair, ID: "3", Price: 11, Side: order.OrderBuy, Volume: 5, ExpiresAt: start},
+			},
+			expire:      start,
+			wantExpired: 3,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderExpired, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderExpired, OrderID: "2", Timestamp: time.Now()},
+				{Type: market.OrderExpired, OrderID: "3", Timestamp: time.Now()},
+			},
+			wantVolumeEvents: []*market.VolumeEvent{
+				{Pair: pair, Side: order.OrderBuy, Price: 10, Volume: 0, Timestamp: time.Now()},
+				{Pair: pair, Side: order.OrderBuy, Price: 9, Volume: 0, Timestamp: time.Now()},
+				{Pair: pair, Side: order.OrderBuy, Price: 11, Volume: 0, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "nothing_due",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start.Add(time.Hour)},
+				{Pair: pair, ID: "2", Price: 10, Side: order.OrderBuy, Volume: 5},
+			},
+			expire: start,
+		},
+		{
+			name: "good_till_cancelled_never_expires",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+			},
+			expire: start.Add(24 * 365 * time.Hour),
+		},
+		{
+			name: "cancelled_order_unscheduled",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start},
+			},
+			cancel: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+			},
+			expire: start,
+		},
+		{
+			name: "filled_order_unscheduled",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderSell, Volume: 5, ExpiresAt: start},
+				{Pair: pair, ID: "2", Price: 10, Side: order.OrderSell, Volume: 5, ExpiresAt: start},
+			},
+			takers: []*order.Order{
+				{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 7},
+			},
+			expire:      start,
+			wantExpired: 1,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderExpired, OrderID: "2", Timestamp: time.Now()},
+			},
+			wantVolumeEvents: []*market.VolumeEvent{
+				{Pair: pair, Side: order.OrderSell, Price: 10, Volume: 0, Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			for _, o := range tc.setup {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			for _, o := range tc.cancel {
+				if err := m.Cancel(o); err != nil {
+					t.Fatalf("Cancel(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			for _, o := range tc.takers {
+				if err := m.MatchTakerOrder(o); err != nil {
+					t.Fatalf("MatchTakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			tracker.reset()
+
+			if got := m.Expire(tc.expire); got != tc.wantExpired {
+				t.Errorf("Expire(%v) want: %d, got: %d", tc.expire, tc.wantExpired, got)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("Expire(%v) order events diff (-want, +got):\n%s", tc.expire, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantVolumeEvents, tracker.volumeEvents, opts); diff != "" {
+				t.Errorf("Expire(%v) volume events diff (-want, +got):\n%s", tc.expire, diff)
+			}
+
+			if got := m.Expire(tc.expire); got != 0 {
+				t.Errorf("Expire(%v) again want: 0, got: %d", tc.expire, got)
+			}
+		})
+	}
+}
+
+func Test_InsertMakerOrder_Expired(t *testing.T) {
+	pair := "USD/GBP"
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	m.Expire(start)
+
+	for _, expiresAt := range []time.Time{start.Add(-time.Hour), start} {
+		o := &order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: expiresAt}
+		if err := m.InsertMakerOrder(o); err == nil {
+			t.Errorf("InsertMakerOrder(%v) want error, got nil", o)
+		}
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedExpired, Timestamp: time.Now()},
+		{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedExpired, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("InsertMakerOrder() order events diff (-want, +got):\n%s", diff)
+	}
+
+	o := &order.Order{Pair: pair, ID: "2", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start.Add(time.Nanosecond)}
+	if err := m.InsertMakerOrder(o); err != nil {
+		t.Errorf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+	}
+}
@@ -0,0 +1,317 @@
+package market
+
+import (
+	"exchange/engine/order"
+	"fmt"
+	"time"
+)
+
+// group is a set of linked orders. Once active, the legs are an OCO: any fill
+// or cancellation of one leg cancels the other. A bracket holds its legs back
+// until the entry order is done, and then activates them with the volume
+// filled by the entry.
+type group struct {
+	// The entry order of a bracket, nil for an OCO or an activated bracket.
+	entry *order.Order
+
+	// The volume filled by the entry order so far.
+	entryFilled uint64
+
+	// The linked orders.
+	legs [2]*order.Order
+}
+
+// other returns the leg linked to the given one.
+func (g *group) other(orderID string) *order.Order {
+	if g.legs[0].ID == orderID {
+		return g.legs[1]
+	}
+
+	return g.legs[0]
+}
+
+// reaction is a change to an order of a group, applied to the rest of the group
+// once the change that caused it finished firing its events.
+type reaction struct {
+	orderID string
+
+	// The volume filled.
+	volume uint64
+
+	// Whether the order left the market.
+	done bool
+}
+
+// InsertOCO places two orders linked as one-cancels-other: as soon as one of
+// them is filled, even partially, or cancelled, the other is cancelled with an
+// OrderCancelled event giving CancelledLinkedOrder as the reason. Each leg is
+// a limit order or a stop order, see InsertStopOrder.
+//
+// If a leg is rejected, both are.
+func (m *Market) InsertOCO(a *order.Order, b *order.Order) error {
+	if err := m.validateLegs(a, b); err != nil {
+		return fmt.Errorf("InsertOCO: %w", err)
+	}
+
+	if isLimit(a) && isLimit(b) && a.Side != b.Side && crosses(a, b) {
+		for _, o := range []*order.Order{a, b} {
+			m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedCrossedLegs, Timestamp: time.Now()}
+		}
+		return fmt.Errorf("InsertOCO: market %q, orders %q and %q cross: %w", m.pair, a.ID, b.ID, InvalidOrderErr)
+	}
+
+	g := &group{legs: [2]*order.Order{a, b}}
+	m.groups[a.ID] = g
+	m.groups[b.ID] = g
+
+	for _, o := range g.legs {
+		m.placeLeg(o)
+	}
+	m.settle()
+
+	return nil
+}
+
+// InsertBracket places a limit entry order with a take-profit limit order and
+// a stop-loss stop order, both on the opposite side of the entry. The legs
+// fire OrderPending events and are held back until the entry is done, filled
+// or cancelled. Then they are placed as an OCO for the volume the entry
+// filled, or cancelled with CancelledBracketEntry as the reason if it filled
+// none.
+//
+// If an order is rejected, all are.
+func (m *Market) InsertBracket(entry *order.Order, takeProfit *order.Order, stopLoss *order.Order) error {
+	if err := m.validateBracket(entry, takeProfit, stopLoss); err != nil {
+		return fmt.Errorf("InsertBracket: %w", err)
+	}
+
+	g := &group{entry: entry, legs: [2]*order.Order{takeProfit, stopLoss}}
+	m.groups[entry.ID] = g
+	for _, o := range g.legs {
+		m.groups[o.ID] = g
+		m.orderEvents <- &OrderEvent{Type: OrderPending, OrderID: o.ID, Timestamp: time.Now()}
+	}
+
+	err := m.insertMakerOrder(entry)
+	m.settle()
+
+	return err
+}
+
+func (m *Market) validateBracket(entry *order.Order, takeProfit *order.Order, stopLoss *order.Order) error {
+	orders := []*order.Order{entry, takeProfit, stopLoss}
+	validations := []func(*order.Order) error{m.validateLimitOrder, m.validateLimitOrder, m.validateStopOrder}
+
+	for i, o := range orders {
+		if err := validations[i](o); err != nil {
+			m.rejectLinked(orders, o)
+			return err
+		}
+	}
+
+	for _, o := range orders[1:] {
+		if o.Side == entry.Side {
+			m.rejectAll(orders, RejectedBracketSide)
+			return fmt.Errorf("market %q, order %q, same side as the entry order %q: %w", m.pair, o.ID, entry.ID, InvalidOrderErr)
+		}
+	}
+
+	if entry.ID == takeProfit.ID || entry.ID == stopLoss.ID || takeProfit.ID == stopLoss.ID {
+		m.rejectAll(orders, RejectedDuplicateLeg)
+		return fmt.Errorf("market %q, order %q, linked orders share an ID: %w", m.pair, entry.ID, InvalidOrderErr)
+	}
+
+	return nil
+}
+
+// validateLegs validates both legs of an OCO, rejecting both if either one is
+// invalid.
+func (m *Market) validateLegs(a *order.Order, b *order.Order) error {
+	orders := []*order.Order{a, b}
+	for _, o := range orders {
+		validate := m.validateLimitOrder
+		if o != nil && o.StopPrice > 0 {
+			validate = m.validateStopOrder
+		}
+
+		if err := validate(o); err != nil {
+			m.rejectLinked(orders, o)
+			return err
+		}
+	}
+
+	if a.ID == b.ID {
+		m.rejectAll(orders, RejectedDuplicateLeg)
+		return fmt.Errorf("market %q, order %q, linked orders share an ID: %w", m.pair, a.ID, InvalidOrderErr)
+	}
+
+	return nil
+}
+
+func (m *Market) validateLimitOrder(o *order.Order) error {
+	if err := m.validateOrder(o); err != nil {
+		return err
+	}
+
+	return m.validateExpiry(o)
+}
+
+// rejectLinked rejects the valid orders linked to a rejected one.
+func (m *Market) rejectLinked(orders []*order.Order, rejected *order.Order) {
+	for _, o := range orders {
+		if o != nil && o != rejected && o.ID != "" {
+			m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedLinkedOrder, Timestamp: time.Now()}
+		}
+	}
+}
+
+func (m *Market) rejectAll(orders []*order.Order, reason string) {
+	for _, o := range orders {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: reason, Timestamp: time.Now()}
+	}
+}
+
+func isLimit(o *order.Order) bool {
+	return o.StopPrice == 0
+}
+
+// crosses returns whether two limit orders on opposite sides would match each
+// other.
+func crosses(a *order.Order, b *order.Order) bool {
+	if a.Side == order.OrderSell {
+		a, b = b, a
+	}
+
+	return a.Price >= b.Price
+}
+
+// placeLeg places a leg of an active group in the market.
+func (m *Market) placeLeg(o *order.Order) {
+	if o.StopPrice > 0 {
+		m.holdStop(o)
+		return
+	}
+
+	// The error was already fired as an OrderRejected event.
+	_ = m.insertMakerOrder(o)
+}
+
+// react queues a reaction to a change in an order, if it belongs to a group.
+func (m *Market) react(orderID string, volume uint64, done bool) {
+	if len(m.groups) == 0 {
+		return
+	}
+
+	if _, ok := m.groups[orderID]; !ok {
+		return
+	}
+
+	m.reactions = append(m.reactions, reaction{orderID: orderID, volume: volume, done: done})
+}
+
+// settle applies the reactions of the groups and triggers the stop orders until
+// the market is stable, and then reprices the pegged orders. Reactions go
+// first, so a leg cancelled by a fill of the other leg never triggers.
+func (m *Market) settle() {
+	for {
+		for len(m.reactions) > 0 {
+			r := m.reactions[0]
+			m.reactions = m.reactions[1:]
+			m.handleReaction(r)
+		}
+
+		o, ok := m.nextTriggeredStop()
+		if !ok {
+			break
+		}
+
+		m.trigger(o)
+	}
+
+	if m.pegs.dirty && m.pegs.orders.Len() > 0 {
+		m.reprice()
+	}
+}
+
+func (m *Market) handleReaction(r reaction) {
+	g, ok := m.groups[r.orderID]
+	if !ok {
+		// The group was already resolved by the reaction of another order.
+		return
+	}
+
+	if g.entry != nil {
+		m.handleEntryReaction(g, r)
+		return
+	}
+
+	for _, o := range g.legs {
+		delete(m.groups, o.ID)
+	}
+
+	other := g.other(r.orderID)
+	if m.remove(other) {
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: other.ID, Reason: CancelledLinkedOrder, Timestamp: time.Now()}
+	}
+}
+
+// handleEntryReaction activates the legs of a bracket once its entry order is
+// done.
+func (m *Market) handleEntryReaction(g *group, r reaction) {
+	g.entryFilled += r.volume
+	if !r.done {
+		return
+	}
+
+	delete(m.groups, g.entry.ID)
+	g.entry = nil
+
+	if g.entryFilled == 0 {
+		for _, o := range g.legs {
+			delete(m.groups, o.ID)
+			m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Reason: CancelledBracketEntry, Timestamp: time.Now()}
+		}
+		return
+	}
+
+	for _, o := range g.legs {
+		o.Volume = g.entryFilled
+	}
+
+	for _, o := range g.legs {
+		if err := m.validateExpiry(o); err != nil {
+			m.react(o.ID, 0, true)
+			continue
+		}
+
+		m.placeLeg(o)
+	}
+}
+
+// cancelHeld cancels an order held by the market outside of the books, a stop
+// order or the leg of a bracket waiting for its entry, and returns whether it
+// was held. Cancelling a waiting leg cancels the other leg, and leaves the
+// entry as a plain order.
+func (m *Market) cancelHeld(o *order.Order) bool {
+	if g, ok := m.groups[o.ID]; ok && g.entry != nil && g.entry.ID != o.ID {
+		delete(m.groups, g.entry.ID)
+		for _, leg := range g.legs {
+			delete(m.groups, leg.ID)
+		}
+
+		other := g.other(o.ID)
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Timestamp: time.Now()}
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: other.ID, Reason: CancelledLinkedOrder, Timestamp: time.Now()}
+		return true
+	}
+
+	if _, ok := m.stops(o.Side).remove(o.ID); !ok {
+		return false
+	}
+	m.forget(o.ID)
+
+	m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Timestamp: time.Now()}
+	m.react(o.ID, 0, true)
+
+	return true
+}
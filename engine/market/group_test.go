@@ -0,0 +1,273 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_InsertOCO(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name            string
+		makers          []*order.Order
+		legs            [2]*order.Order
+		wantErr         bool
+		act             func(m *market.Market) error
+		wantOrderEvents []*market.OrderEvent
+	}{
+		{
+			name: "fill_cancels_other_leg",
+			legs: [2]*order.Order{
+				{Pair: pair, ID: "1", Price: 12, Side: order.OrderSell, Volume: 5},
+				{Pair: pair, ID: "2", StopPrice: 8, Side: order.OrderSell, Volume: 5},
+			},
+			act: func(m *market.Market) error {
+				return m.MatchTakerOrder(&order.Order{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 2})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledLinkedOrder, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "cancel_cancels_other_leg",
+			legs: [2]*order.Order{
+				{Pair: pair, ID: "1", Price: 12, Side: order.OrderSell, Volume: 5},
+				{Pair: pair, ID: "2", StopPrice: 8, Side: order.OrderSell, Volume: 5},
+			},
+			act: func(m *market.Market) error {
+				return m.Cancel(&order.Order{Pair: pair, ID: "1", Price: 12, Side: order.OrderSell, Volume: 5})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledLinkedOrder, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "triggered_stop_cancels_other_leg",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 8, Side: order.OrderBuy, Volume: 10},
+			},
+			legs: [2]*order.Order{
+				{Pair: pair, ID: "2", Price: 12, Side: order.OrderSell, Volume: 5},
+				{Pair: pair, ID: "3", StopPrice: 8, Side: order.OrderSell, Volume: 5},
+			},
+			act: func(m *market.Market) error {
+				return m.MatchTakerOrder(&order.Order{Pair: pair, ID: "4", Side: order.OrderSell, Volume: 1})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderTriggered, OrderID: "3", Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledLinkedOrder, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "crossed_legs_rejected",
+			legs: [2]*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "2", Price: 9, Side: order.OrderSell, Volume: 5},
+			},
+			wantErr: true,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedCrossedLegs, Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "2", Reason: market.RejectedCrossedLegs, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "invalid_leg_rejects_both",
+			legs: [2]*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "2", StopPrice: 12, Side: order.OrderBuy},
+			},
+			wantErr: true,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderRejected, OrderID: "2", Reason: market.RejectedZeroVolume, Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedLinkedOrder, Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			for _, o := range tc.makers {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			tracker.reset()
+
+			err := m.InsertOCO(tc.legs[0], tc.legs[1])
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("InsertOCO() want error: %v, got: %v", tc.wantErr, err)
+			}
+
+			if tc.act != nil {
+				tracker.reset()
+
+				if err := tc.act(m); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("order events diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_InsertBracket(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name            string
+		act             func(m *market.Market) error
+		wantOrderEvents []*market.OrderEvent
+		wantTakeProfit  uint64
+	}{
+		{
+			name: "entry_fill_activates_legs",
+			act: func(m *market.Market) error {
+				return m.MatchTakerOrder(&order.Order{Pair: pair, ID: "4", Side: order.OrderSell, Volume: 5})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "2", Timestamp: time.Now()},
+				{Type: market.OrderPending, OrderID: "3", Timestamp: time.Now()},
+			},
+			wantTakeProfit: 5,
+		},
+		{
+			name: "partial_fill_activates_legs_when_entry_done",
+			act: func(m *market.Market) error {
+				if err := m.MatchTakerOrder(&order.Order{Pair: pair, ID: "4", Side: order.OrderSell, Volume: 3}); err != nil {
+					return err
+				}
+
+				return m.Cancel(&order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.MakerOrderInserted, OrderID: "2", Timestamp: time.Now()},
+				{Type: market.OrderPending, OrderID: "3", Timestamp: time.Now()},
+			},
+			wantTakeProfit: 3,
+		},
+		{
+			name: "unfilled_entry_cancels_legs",
+			act: func(m *market.Market) error {
+				return m.Cancel(&order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledBracketEntry, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledBracketEntry, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "cancel_waiting_leg_cancels_other_leg",
+			act: func(m *market.Market) error {
+				return m.Cancel(&order.Order{Pair: pair, ID: "3", StopPrice: 8, Side: order.OrderSell})
+			},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "3", Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledLinkedOrder, Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			entry := &order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5}
+			takeProfit := &order.Order{Pair: pair, ID: "2", Price: 12, Side: order.OrderSell, Volume: 5}
+			stopLoss := &order.Order{Pair: pair, ID: "3", StopPrice: 8, Side: order.OrderSell, Volume: 5}
+			if err := m.InsertBracket(entry, takeProfit, stopLoss); err != nil {
+				t.Fatalf("InsertBracket() unexpected error: %v", err)
+			}
+
+			tracker.flush()
+
+			want := []*market.OrderEvent{
+				{Type: market.OrderPending, OrderID: "2", Timestamp: time.Now()},
+				{Type: market.OrderPending, OrderID: "3", Timestamp: time.Now()},
+				{Type: market.MakerOrderInserted, OrderID: "1", Timestamp: time.Now()},
+			}
+			if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+				t.Errorf("InsertBracket() order events diff (-want, +got):\n%s", diff)
+			}
+
+			tracker.reset()
+
+			if err := tc.act(m); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("order events diff (-want, +got):\n%s", diff)
+			}
+
+			if tc.wantTakeProfit == 0 {
+				return
+			}
+
+			resting, err := m.RestingOrder(takeProfit)
+			if err != nil {
+				t.Fatalf("RestingOrder(%v) unexpected error: %v", takeProfit, err)
+			}
+
+			if resting.OriginalVolume != tc.wantTakeProfit {
+				t.Errorf("take profit volume want: %d, got: %d", tc.wantTakeProfit, resting.OriginalVolume)
+			}
+		})
+	}
+}
+
+func Test_InsertBracket_Rejected(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	entry := &order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5}
+	takeProfit := &order.Order{Pair: pair, ID: "2", Price: 12, Side: order.OrderBuy, Volume: 5}
+	stopLoss := &order.Order{Pair: pair, ID: "3", StopPrice: 8, Side: order.OrderSell, Volume: 5}
+	if err := m.InsertBracket(entry, takeProfit, stopLoss); err == nil {
+		t.Errorf("InsertBracket() want error, got nil")
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedBracketSide, Timestamp: time.Now()},
+		{Type: market.OrderRejected, OrderID: "2", Reason: market.RejectedBracketSide, Timestamp: time.Now()},
+		{Type: market.OrderRejected, OrderID: "3", Reason: market.RejectedBracketSide, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("InsertBracket() order events diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+package market
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+
+	"exchange/engine/order"
+)
+
+// heapEntry is an order in an orderHeap.
+type heapEntry struct {
+	order *order.Order
+
+	// Breaks ties between orders that are equal by the heap order, in the
+	// sequence they were pushed.
+	sequence uint64
+}
+
+// orderHeap is a heap of orders with the position of each one, to remove
+// orders by ID when they leave the market.
+type orderHeap struct {
+	entries  []heapEntry
+	index    map[string]int
+	sequence uint64
+
+	// Whether a goes before b.
+	less func(a, b *order.Order) bool
+}
+
+func newOrderHeap(less func(a, b *order.Order) bool) *orderHeap {
+	return &orderHeap{index: map[string]int{}, less: less}
+}
+
+func (h *orderHeap) Len() int {
+	return len(h.entries)
+}
+
+func (h *orderHeap) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if h.less(a.order, b.order) {
+		return true
+	}
+
+	if h.less(b.order, a.order) {
+		return false
+	}
+
+	return a.sequence < b.sequence
+}
+
+func (h *orderHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.index[h.entries[i].order.ID] = i
+	h.index[h.entries[j].order.ID] = j
+}
+
+func (h *orderHeap) Push(x any) {
+	e := x.(heapEntry)
+	h.index[e.order.ID] = len(h.entries)
+	h.entries = append(h.entries, e)
+}
+
+func (h *orderHeap) Pop() any {
+	e := h.entries[len(h.entries)-1]
+	h.entries[len(h.entries)-1] = heapEntry{}
+	h.entries = h.entries[:len(h.entries)-1]
+	delete(h.index, e.order.ID)
+
+	return e
+}
+
+// push adds an order.
+//
+// O(log n)
+func (h *orderHeap) push(o *order.Order) {
+	h.sequence++
+	heap.Push(h, heapEntry{order: o, sequence: h.sequence})
+}
+
+// remove removes an order by ID, and returns it if it was in the heap.
+//
+// O(log n)
+func (h *orderHeap) remove(orderID string) (*order.Order, bool) {
+	i, ok := h.index[orderID]
+	if !ok {
+		return nil, false
+	}
+
+	return heap.Remove(h, i).(heapEntry).order, true
+}
+
+// fix restores the order of the heap after an order in it changed.
+//
+// O(log n)
+func (h *orderHeap) fix(orderID string) {
+	if i, ok := h.index[orderID]; ok {
+		heap.Fix(h, i)
+	}
+}
+
+// peek returns the first order, if any.
+//
+// O(1)
+func (h *orderHeap) peek() (*order.Order, bool) {
+	if len(h.entries) == 0 {
+		return nil, false
+	}
+
+	return h.entries[0].order, true
+}
+
+// orders returns the orders of the heap in the sequence they were pushed, so
+// that pushing them again in a new heap keeps the order between equal ones.
+//
+// O(n log n)
+func (h *orderHeap) orders() []*order.Order {
+	entries := slices.Clone(h.entries)
+	slices.SortFunc(entries, func(a, b heapEntry) int {
+		return cmp.Compare(a.sequence, b.sequence)
+	})
+
+	orders := make([]*order.Order, len(entries))
+	for i, e := range entries {
+		orders[i] = e.order
+	}
+
+	return orders
+}
@@ -14,6 +14,21 @@ func (m *Market) InsertMakerOrder(o *order.Order) error {
 		return fmt.Errorf("InsertMakerOrder: %w", err)
 	}
 
+	if err := m.validateExpiry(o); err != nil {
+		return fmt.Errorf("InsertMakerOrder: %w", err)
+	}
+
+	if err := m.insertMakerOrder(o); err != nil {
+		return err
+	}
+
+	m.settle()
+	return nil
+}
+
+// insertMakerOrder places a validated maker order, without settling the
+// market.
+func (m *Market) insertMakerOrder(o *order.Order) error {
 	var makerBook *orderbook.OrderBook
 	if o.Side == order.OrderBuy {
 		if headPrice := m.sellBook.HeadPrice(); headPrice != 0 && o.Price >= headPrice {
@@ -32,10 +47,21 @@ func (m *Market) InsertMakerOrder(o *order.Order) error {
 	}
 
 	if err := makerBook.Insert(o); err != nil {
-		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Timestamp: time.Now()}
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: err.Error(), Timestamp: time.Now()}
+		m.react(o.ID, 0, true)
 		return err
 	}
 
-	m.orderEvents <- &OrderEvent{Type: MakerOrderInserted, OrderID: o.ID, Timestamp: time.Now()}
+	if !o.ExpiresAt.IsZero() {
+		m.expiries.push(o)
+	}
+
+	ev := &OrderEvent{Type: MakerOrderInserted, OrderID: o.ID, Timestamp: time.Now()}
+	if o.Peg != nil {
+		// The price is set by the market.
+		ev.Price = o.Price
+	}
+
+	m.orderEvents <- ev
 	return nil
 }
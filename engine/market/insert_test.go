@@ -4,6 +4,7 @@ import (
 	"errors"
 	"exchange/engine/market"
 	"exchange/engine/order"
+	"exchange/engine/orderbook"
 	"testing"
 	"time"
 
@@ -58,7 +59,7 @@ func Test_InsertMakerOrder(t *testing.T) {
 				{Pair: pair, Side: order.OrderBuy, Price: 10, Volume: 5, Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 10, MatchedVolume: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 10, MatchedVolume: 10, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -71,7 +72,7 @@ func Test_InsertMakerOrder(t *testing.T) {
 				{Pair: pair, Side: order.OrderSell, Price: 10, Volume: 5, Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 10, MatchedVolume: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 10, MatchedVolume: 10, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -88,9 +89,9 @@ func Test_InsertMakerOrder(t *testing.T) {
 				{Pair: pair, Side: order.OrderBuy, Price: 8, Volume: 5, Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 9, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 8, MatchedVolume: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 9, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 8, MatchedVolume: 10, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -107,9 +108,9 @@ func Test_InsertMakerOrder(t *testing.T) {
 				{Pair: pair, Side: order.OrderSell, Price: 12, Volume: 5, Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 11, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 12, MatchedVolume: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 11, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 12, MatchedVolume: 10, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -122,7 +123,7 @@ func Test_InsertMakerOrder(t *testing.T) {
 			insert:  &order.Order{Pair: pair, Price: 10, Side: order.OrderBuy, Volume: 10},
 			wantErr: market.InvalidOrderErr,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, Timestamp: time.Now()},
+				{Type: market.OrderRejected, Reason: market.RejectedNoOrderID, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -130,7 +131,7 @@ func Test_InsertMakerOrder(t *testing.T) {
 			insert:  &order.Order{Pair: "USD/BTC", ID: "1", Price: 10, Side: order.OrderBuy, Volume: 10},
 			wantErr: market.InvalidOrderErr,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedDifferentPair, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -138,7 +139,7 @@ func Test_InsertMakerOrder(t *testing.T) {
 			insert:  &order.Order{Pair: pair, ID: "1", Price: 0, Side: order.OrderBuy, Volume: 10},
 			wantErr: market.InvalidOrderErr,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedZeroPrice, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -146,7 +147,7 @@ func Test_InsertMakerOrder(t *testing.T) {
 			insert:  &order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 0},
 			wantErr: market.InvalidOrderErr,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedZeroVolume, Timestamp: time.Now()},
 			},
 		},
 	}
@@ -189,3 +190,166 @@ func Test_InsertMakerOrder(t *testing.T) {
 		})
 	}
 }
+
+func Test_InsertMakerOrder_PriceRange(t *testing.T) {
+	tracker := newEventsTracker(10)
+
+	pair := "USD/GBP"
+	prices := orderbook.PriceRange{Min: 10, Max: 100, Tick: 5}
+
+	testCases := []struct {
+		name             string
+		setup            []*order.Order
+		insert           *order.Order
+		wantErr          error
+		wantOrderEvents  []*market.OrderEvent
+		wantVolumeEvents []*market.VolumeEvent
+		wantMatchEvents  []*market.MatchEvent
+	}{
+		{
+			name:   "on_tick",
+			insert: &order.Order{Pair: pair, ID: "1", Price: 55, Side: order.OrderBuy, Volume: 10},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "1", Timestamp: time.Now()},
+			},
+			wantVolumeEvents: []*market.VolumeEvent{
+				{Pair: pair, Side: order.OrderBuy, Price: 55, Volume: 10, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:    "off_tick",
+			insert:  &order.Order{Pair: pair, ID: "1", Price: 56, Side: order.OrderBuy, Volume: 10},
+			wantErr: market.InvalidOrderErr,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedPriceTick, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:    "above_range",
+			insert:  &order.Order{Pair: pair, ID: "1", Price: 105, Side: order.OrderSell, Volume: 10},
+			wantErr: market.InvalidOrderErr,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedPriceRange, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "crossing_out_of_range",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 20, Side: order.OrderSell, Volume: 10},
+			},
+			insert:  &order.Order{Pair: pair, ID: "2", Price: 200, Side: order.OrderBuy, Volume: 10},
+			wantErr: market.InvalidOrderErr,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderRejected, OrderID: "2", Reason: market.RejectedPriceRange, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "max_price_below_ask",
+			setup: []*order.Order{
+				{Pair: pair, ID: "1", Price: 100, Side: order.OrderSell, Volume: 10},
+			},
+			insert: &order.Order{Pair: pair, ID: "2", Price: 95, Side: order.OrderBuy, Volume: 10},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "2", Timestamp: time.Now()},
+			},
+			wantVolumeEvents: []*market.VolumeEvent{
+				{Pair: pair, Side: order.OrderBuy, Price: 95, Volume: 10, Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := market.NewWithPriceRange(pair, prices, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+			if err != nil {
+				t.Fatalf("NewWithPriceRange() unexpected error: %v", err)
+			}
+
+			for _, o := range tc.setup {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			tracker.reset()
+
+			err = m.InsertMakerOrder(tc.insert)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("InsertMakerOrder unexpected error, want: %v, got: %v", tc.wantErr, err)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("InsertMakerOrder order events diff:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantVolumeEvents, tracker.volumeEvents, opts); diff != "" {
+				t.Errorf("InsertMakerOrder volume events diff:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantMatchEvents, tracker.matchEvents, opts); diff != "" {
+				t.Errorf("InsertMakerOrder match events diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_InsertMakerOrder_LotSize(t *testing.T) {
+	tracker := newEventsTracker(10)
+
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name            string
+		insert          *order.Order
+		wantErr         error
+		wantOrderEvents []*market.OrderEvent
+	}{
+		{
+			name:   "on_lot",
+			insert: &order.Order{Pair: pair, ID: "1", Price: 55, Side: order.OrderBuy, Volume: 20},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "1", Timestamp: time.Now()},
+			},
+		},
+		{
+			name:    "off_lot",
+			insert:  &order.Order{Pair: pair, ID: "1", Price: 55, Side: order.OrderBuy, Volume: 25},
+			wantErr: market.InvalidOrderErr,
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedVolumeLot, Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+			m.SetLotSize(10)
+
+			tracker.reset()
+
+			err := m.InsertMakerOrder(tc.insert)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("InsertMakerOrder unexpected error, want: %v, got: %v", tc.wantErr, err)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("InsertMakerOrder order events diff:\n%s", diff)
+			}
+		})
+	}
+}
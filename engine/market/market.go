@@ -3,8 +3,10 @@ package market
 import (
 	"exchange/engine/order"
 	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
+	"exchange/engine/orderbook/pricelevel"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -25,26 +27,137 @@ type Market struct {
 
 	// Events triggered when two orders are matched.
 	matchEvents chan<- *MatchEvent
+
+	// Reused by each taker order to hold its matches.
+	matches []order.Match
+
+	// The resting orders with an expiry, by expiry time.
+	expiries *orderHeap
+
+	// The latest time given to Expire. Orders expiring at or before it are
+	// rejected.
+	expiredUntil time.Time
+
+	// The stop orders waiting for their stop price, in the order they trigger.
+	buyStops  *orderHeap
+	sellStops *orderHeap
+
+	// The price of the last match, which triggers the stop orders.
+	lastPrice uint64
+
+	// The held stop orders with a trail, by ID.
+	trailing map[string]*trailingStop
+
+	// The pegged orders resting in the books.
+	pegs *pegs
+
+	// The linked groups, by the ID of each of their orders.
+	groups map[string]*group
+
+	// The changes to orders of groups not yet applied to the rest of their
+	// group.
+	reactions []reaction
+
+	// The prices accepted by a market created with NewWithPriceRange, nil if
+	// any price is accepted.
+	prices *orderbook.PriceRange
+
+	// Volumes must be multiples of it, any volume is accepted when 0.
+	lotSize uint64
+
+	// The last published view, the only field safe for concurrent reads.
+	view atomic.Pointer[View]
+
+	// Whether the books changed since the last published view.
+	viewDirty bool
+
+	// The levels per side of the last published view, and its sequence.
+	viewLevels   int
+	viewSequence uint64
+
+	// Whether a snapshot is being restored, which fires no events.
+	restoring bool
 }
 
+// New returns a market accepting any price, with its levels indexed in
+// red-black trees.
 func New(pair string, orderEvents chan<- *OrderEvent, volumeEvents chan<- *VolumeEvent, matchEvents chan<- *MatchEvent) *Market {
 	pool := &sync.Pool{
 		New: func() any {
-			return rbtree.NewNode()
+			return orderbook.NewPriceNode()
 		},
 	}
 
+	return newMarket(pair, orderbook.NewTreeIndex(order.OrderBuy, pool), orderbook.NewTreeIndex(order.OrderSell, pool), orderEvents, volumeEvents, matchEvents)
+}
+
+// NewWithPriceRange returns a market accepting only the prices of the range,
+// with its levels indexed in arrays by tick. Orders at any other price are
+// rejected.
+func NewWithPriceRange(pair string, prices orderbook.PriceRange, orderEvents chan<- *OrderEvent, volumeEvents chan<- *VolumeEvent, matchEvents chan<- *MatchEvent) (*Market, error) {
+	buyIndex, err := orderbook.NewTickIndex(order.OrderBuy, prices)
+	if err != nil {
+		return nil, fmt.Errorf("market %q: %w", pair, err)
+	}
+
+	sellIndex, err := orderbook.NewTickIndex(order.OrderSell, prices)
+	if err != nil {
+		return nil, fmt.Errorf("market %q: %w", pair, err)
+	}
+
+	m := newMarket(pair, buyIndex, sellIndex, orderEvents, volumeEvents, matchEvents)
+	m.prices = &prices
+
+	return m, nil
+}
+
+// SetAllocator sets how the volume matched at each price is split between the
+// maker orders, on both sides. Nil restores the default, FIFO. It must be
+// called before matching any order.
+func (m *Market) SetAllocator(allocator pricelevel.Allocator) {
+	m.buyBook.SetAllocator(allocator)
+	m.sellBook.SetAllocator(allocator)
+}
+
+// SetLotSize sets the lot size order volumes must be multiples of. Zero
+// accepts any volume. It must be called before inserting any order.
+func (m *Market) SetLotSize(lotSize uint64) {
+	m.lotSize = lotSize
+}
+
+func newMarket(pair string, buyIndex orderbook.PriceIndex, sellIndex orderbook.PriceIndex, orderEvents chan<- *OrderEvent, volumeEvents chan<- *VolumeEvent, matchEvents chan<- *MatchEvent) *Market {
 	m := &Market{
 		pair:        pair,
 		orderEvents: orderEvents,
 		matchEvents: matchEvents,
-		buyBook: orderbook.New(order.OrderBuy, pool, func(price uint64, volume uint64) {
-			volumeEvents <- &VolumeEvent{Pair: pair, Side: order.OrderBuy, Price: price, Volume: volume, Timestamp: time.Now()}
-		}),
-		sellBook: orderbook.New(order.OrderSell, pool, func(price uint64, volume uint64) {
-			volumeEvents <- &VolumeEvent{Pair: pair, Side: order.OrderSell, Price: price, Volume: volume, Timestamp: time.Now()}
-		}),
+		expiries:    newExpirySchedule(),
+		buyStops:    newStopOrders(order.OrderBuy),
+		sellStops:   newStopOrders(order.OrderSell),
+		trailing:    map[string]*trailingStop{},
+		groups:      map[string]*group{},
+		pegs:        newPegs(),
 	}
 
+	m.buyBook = orderbook.NewWithIndex(order.OrderBuy, buyIndex, func(price uint64, volume uint64) {
+		if m.restoring {
+			return
+		}
+
+		m.viewDirty = true
+		m.pegs.dirty = true
+		volumeEvents <- &VolumeEvent{Pair: pair, Side: order.OrderBuy, Price: price, Volume: volume, Timestamp: time.Now()}
+	})
+	m.sellBook = orderbook.NewWithIndex(order.OrderSell, sellIndex, func(price uint64, volume uint64) {
+		if m.restoring {
+			return
+		}
+
+		m.viewDirty = true
+		m.pegs.dirty = true
+		volumeEvents <- &VolumeEvent{Pair: pair, Side: order.OrderSell, Price: price, Volume: volume, Timestamp: time.Now()}
+	})
+
+	m.view.Store(&View{Pair: pair, Bids: []orderbook.Level{}, Asks: []orderbook.Level{}, Timestamp: time.Now()})
+
 	return m
 }
@@ -3,6 +3,7 @@ package market_test
 import (
 	"exchange/engine/market"
 	"exchange/engine/order"
+	"exchange/engine/orderbook"
 	"fmt"
 	"testing"
 )
@@ -105,6 +106,31 @@ func (e *eventsTracker) ignoreAll() {
 	}()
 }
 
+// benchmarkMarkets are the price indices compared by the benchmarks. Ticks
+// cover the prices of every benchmark case.
+var benchmarkMarkets = []struct {
+	name string
+	new  func(pair string, tracker *eventsTracker) *market.Market
+}{
+	{
+		name: "tree",
+		new: func(pair string, tracker *eventsTracker) *market.Market {
+			return market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+		},
+	},
+	{
+		name: "ticks",
+		new: func(pair string, tracker *eventsTracker) *market.Market {
+			prices := orderbook.PriceRange{Min: 1, Max: 1_100_000, Tick: 1}
+			m, err := market.NewWithPriceRange(pair, prices, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+			if err != nil {
+				panic(err)
+			}
+			return m
+		},
+	},
+}
+
 func Benchmark_PriceDeletionAndInsertion(b *testing.B) {
 	// On this test we expect allocations to happen only for the triggered events
 	// not for the creation or price nodes.
@@ -152,50 +178,52 @@ func Benchmark_PriceDeletionAndInsertion(b *testing.B) {
 	}
 
 	for _, tc := range testCases {
-		b.Run(tc.name, func(b *testing.B) {
-			b.ReportAllocs()
-
-			pair := "USD/BTC"
-			sellBoundary := uint64(1_000_010)
-			buyBoundary := uint64(1_000_005)
-			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
-
-			buyOrders, sellOrders := []*order.Order{}, []*order.Order{}
-			for i := range tc.depth {
-				buyOrder := &order.Order{ID: fmt.Sprintf("buy-%d", i), Pair: pair, Price: buyBoundary - i, Side: order.OrderBuy, Volume: 1}
-				sellOrder := &order.Order{ID: fmt.Sprintf("sell-%d", i), Pair: pair, Price: sellBoundary + i, Side: order.OrderSell, Volume: 1}
+		for _, benchmarkMarket := range benchmarkMarkets {
+			b.Run(tc.name+"/"+benchmarkMarket.name, func(b *testing.B) {
+				b.ReportAllocs()
 
-				buyOrders = append(buyOrders, buyOrder)
-				sellOrders = append(sellOrders, sellOrder)
+				pair := "USD/BTC"
+				sellBoundary := uint64(1_000_010)
+				buyBoundary := uint64(1_000_005)
+				m := benchmarkMarket.new(pair, tracker)
 
-				// Start only with all the buy orders
-				m.InsertMakerOrder(buyOrder)
-			}
-
-			b.ResetTimer()
+				buyOrders, sellOrders := []*order.Order{}, []*order.Order{}
+				for i := range tc.depth {
+					buyOrder := &order.Order{ID: fmt.Sprintf("buy-%d", i), Pair: pair, Price: buyBoundary - i, Side: order.OrderBuy, Volume: 1}
+					sellOrder := &order.Order{ID: fmt.Sprintf("sell-%d", i), Pair: pair, Price: sellBoundary + i, Side: order.OrderSell, Volume: 1}
 
-			for range b.N {
-				for _, o := range buyOrders {
-					// Delete buy orders to fill the price node pool
-					m.Cancel(o)
-				}
+					buyOrders = append(buyOrders, buyOrder)
+					sellOrders = append(sellOrders, sellOrder)
 
-				for _, o := range sellOrders {
-					// Insert the other side so that they use the price node pool
-					m.InsertMakerOrder(o)
+					// Start only with all the buy orders
+					m.InsertMakerOrder(buyOrder)
 				}
 
-				for _, o := range sellOrders {
-					// Delete order to re-fill the price node pool
-					m.Cancel(o)
+				b.ResetTimer()
+
+				for range b.N {
+					for _, o := range buyOrders {
+						// Delete buy orders to fill the price node pool
+						m.Cancel(o)
+					}
+
+					for _, o := range sellOrders {
+						// Insert the other side so that they use the price node pool
+						m.InsertMakerOrder(o)
+					}
+
+					for _, o := range sellOrders {
+						// Delete order to re-fill the price node pool
+						m.Cancel(o)
+					}
+
+					for _, o := range buyOrders {
+						// Repopulate the buy side to get price nodes from the sync pool and
+						// come back to the initial state
+						m.InsertMakerOrder(o)
+					}
 				}
-
-				for _, o := range buyOrders {
-					// Repopulate the buy side to get price nodes from the sync pool and
-					// come back to the initial state
-					m.InsertMakerOrder(o)
-				}
-			}
-		})
+			})
+		}
 	}
 }
@@ -0,0 +1,142 @@
+package market
+
+import (
+	"time"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+)
+
+// CancelFilter selects the orders cancelled by CancelAll. The zero value
+// selects every order.
+type CancelFilter struct {
+	// Only the orders of the account, if set.
+	Account string
+
+	// Only the orders tagged with the session, if set.
+	Session string
+
+	// Only the orders of the side, if set.
+	Side *order.OrderSide
+
+	// Only the orders with a price in the range, if either bound is set.
+	// Orders without a price, market stop orders, are never in a range.
+	MinPrice uint64
+	MaxPrice uint64
+}
+
+func (f CancelFilter) matches(o *order.Order) bool {
+	if f.Account != "" && o.Account != f.Account {
+		return false
+	}
+
+	if f.Session != "" && o.Session != f.Session {
+		return false
+	}
+
+	if f.Side != nil && o.Side != *f.Side {
+		return false
+	}
+
+	if f.MinPrice == 0 && f.MaxPrice == 0 {
+		return true
+	}
+
+	if o.Price == 0 || o.Price < f.MinPrice {
+		return false
+	}
+
+	return f.MaxPrice == 0 || o.Price <= f.MaxPrice
+}
+
+// CancelAll cancels every resting and stop order selected by the filter at
+// once, firing an OrderCancelled event for each one with CancelledMassCancel as
+// the reason: the buy book from its best price, then the sell book, the stop
+// orders and the legs of brackets waiting for their entry. It then fires a
+// MassCancelled event for the request with the number of orders cancelled, and
+// returns it.
+//
+// The waiting legs of a cancelled entry are cancelled with it, so that they are
+// never placed. A waiting leg selected without its entry cancels the other leg
+// with CancelledLinkedOrder as the reason, after the MassCancelled event, and
+// leaves the entry as a plain order, as with Cancel.
+//
+// Nothing is matched or triggered until every selected order is cancelled.
+// Then the orders linked to them react as if they were cancelled one by one,
+// see Cancel.
+//
+// O(n), where n is the number of orders in the market.
+func (m *Market) CancelAll(requestID string, filter CancelFilter) int {
+	var cancelled []*order.Order
+
+	// The brackets waiting for their entry, which rests in a book.
+	var brackets []*group
+	for _, book := range []*orderbook.OrderBook{m.buyBook, m.sellBook} {
+		for o := range book.Orders() {
+			if filter.matches(o) {
+				cancelled = append(cancelled, o)
+			}
+
+			if g, ok := m.groups[o.ID]; ok && g.entry == o {
+				brackets = append(brackets, g)
+			}
+		}
+	}
+
+	for _, stops := range []*orderHeap{m.buyStops, m.sellStops} {
+		for _, e := range stops.entries {
+			if filter.matches(e.order) {
+				cancelled = append(cancelled, e.order)
+			}
+		}
+	}
+
+	// The legs of the brackets are dropped before any order is cancelled, so
+	// that the entries don't place them.
+	var held, linked []*order.Order
+	for _, g := range brackets {
+		entry := filter.matches(g.entry)
+
+		var selected, others []*order.Order
+		for _, leg := range g.legs {
+			if entry || filter.matches(leg) {
+				selected = append(selected, leg)
+			} else {
+				others = append(others, leg)
+			}
+		}
+
+		if len(selected) == 0 {
+			continue
+		}
+
+		delete(m.groups, g.entry.ID)
+		for _, leg := range g.legs {
+			delete(m.groups, leg.ID)
+		}
+
+		held = append(held, selected...)
+		linked = append(linked, others...)
+	}
+
+	for _, o := range cancelled {
+		m.remove(o)
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Reason: CancelledMassCancel, Timestamp: time.Now()}
+		m.react(o.ID, 0, true)
+	}
+
+	for _, o := range held {
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Reason: CancelledMassCancel, Timestamp: time.Now()}
+	}
+
+	count := len(cancelled) + len(held)
+	m.orderEvents <- &OrderEvent{Type: MassCancelled, OrderID: requestID, Cancelled: count, Timestamp: time.Now()}
+
+	for _, o := range linked {
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Reason: CancelledLinkedOrder, Timestamp: time.Now()}
+	}
+
+	m.settle()
+
+	return count
+}
@@ -0,0 +1,246 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_CancelAll(t *testing.T) {
+	pair := "USD/GBP"
+	buy, sell := order.OrderBuy, order.OrderSell
+
+	testCases := []struct {
+		name            string
+		filter          market.CancelFilter
+		wantOrderEvents []*market.OrderEvent
+	}{
+		{
+			name:   "everything",
+			filter: market.CancelFilter{},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "1", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "4", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "5", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 5, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:   "account",
+			filter: market.CancelFilter{Account: "a"},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "1", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "5", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 3, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:   "side",
+			filter: market.CancelFilter{Side: &sell},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "4", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "5", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 3, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:   "price_range_skips_market_stops",
+			filter: market.CancelFilter{MinPrice: 10, MaxPrice: 20},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "1", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 3, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:   "session",
+			filter: market.CancelFilter{Session: "s"},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 1, Timestamp: time.Now()},
+			},
+		},
+		{
+			name:   "account_and_side",
+			filter: market.CancelFilter{Account: "b", Side: &buy},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MassCancelled, OrderID: "cancel", Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			for _, o := range []*order.Order{
+				{Pair: pair, ID: "1", Account: "a", Price: 10, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "2", Account: "a", Price: 11, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "3", Account: "b", Session: "s", Price: 20, Side: order.OrderSell, Volume: 5},
+				{Pair: pair, ID: "4", Account: "b", Price: 21, Side: order.OrderSell, Volume: 5},
+			} {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			stop := &order.Order{Pair: pair, ID: "5", Account: "a", StopPrice: 5, Side: order.OrderSell, Volume: 5}
+			if err := m.InsertStopOrder(stop); err != nil {
+				t.Fatalf("InsertStopOrder(%v) unexpected error: %v", stop, err)
+			}
+
+			tracker.reset()
+
+			got := m.CancelAll("cancel", tc.filter)
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("CancelAll() order events diff (-want, +got):\n%s", diff)
+			}
+
+			if want := len(tc.wantOrderEvents) - 1; got != want {
+				t.Errorf("CancelAll() want: %d, got: %d", want, got)
+			}
+		})
+	}
+}
+
+func Test_CancelAll_LinkedOrders(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(100)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	a := &order.Order{Pair: pair, ID: "1", Account: "a", Price: 10, Side: order.OrderBuy, Volume: 5}
+	b := &order.Order{Pair: pair, ID: "2", Account: "b", Price: 20, Side: order.OrderSell, Volume: 5}
+	if err := m.InsertOCO(a, b); err != nil {
+		t.Fatalf("InsertOCO() unexpected error: %v", err)
+	}
+
+	tracker.reset()
+
+	m.CancelAll("cancel", market.CancelFilter{Account: "a"})
+
+	tracker.flush()
+
+	// The linked order is cancelled after the mass cancel, as with Cancel.
+	want := []*market.OrderEvent{
+		{Type: market.OrderCancelled, OrderID: "1", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+		{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 1, Timestamp: time.Now()},
+		{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledLinkedOrder, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("CancelAll() order events diff (-want, +got):\n%s", diff)
+	}
+}
+
+func Test_CancelAll_Bracket(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name            string
+		filter          market.CancelFilter
+		wantOrderEvents []*market.OrderEvent
+		wantCancelled   int
+		wantEntry       bool
+	}{
+		{
+			name:   "entry_cancels_waiting_legs",
+			filter: market.CancelFilter{Session: "s"},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 3, Timestamp: time.Now()},
+			},
+			wantCancelled: 3,
+		},
+		{
+			name:   "waiting_leg_cancels_other_leg",
+			filter: market.CancelFilter{MinPrice: 15, MaxPrice: 25},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "2", Reason: market.CancelledMassCancel, Timestamp: time.Now()},
+				{Type: market.MassCancelled, OrderID: "cancel", Cancelled: 1, Timestamp: time.Now()},
+				{Type: market.OrderCancelled, OrderID: "3", Reason: market.CancelledLinkedOrder, Timestamp: time.Now()},
+			},
+			wantCancelled: 1,
+			wantEntry:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			entry := &order.Order{Pair: pair, ID: "1", Session: "s", Price: 10, Side: order.OrderBuy, Volume: 5}
+			takeProfit := &order.Order{Pair: pair, ID: "2", Session: "s", Price: 20, Side: order.OrderSell, Volume: 5}
+			stopLoss := &order.Order{Pair: pair, ID: "3", Session: "s", StopPrice: 8, Side: order.OrderSell, Volume: 5}
+			if err := m.InsertBracket(entry, takeProfit, stopLoss); err != nil {
+				t.Fatalf("InsertBracket() unexpected error: %v", err)
+			}
+
+			// The entry is partially filled, its legs wait for the rest.
+			if err := m.MatchTakerOrder(&order.Order{Pair: pair, ID: "4", Side: order.OrderSell, Volume: 4}); err != nil {
+				t.Fatalf("MatchTakerOrder() unexpected error: %v", err)
+			}
+
+			tracker.reset()
+
+			got := m.CancelAll("cancel", tc.filter)
+
+			tracker.flush()
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+				t.Errorf("CancelAll() order events diff (-want, +got):\n%s", diff)
+			}
+
+			if got != tc.wantCancelled {
+				t.Errorf("CancelAll() want: %d, got: %d", tc.wantCancelled, got)
+			}
+
+			// The legs are never placed, even once the entry is done.
+			if _, err := m.RestingOrder(takeProfit); err == nil {
+				t.Errorf("RestingOrder(%v) want error, got nil", takeProfit)
+			}
+
+			if _, err := m.RestingOrder(entry); (err == nil) != tc.wantEntry {
+				t.Errorf("RestingOrder(%v) got error %v, want resting: %v", entry, err, tc.wantEntry)
+			}
+
+			if tc.wantEntry {
+				tracker.reset()
+				if err := m.Cancel(entry); err != nil {
+					t.Fatalf("Cancel() unexpected error: %v", err)
+				}
+				tracker.flush()
+
+				want := []*market.OrderEvent{{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()}}
+				if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+					t.Errorf("Cancel() order events diff (-want, +got):\n%s", diff)
+				}
+
+				if _, err := m.RestingOrder(takeProfit); err == nil {
+					t.Errorf("RestingOrder(%v) want error, got nil", takeProfit)
+				}
+			}
+		})
+	}
+}
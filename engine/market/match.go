@@ -20,6 +20,7 @@ func (m *Market) MatchTakerOrder(o *order.Order) error {
 	}
 
 	m.matchTakerOrder(o, makerBook)
+	m.settle()
 
 	return nil
 }
@@ -27,13 +28,26 @@ func (m *Market) MatchTakerOrder(o *order.Order) error {
 func (m *Market) matchTakerOrder(o *order.Order, makerBook *orderbook.OrderBook) {
 	txnTime := time.Now()
 
-	matches, missingVolume := makerBook.MatchAndExtract(o.Volume)
+	matches, missingVolume := makerBook.MatchAndExtract(o.Volume, m.matches[:0])
+	defer func() {
+		// Keep the buffer for the next taker, without the maker orders.
+		clear(matches)
+		m.matches = matches[:0]
+	}()
 
 	if missingVolume > 0 {
 		m.orderEvents <- &OrderEvent{Type: TakerOrderUnfulfilled, OrderID: o.ID, Timestamp: txnTime}
 	}
 
 	for i, match := range matches {
+		if match.Type == order.OrderFulfilled {
+			m.forget(match.MakerOrder.ID)
+		}
+		m.react(match.MakerOrder.ID, match.VolumeTaken, match.Type == order.OrderFulfilled)
+		if len(m.trailing) > 0 {
+			m.trail(match.MakerOrder.Price)
+		}
+
 		takerMatchType := order.OrderPartiallyFulfilled
 		if i == len(matches)-1 && missingVolume == 0 {
 			takerMatchType = order.OrderFulfilled
@@ -42,6 +56,7 @@ func (m *Market) matchTakerOrder(o *order.Order, makerBook *orderbook.OrderBook)
 		m.matchEvents <- &MatchEvent{
 			Pair:            m.pair,
 			TakerOrderID:    o.ID,
+			TakerSide:       o.Side,
 			TakerMatchType:  takerMatchType,
 			MakerOrderID:    match.MakerOrder.ID,
 			MakerMatchType:  match.Type,
@@ -50,4 +65,11 @@ func (m *Market) matchTakerOrder(o *order.Order, makerBook *orderbook.OrderBook)
 			Timestamp:       txnTime,
 		}
 	}
+
+	if len(matches) > 0 {
+		m.lastPrice = matches[len(matches)-1].MakerOrder.Price
+	}
+
+	// Taker orders never rest, whatever volume is missing is dropped.
+	m.react(o.ID, o.Volume-missingVolume, true)
 }
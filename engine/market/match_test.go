@@ -40,11 +40,11 @@ func Test_MatchTakerOrder(t *testing.T) {
 				{Pair: pair, Side: order.OrderSell, Price: 12, Volume: 5, Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderFulfilled, SettlementPrice: 11, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "103", MakerMatchType: order.OrderFulfilled, SettlementPrice: 11, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderFulfilled, MakerOrderID: "104", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 12, MatchedVolume: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderFulfilled, SettlementPrice: 11, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "103", MakerMatchType: order.OrderFulfilled, SettlementPrice: 11, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderBuy, TakerMatchType: order.OrderFulfilled, MakerOrderID: "104", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 12, MatchedVolume: 10, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -63,11 +63,11 @@ func Test_MatchTakerOrder(t *testing.T) {
 				{Pair: pair, Side: order.OrderBuy, Price: 8, Volume: 5, Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderFulfilled, SettlementPrice: 9, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "103", MakerMatchType: order.OrderFulfilled, SettlementPrice: 9, MatchedVolume: 15, Timestamp: time.Now()},
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderFulfilled, MakerOrderID: "104", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 8, MatchedVolume: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "101", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "102", MakerMatchType: order.OrderFulfilled, SettlementPrice: 9, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "103", MakerMatchType: order.OrderFulfilled, SettlementPrice: 9, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "104", MakerMatchType: order.OrderPartiallyFulfilled, SettlementPrice: 8, MatchedVolume: 10, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -83,7 +83,7 @@ func Test_MatchTakerOrder(t *testing.T) {
 				{Type: market.TakerOrderUnfulfilled, OrderID: "1", Timestamp: time.Now()},
 			},
 			wantMatchEvents: []*market.MatchEvent{
-				{Pair: pair, TakerOrderID: "1", TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "1", TakerSide: order.OrderSell, TakerMatchType: order.OrderPartiallyFulfilled, MakerOrderID: "100", MakerMatchType: order.OrderFulfilled, SettlementPrice: 10, MatchedVolume: 15, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -96,7 +96,7 @@ func Test_MatchTakerOrder(t *testing.T) {
 			match:   &order.Order{Pair: pair, Side: order.OrderBuy, Volume: 10},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, Timestamp: time.Now()},
+				{Type: market.OrderRejected, Reason: market.RejectedNoOrderID, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -104,7 +104,7 @@ func Test_MatchTakerOrder(t *testing.T) {
 			match:   &order.Order{Pair: "USD/BTC", ID: "1", Side: order.OrderBuy, Volume: 10},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedDifferentPair, Timestamp: time.Now()},
 			},
 		},
 		{
@@ -112,7 +112,7 @@ func Test_MatchTakerOrder(t *testing.T) {
 			match:   &order.Order{Pair: pair, ID: "1", Side: order.OrderBuy, Volume: 0},
 			wantErr: true,
 			wantOrderEvents: []*market.OrderEvent{
-				{Type: market.OrderRejected, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedZeroVolume, Timestamp: time.Now()},
 			},
 		},
 	}
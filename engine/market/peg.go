@@ -0,0 +1,283 @@
+package market
+
+import (
+	"container/list"
+	"fmt"
+	"time"
+
+	"exchange/engine/order"
+)
+
+// pegs are the pegged orders resting in a market.
+type pegs struct {
+	// The orders in the sequence they were inserted, which is the sequence
+	// they are repriced in.
+	orders   *list.List
+	elements map[string]*list.Element
+
+	// The number of pegged orders resting at each price, per side, to find the
+	// best prices of the orders that are not pegged.
+	buyCounts  map[uint64]int
+	sellCounts map[uint64]int
+
+	// Whether the books changed since the orders were last repriced.
+	dirty bool
+
+	// The best prices of the orders that are not pegged when the orders were
+	// last repriced, 0 for an empty side.
+	bid uint64
+	ask uint64
+}
+
+func newPegs() *pegs {
+	return &pegs{
+		orders:     list.New(),
+		elements:   map[string]*list.Element{},
+		buyCounts:  map[uint64]int{},
+		sellCounts: map[uint64]int{},
+	}
+}
+
+func (p *pegs) counts(side order.OrderSide) map[uint64]int {
+	if side == order.OrderSell {
+		return p.sellCounts
+	}
+
+	return p.buyCounts
+}
+
+// add tracks a pegged order resting at its price.
+func (p *pegs) add(o *order.Order) {
+	p.elements[o.ID] = p.orders.PushBack(o)
+	p.counts(o.Side)[o.Price]++
+}
+
+// remove stops tracking a pegged order, if it was tracked.
+func (p *pegs) remove(orderID string) {
+	e, ok := p.elements[orderID]
+	if !ok {
+		return
+	}
+
+	o := p.orders.Remove(e).(*order.Order)
+	delete(p.elements, orderID)
+
+	counts := p.counts(o.Side)
+	if counts[o.Price]--; counts[o.Price] == 0 {
+		delete(counts, o.Price)
+	}
+}
+
+// moved updates the price of a tracked pegged order.
+func (p *pegs) moved(o *order.Order, from uint64) {
+	counts := p.counts(o.Side)
+	if counts[from]--; counts[from] == 0 {
+		delete(counts, from)
+	}
+
+	counts[o.Price]++
+}
+
+// InsertPeggedOrder places a maker order whose price follows the book, as
+// given by its Peg. Whenever the best prices of the orders that are not pegged
+// change, the order is moved to its new price, losing its time priority, and
+// an OrderAmended event is fired.
+//
+// Pegged orders never take liquidity: their price is kept one tick away from
+// the opposite side. While there is no price to peg to, they keep their last
+// price, and new pegged orders are rejected.
+func (m *Market) InsertPeggedOrder(o *order.Order) error {
+	if err := m.validateTakerOrder(o); err != nil {
+		return fmt.Errorf("InsertPeggedOrder: %w", err)
+	}
+
+	if o.Peg == nil {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedNoPeg, Timestamp: time.Now()}
+		return fmt.Errorf("InsertPeggedOrder: market %q, order %q, no peg: %w", m.pair, o.ID, InvalidOrderErr)
+	}
+
+	if err := m.validateExpiry(o); err != nil {
+		return fmt.Errorf("InsertPeggedOrder: %w", err)
+	}
+
+	bid, ask := m.referencePrices()
+	price, ok := m.pegPrice(o, bid, ask)
+	if !ok {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedNoPegPrice, Timestamp: time.Now()}
+		return fmt.Errorf("InsertPeggedOrder: market %q, order %q, no price to peg to: %w", m.pair, o.ID, InvalidOrderErr)
+	}
+	o.Price = price
+
+	if err := m.insertMakerOrder(o); err != nil {
+		return err
+	}
+
+	m.pegs.add(o)
+	m.settle()
+
+	return nil
+}
+
+// referencePrices returns the best bid and offer of the orders that are not
+// pegged, 0 for a side without any.
+func (m *Market) referencePrices() (uint64, uint64) {
+	var bid, ask uint64
+	for level := range m.buyBook.Levels() {
+		if level.Orders > m.pegs.buyCounts[level.Price] {
+			bid = level.Price
+			break
+		}
+	}
+
+	for level := range m.sellBook.Levels() {
+		if level.Orders > m.pegs.sellCounts[level.Price] {
+			ask = level.Price
+			break
+		}
+	}
+
+	return bid, ask
+}
+
+// pegPrice returns the price of a pegged order for the given reference prices,
+// and false if there is none.
+func (m *Market) pegPrice(o *order.Order, bid uint64, ask uint64) (uint64, bool) {
+	own, opposite := bid, ask
+	if o.Side == order.OrderSell {
+		own, opposite = ask, bid
+	}
+
+	var ref uint64
+	switch o.Peg.Type {
+	case order.PegPrimary:
+		ref = own
+	case order.PegMarket:
+		ref = opposite
+	case order.PegMidpoint:
+		if bid == 0 || ask == 0 {
+			return 0, false
+		}
+
+		// Rounded away from the opposite side.
+		ref = bid + (ask-bid)/2
+		if o.Side == order.OrderSell {
+			ref = bid + (ask-bid+1)/2
+		}
+	}
+
+	if ref == 0 {
+		return 0, false
+	}
+
+	offset := o.Peg.Offset
+	if o.Side == order.OrderSell {
+		offset = -offset
+	}
+
+	if offset < 0 && uint64(-offset) >= ref {
+		return 0, false
+	}
+	price := ref + uint64(offset)
+
+	tick := uint64(1)
+	if m.prices != nil {
+		tick = m.prices.Tick
+
+		// Rounded away from the opposite side, onto the price grid.
+		if off := (price - m.prices.Min) % tick; price >= m.prices.Min && off != 0 {
+			price -= off
+			if o.Side == order.OrderSell {
+				price += tick
+			}
+		}
+	}
+
+	if o.Side == order.OrderBuy {
+		if head := m.sellBook.HeadPrice(); head != 0 && price >= head {
+			price = head - tick
+		}
+	} else {
+		if head := m.buyBook.HeadPrice(); head != 0 && price <= head {
+			price = head + tick
+		}
+	}
+
+	if price == 0 {
+		return 0, false
+	}
+
+	if m.prices != nil && m.prices.Check(price) != nil {
+		return 0, false
+	}
+
+	return price, true
+}
+
+// reprice moves the pegged orders to their prices, if the best prices of the
+// orders that are not pegged changed since they were last repriced.
+func (m *Market) reprice() {
+	m.pegs.dirty = false
+
+	bid, ask := m.referencePrices()
+	if bid == m.pegs.bid && ask == m.pegs.ask {
+		return
+	}
+	m.pegs.bid, m.pegs.ask = bid, ask
+
+	// Orders moving away from the opposite side go first, to make room for the
+	// orders moving towards it.
+	for _, advancing := range []bool{false, true} {
+		for e := m.pegs.orders.Front(); e != nil; {
+			o := e.Value.(*order.Order)
+
+			// Moving the order may forget it.
+			e = e.Next()
+
+			price, ok := m.pegPrice(o, bid, ask)
+			if !ok || price == o.Price || advances(o, price) != advancing {
+				continue
+			}
+
+			m.move(o, price)
+		}
+	}
+
+	// Moving the orders does not change the reference prices.
+	m.pegs.dirty = false
+}
+
+// advances returns whether moving an order to a price moves it towards the
+// opposite side.
+func advances(o *order.Order, price uint64) bool {
+	if o.Side == order.OrderSell {
+		return price < o.Price
+	}
+
+	return price > o.Price
+}
+
+// move reinserts a resting pegged order at a new price.
+func (m *Market) move(o *order.Order, price uint64) {
+	book := m.buyBook
+	if o.Side == order.OrderSell {
+		book = m.sellBook
+	}
+
+	from := o.Price
+	if err := book.Delete(o); err != nil {
+		return
+	}
+
+	o.Price = price
+	if err := book.Insert(o); err != nil {
+		// The order already left the book, so it is cancelled.
+		o.Price = from
+		m.forget(o.ID)
+		m.orderEvents <- &OrderEvent{Type: OrderCancelled, OrderID: o.ID, Reason: err.Error(), Timestamp: time.Now()}
+		m.react(o.ID, 0, true)
+		return
+	}
+	m.pegs.moved(o, from)
+
+	m.orderEvents <- &OrderEvent{Type: OrderAmended, OrderID: o.ID, Price: price, Timestamp: time.Now()}
+}
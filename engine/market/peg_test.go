@@ -0,0 +1,252 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_InsertPeggedOrder(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name            string
+		makers          []*order.Order
+		pegged          []*order.Order
+		act             func(m *market.Market) error
+		wantPrices      []uint64
+		wantOrderEvents []*market.OrderEvent
+	}{
+		{
+			name: "primary_peg_follows_best_bid",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+			},
+			pegged: []*order.Order{
+				{Pair: pair, ID: "2", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegPrimary}},
+			},
+			act: func(m *market.Market) error {
+				return m.InsertMakerOrder(&order.Order{Pair: pair, ID: "3", Price: 11, Side: order.OrderBuy, Volume: 5})
+			},
+			wantPrices: []uint64{11},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "3", Timestamp: time.Now()},
+				{Type: market.OrderAmended, OrderID: "2", Price: 11, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "ignores_pegged_orders_at_best_bid",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "2", Price: 9, Side: order.OrderBuy, Volume: 5},
+			},
+			pegged: []*order.Order{
+				{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegPrimary}},
+			},
+			act: func(m *market.Market) error {
+				return m.Cancel(&order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5})
+			},
+			wantPrices: []uint64{9},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderAmended, OrderID: "3", Price: 9, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "market_peg_with_offset",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 12, Side: order.OrderSell, Volume: 5},
+				{Pair: pair, ID: "2", Price: 14, Side: order.OrderSell, Volume: 5},
+			},
+			pegged: []*order.Order{
+				{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegMarket, Offset: -2}},
+			},
+			act: func(m *market.Market) error {
+				return m.Cancel(&order.Order{Pair: pair, ID: "1", Price: 12, Side: order.OrderSell, Volume: 5})
+			},
+			wantPrices: []uint64{12},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+				{Type: market.OrderAmended, OrderID: "3", Price: 12, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "market_peg_never_crosses",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 12, Side: order.OrderSell, Volume: 5},
+			},
+			pegged: []*order.Order{
+				{Pair: pair, ID: "2", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegMarket}},
+			},
+			act: func(m *market.Market) error {
+				return m.InsertMakerOrder(&order.Order{Pair: pair, ID: "3", Price: 15, Side: order.OrderSell, Volume: 5})
+			},
+			wantPrices: []uint64{11},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "3", Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "midpoint_pegs_make_room_for_each_other",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+				{Pair: pair, ID: "2", Price: 15, Side: order.OrderSell, Volume: 5},
+			},
+			pegged: []*order.Order{
+				{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegMidpoint}},
+				{Pair: pair, ID: "4", Side: order.OrderSell, Volume: 5, Peg: &order.Peg{Type: order.PegMidpoint}},
+			},
+			act: func(m *market.Market) error {
+				return m.InsertMakerOrder(&order.Order{Pair: pair, ID: "5", Price: 12, Side: order.OrderBuy, Volume: 5})
+			},
+			wantPrices: []uint64{13, 14},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.MakerOrderInserted, OrderID: "5", Timestamp: time.Now()},
+				{Type: market.OrderAmended, OrderID: "4", Price: 14, Timestamp: time.Now()},
+				{Type: market.OrderAmended, OrderID: "3", Price: 13, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "keeps_price_without_reference",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+			},
+			pegged: []*order.Order{
+				{Pair: pair, ID: "2", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegPrimary, Offset: -1}},
+			},
+			act: func(m *market.Market) error {
+				return m.Cancel(&order.Order{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5})
+			},
+			wantPrices: []uint64{9},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			for _, o := range tc.makers {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			for _, o := range tc.pegged {
+				if err := m.InsertPeggedOrder(o); err != nil {
+					t.Fatalf("InsertPeggedOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			tracker.reset()
+
+			if err := tc.act(m); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("order events diff (-want, +got):\n%s", diff)
+			}
+
+			gotPrices := []uint64{}
+			for _, o := range tc.pegged {
+				resting, err := m.RestingOrder(o)
+				if err != nil {
+					t.Fatalf("RestingOrder(%v) unexpected error: %v", o, err)
+				}
+
+				gotPrices = append(gotPrices, resting.Order.Price)
+			}
+
+			if diff := cmp.Diff(tc.wantPrices, gotPrices); diff != "" {
+				t.Errorf("pegged prices diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_InsertPeggedOrder_PriceRange(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m, err := market.NewWithPriceRange(pair, orderbook.PriceRange{Min: 10, Max: 100, Tick: 5}, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+	if err != nil {
+		t.Fatalf("NewWithPriceRange() unexpected error: %v", err)
+	}
+
+	for _, o := range []*order.Order{
+		{Pair: pair, ID: "1", Price: 20, Side: order.OrderBuy, Volume: 5},
+		{Pair: pair, ID: "2", Price: 35, Side: order.OrderSell, Volume: 5},
+	} {
+		if err := m.InsertMakerOrder(o); err != nil {
+			t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	// The midpoint 27.5 is rounded away from the opposite side, onto a tick.
+	testCases := []struct {
+		o         *order.Order
+		wantPrice uint64
+	}{
+		{
+			o:         &order.Order{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegMidpoint}},
+			wantPrice: 25,
+		},
+		{
+			o:         &order.Order{Pair: pair, ID: "4", Side: order.OrderSell, Volume: 5, Peg: &order.Peg{Type: order.PegMidpoint}},
+			wantPrice: 30,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := m.InsertPeggedOrder(tc.o); err != nil {
+			t.Fatalf("InsertPeggedOrder(%v) unexpected error: %v", tc.o, err)
+		}
+
+		if tc.o.Price != tc.wantPrice {
+			t.Errorf("InsertPeggedOrder(%v) want price: %d, got: %d", tc.o.ID, tc.wantPrice, tc.o.Price)
+		}
+	}
+}
+
+func Test_InsertPeggedOrder_Rejected(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	for _, o := range []*order.Order{
+		{Pair: pair, ID: "1", Side: order.OrderBuy, Volume: 5},
+		{Pair: pair, ID: "2", Side: order.OrderBuy, Volume: 5, Peg: &order.Peg{Type: order.PegPrimary}},
+	} {
+		if err := m.InsertPeggedOrder(o); err == nil {
+			t.Errorf("InsertPeggedOrder(%v) want error, got nil", o)
+		}
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedNoPeg, Timestamp: time.Now()},
+		{Type: market.OrderRejected, OrderID: "2", Reason: market.RejectedNoPegPrice, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("InsertPeggedOrder() order events diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,50 @@
+package market
+
+import (
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+	"exchange/engine/orderbook/pricelevel"
+	"fmt"
+)
+
+// restingBook returns the book where the given order rests. Unlike the
+// validations of new orders, it does not fire OrderRejected events.
+func (m *Market) restingBook(o *order.Order) (*orderbook.OrderBook, error) {
+	if o == nil {
+		return nil, fmt.Errorf("market %q, nil order: %w", m.pair, InvalidOrderErr)
+	}
+
+	if o.Pair != m.pair {
+		return nil, fmt.Errorf("market %q, order %q, different pair %q: %w", m.pair, o.ID, o.Pair, InvalidOrderErr)
+	}
+
+	if o.Side == order.OrderSell {
+		return m.sellBook, nil
+	}
+
+	return m.buyBook, nil
+}
+
+// RestingOrder returns a copy of a resting order with its metadata: original
+// volume, volume filled, sequence and entry time.
+func (m *Market) RestingOrder(o *order.Order) (pricelevel.RestingOrder, error) {
+	book, err := m.restingBook(o)
+	if err != nil {
+		return pricelevel.RestingOrder{}, err
+	}
+
+	return book.Order(o)
+}
+
+// QueuePosition returns the number of orders, and their volume, that will be
+// matched before a resting order at its price.
+//
+// O(n), where n is the number of orders ahead at the same price.
+func (m *Market) QueuePosition(o *order.Order) (pricelevel.Position, error) {
+	book, err := m.restingBook(o)
+	if err != nil {
+		return pricelevel.Position{}, err
+	}
+
+	return book.Position(o)
+}
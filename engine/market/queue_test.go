@@ -0,0 +1,100 @@
+package market_test
+
+import (
+	"testing"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_QueuePosition(t *testing.T) {
+	pair := "USD/BTC"
+	tracker := newEventsTracker(100)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	makers := []*order.Order{
+		{ID: "1", Pair: pair, Price: 10, Volume: 5, Side: order.OrderSell},
+		{ID: "2", Pair: pair, Price: 10, Volume: 7, Side: order.OrderSell},
+		{ID: "3", Pair: pair, Price: 10, Volume: 9, Side: order.OrderSell},
+		{ID: "4", Pair: pair, Price: 11, Volume: 1, Side: order.OrderSell},
+	}
+	for _, o := range makers {
+		if err := m.InsertMakerOrder(o); err != nil {
+			t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	taker := &order.Order{ID: "5", Pair: pair, Volume: 8, Side: order.OrderBuy}
+	if err := m.MatchTakerOrder(taker); err != nil {
+		t.Fatalf("MatchTakerOrder(%v) unexpected error: %v", taker, err)
+	}
+
+	tracker.reset()
+
+	testCases := []struct {
+		name         string
+		order        *order.Order
+		wantPosition pricelevel.Position
+		wantErr      bool
+	}{
+		{
+			name:         "partially_filled_front",
+			order:        makers[1],
+			wantPosition: pricelevel.Position{},
+		},
+		{
+			name:         "behind_partially_filled",
+			order:        makers[2],
+			wantPosition: pricelevel.Position{OrdersAhead: 1, VolumeAhead: 4},
+		},
+		{
+			name:         "alone_at_price",
+			order:        makers[3],
+			wantPosition: pricelevel.Position{},
+		},
+		{
+			name:    "filled",
+			order:   makers[0],
+			wantErr: true,
+		},
+		{
+			name:    "different_pair",
+			order:   &order.Order{ID: "1", Pair: "EUR/BTC", Price: 10, Volume: 5, Side: order.OrderSell},
+			wantErr: true,
+		},
+		{
+			name:    "nil",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := m.QueuePosition(tc.order)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("QueuePosition(%v) want error: %v, got: %v", tc.order, tc.wantErr, err)
+			}
+
+			if diff := cmp.Diff(tc.wantPosition, got); diff != "" {
+				t.Errorf("QueuePosition(%v) diff (-want, +got):\n%s", tc.order, diff)
+			}
+
+			tracker.flush()
+			if len(tracker.orderEvents) != 0 {
+				t.Errorf("QueuePosition(%v) want no order events, got: %v", tc.order, tracker.orderEvents)
+			}
+		})
+	}
+
+	resting, err := m.RestingOrder(makers[1])
+	if err != nil {
+		t.Fatalf("RestingOrder(%v) unexpected error: %v", makers[1], err)
+	}
+
+	if resting.OriginalVolume != 7 || resting.Filled != 3 || resting.Order.Volume != 4 {
+		t.Errorf("RestingOrder(%v) want original volume 7, filled 3, volume 4, got: %+v", makers[1], resting)
+	}
+}
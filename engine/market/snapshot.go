@@ -0,0 +1,271 @@
+package market
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"exchange/engine/order"
+)
+
+// Snapshot is the state of a market, to restore it in a new market. Orders are
+// copied once into Orders and referenced by ID everywhere else, and every slice
+// keeps the sequence the market processes its orders in.
+type Snapshot struct {
+	Pair string
+
+	// Every order held by the market: resting in a book, held as a stop order
+	// or waiting as the leg of a bracket.
+	Orders []*order.Order
+
+	// The resting orders, from the best price to the worst and in the
+	// processing order for the same price.
+	Bids []string
+	Asks []string
+
+	// The stop orders, in the sequence they were held.
+	BuyStops  []string
+	SellStops []string
+
+	// The orders with an expiry, in the sequence they were scheduled.
+	Expiries []string
+
+	// The latest time given to Expire.
+	ExpiredUntil time.Time
+
+	// The price of the last match.
+	LastPrice uint64
+
+	// The held stop orders with a trail, by order ID.
+	Trailing []SnapshotTrailingStop
+
+	// The pegged orders in the sequence they are repriced in, and the reference
+	// prices they were last repriced for.
+	Pegs   []string
+	PegBid uint64
+	PegAsk uint64
+
+	// The linked groups, by their first leg.
+	Groups []SnapshotGroup
+
+	// The reactions of the groups not applied yet.
+	Reactions []SnapshotReaction
+}
+
+// SnapshotTrailingStop is a trailing stop order of a snapshot, with the best
+// trade price its stop price trails.
+type SnapshotTrailingStop struct {
+	OrderID string
+	Best    uint64
+}
+
+// SnapshotGroup is a linked group of a snapshot. Entry is empty for an OCO or
+// an activated bracket.
+type SnapshotGroup struct {
+	Entry       string
+	EntryFilled uint64
+	Legs        [2]string
+}
+
+// SnapshotReaction is a change to an order of a group not yet applied to the
+// rest of its group.
+type SnapshotReaction struct {
+	OrderID string
+	Volume  uint64
+	Done    bool
+}
+
+// Snapshot returns the state of the market. It fires no events, and the
+// snapshot shares no orders with the market.
+//
+// O(n log n)
+func (m *Market) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Pair:         m.pair,
+		ExpiredUntil: m.expiredUntil,
+		LastPrice:    m.lastPrice,
+		PegBid:       m.pegs.bid,
+		PegAsk:       m.pegs.ask,
+	}
+
+	seen := map[string]bool{}
+	ids := func(orders []*order.Order) []string {
+		ids := make([]string, 0, len(orders))
+		for _, o := range orders {
+			if !seen[o.ID] {
+				seen[o.ID] = true
+				s.Orders = append(s.Orders, copyOrder(o))
+			}
+			ids = append(ids, o.ID)
+		}
+		return ids
+	}
+
+	s.Bids = ids(slices.Collect(m.buyBook.Orders()))
+	s.Asks = ids(slices.Collect(m.sellBook.Orders()))
+	s.BuyStops = ids(m.buyStops.orders())
+	s.SellStops = ids(m.sellStops.orders())
+	s.Expiries = ids(m.expiries.orders())
+
+	for e := m.pegs.orders.Front(); e != nil; e = e.Next() {
+		s.Pegs = append(s.Pegs, e.Value.(*order.Order).ID)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(m.trailing)) {
+		s.Trailing = append(s.Trailing, SnapshotTrailingStop{OrderID: id, Best: m.trailing[id].best})
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(m.groups)) {
+		g := m.groups[id]
+		if g.legs[0].ID != id {
+			continue
+		}
+
+		// The legs of a bracket waiting for its entry are only held by the
+		// group.
+		sg := SnapshotGroup{Legs: [2]string(ids(g.legs[:]))}
+		if g.entry != nil {
+			sg.Entry = g.entry.ID
+			sg.EntryFilled = g.entryFilled
+		}
+		s.Groups = append(s.Groups, sg)
+	}
+
+	for _, r := range m.reactions {
+		s.Reactions = append(s.Reactions, SnapshotReaction{OrderID: r.orderID, Volume: r.volume, Done: r.done})
+	}
+
+	return s
+}
+
+// Restore restores the state of a snapshot in a new market of the same pair,
+// with the same price range and lot size. It fires no events. The restored
+// orders are copies, so a snapshot can be restored more than once.
+//
+// The metadata of the resting orders starts over, as if they were inserted in
+// the sequence of their queues when restored: their entry time and original
+// volume are those of the restore.
+//
+// O(n log n)
+func (m *Market) Restore(s *Snapshot) error {
+	if s.Pair != m.pair {
+		return fmt.Errorf("Restore: market %q, snapshot of market %q", m.pair, s.Pair)
+	}
+
+	if m.buyBook.HeadPrice() != 0 || m.sellBook.HeadPrice() != 0 || m.buyStops.Len() > 0 || m.sellStops.Len() > 0 || len(m.groups) > 0 {
+		return fmt.Errorf("Restore: market %q is not empty", m.pair)
+	}
+
+	orders := map[string]*order.Order{}
+	for _, o := range s.Orders {
+		orders[o.ID] = copyOrder(o)
+	}
+
+	var errs []error
+	lookup := func(ids []string) []*order.Order {
+		found := make([]*order.Order, 0, len(ids))
+		for _, id := range ids {
+			o, ok := orders[id]
+			if !ok {
+				errs = append(errs, fmt.Errorf("order %q not in the snapshot", id))
+				continue
+			}
+			found = append(found, o)
+		}
+		return found
+	}
+
+	// The books fire no volume events while restored.
+	m.restoring = true
+	defer func() { m.restoring = false }()
+
+	for _, o := range lookup(s.Bids) {
+		if err := m.buyBook.Insert(o); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, o := range lookup(s.Asks) {
+		if err := m.sellBook.Insert(o); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, o := range lookup(s.BuyStops) {
+		m.buyStops.push(o)
+	}
+
+	for _, o := range lookup(s.SellStops) {
+		m.sellStops.push(o)
+	}
+
+	for _, o := range lookup(s.Expiries) {
+		m.expiries.push(o)
+	}
+
+	for _, o := range lookup(s.Pegs) {
+		m.pegs.add(o)
+	}
+
+	for _, t := range s.Trailing {
+		for _, o := range lookup([]string{t.OrderID}) {
+			m.trailing[o.ID] = &trailingStop{order: o, best: t.Best}
+		}
+	}
+
+	for _, sg := range s.Groups {
+		legs := lookup(sg.Legs[:])
+		if len(legs) != 2 {
+			continue
+		}
+
+		g := &group{legs: [2]*order.Order(legs), entryFilled: sg.EntryFilled}
+		if sg.Entry != "" {
+			entry := lookup([]string{sg.Entry})
+			if len(entry) != 1 {
+				continue
+			}
+
+			g.entry = entry[0]
+			m.groups[g.entry.ID] = g
+		}
+
+		for _, o := range g.legs {
+			m.groups[o.ID] = g
+		}
+	}
+
+	for _, r := range s.Reactions {
+		m.reactions = append(m.reactions, reaction{orderID: r.OrderID, volume: r.Volume, done: r.Done})
+	}
+
+	m.expiredUntil = s.ExpiredUntil
+	m.lastPrice = s.LastPrice
+	m.pegs.bid, m.pegs.ask = s.PegBid, s.PegAsk
+	m.pegs.dirty = false
+	m.viewDirty = true
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("Restore: market %q: %w", m.pair, err)
+	}
+
+	return nil
+}
+
+// copyOrder returns a copy of an order that shares nothing with it.
+func copyOrder(o *order.Order) *order.Order {
+	c := *o
+	if o.Peg != nil {
+		peg := *o.Peg
+		c.Peg = &peg
+	}
+
+	if o.Trail != nil {
+		trail := *o.Trail
+		c.Trail = &trail
+	}
+
+	return &c
+}
@@ -0,0 +1,263 @@
+package market_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_Snapshot_Restore(t *testing.T) {
+	pair := "USD/GBP"
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tracker := newEventsTracker(100)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	for _, o := range []*order.Order{
+		{Pair: pair, ID: "b1", Price: 10, Side: order.OrderBuy, Volume: 5, ExpiresAt: start.Add(time.Hour)},
+		{Pair: pair, ID: "b2", Price: 9, Side: order.OrderBuy, Volume: 3},
+		{Pair: pair, ID: "a1", Price: 20, Side: order.OrderSell, Volume: 5},
+	} {
+		if err := m.InsertMakerOrder(o); err != nil {
+			t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	entry := &order.Order{Pair: pair, ID: "e", Price: 11, Side: order.OrderBuy, Volume: 4}
+	takeProfit := &order.Order{Pair: pair, ID: "tp", Price: 30, Side: order.OrderSell, Volume: 4}
+	stopLoss := &order.Order{Pair: pair, ID: "sl", StopPrice: 5, Side: order.OrderSell, Volume: 4}
+	if err := m.InsertBracket(entry, takeProfit, stopLoss); err != nil {
+		t.Fatalf("InsertBracket() unexpected error: %v", err)
+	}
+
+	oco := []*order.Order{
+		{Pair: pair, ID: "o1", Price: 25, Side: order.OrderSell, Volume: 1},
+		{Pair: pair, ID: "o2", StopPrice: 6, Side: order.OrderSell, Volume: 1},
+	}
+	if err := m.InsertOCO(oco[0], oco[1]); err != nil {
+		t.Fatalf("InsertOCO() unexpected error: %v", err)
+	}
+
+	// Stops with the same stop price trigger in the sequence they were held.
+	for _, o := range []*order.Order{
+		{Pair: pair, ID: "s1", StopPrice: 22, Side: order.OrderBuy, Volume: 1, ExpiresAt: start.Add(time.Hour)},
+		{Pair: pair, ID: "s2", StopPrice: 22, Side: order.OrderBuy, Volume: 1},
+		{Pair: pair, ID: "s3", StopPrice: 22, Side: order.OrderBuy, Volume: 1},
+	} {
+		if err := m.InsertStopOrder(o); err != nil {
+			t.Fatalf("InsertStopOrder(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	peg := &order.Order{Pair: pair, ID: "p1", Side: order.OrderBuy, Volume: 2, Peg: &order.Peg{Type: order.PegPrimary}}
+	if err := m.InsertPeggedOrder(peg); err != nil {
+		t.Fatalf("InsertPeggedOrder(%v) unexpected error: %v", peg, err)
+	}
+
+	// The bracket entry is partially filled, its legs wait for the rest.
+	if err := m.MatchTakerOrder(&order.Order{Pair: pair, ID: "t1", Side: order.OrderSell, Volume: 2}); err != nil {
+		t.Fatalf("MatchTakerOrder() unexpected error: %v", err)
+	}
+
+	m.Expire(start)
+
+	// The snapshot is stored as JSON by the engine.
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+
+	snapshot := &market.Snapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+
+	restoredTracker := newEventsTracker(100)
+	restored := market.New(pair, restoredTracker.orderEventsChan, restoredTracker.volumeEventsChan, restoredTracker.matchEventsChan)
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatalf("Restore() unexpected error: %v", err)
+	}
+
+	restoredTracker.flush()
+	if len(restoredTracker.orderEvents)+len(restoredTracker.volumeEvents)+len(restoredTracker.matchEvents) > 0 {
+		t.Errorf("Restore() fired events: %v %v %v", restoredTracker.orderEvents, restoredTracker.volumeEvents, restoredTracker.matchEvents)
+	}
+
+	if diff := cmp.Diff(snapshot, restored.Snapshot()); diff != "" {
+		t.Errorf("Snapshot() after Restore() diff (-want, +got):\n%s", diff)
+	}
+
+	// The restored market carries on as the original one would.
+	after := func(m *market.Market) {
+		// Fills the entry, which places its legs for the volume filled.
+		if err := m.MatchTakerOrder(&order.Order{Pair: pair, ID: "t2", Side: order.OrderSell, Volume: 2}); err != nil {
+			t.Fatalf("MatchTakerOrder() unexpected error: %v", err)
+		}
+
+		m.Expire(start.Add(2 * time.Hour))
+
+		// Fills the OCO leg, cancelling the other one, and triggers the stops.
+		if err := m.MatchTakerOrder(&order.Order{Pair: pair, ID: "t3", Side: order.OrderBuy, Volume: 6}); err != nil {
+			t.Fatalf("MatchTakerOrder() unexpected error: %v", err)
+		}
+
+		// Triggers the stop-loss.
+		if err := m.MatchTakerOrder(&order.Order{Pair: pair, ID: "t4", Side: order.OrderSell, Volume: 10}); err != nil {
+			t.Fatalf("MatchTakerOrder() unexpected error: %v", err)
+		}
+	}
+
+	tracker.reset()
+	after(m)
+	tracker.flush()
+
+	restoredTracker.reset()
+	after(restored)
+	restoredTracker.flush()
+
+	opts := cmpopts.EquateApproxTime(30 * time.Second)
+	if diff := cmp.Diff(tracker.orderEvents, restoredTracker.orderEvents, opts); diff != "" {
+		t.Errorf("restored order events diff (-want, +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(tracker.matchEvents, restoredTracker.matchEvents, opts); diff != "" {
+		t.Errorf("restored match events diff (-want, +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(tracker.volumeEvents, restoredTracker.volumeEvents, opts); diff != "" {
+		t.Errorf("restored volume events diff (-want, +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(m.Snapshot(), restored.Snapshot()); diff != "" {
+		t.Errorf("Snapshot() diff (-want, +got):\n%s", diff)
+	}
+
+	// Every order of the snapshot was used.
+	if len(tracker.orderEvents) < 10 {
+		t.Errorf("want the orders of the snapshot to fire events, got %v", tracker.orderEvents)
+	}
+}
+
+func Test_Restore_Errors(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name     string
+		snapshot *market.Snapshot
+		orders   []*order.Order
+	}{
+		{
+			name:     "other_pair",
+			snapshot: &market.Snapshot{Pair: "EUR/GBP"},
+		},
+		{
+			name:     "not_empty",
+			snapshot: &market.Snapshot{Pair: pair},
+			orders:   []*order.Order{{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 1}},
+		},
+		{
+			name:     "unknown_order",
+			snapshot: &market.Snapshot{Pair: pair, Bids: []string{"1"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			for _, o := range tc.orders {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			if err := m.Restore(tc.snapshot); err == nil {
+				t.Errorf("Restore() want error, got nil")
+			}
+		})
+	}
+}
+
+func Test_Snapshot_TrailingStop(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(100)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	trade(t, m, "1", 15, order.OrderBuy)
+
+	stop := &order.Order{Pair: pair, ID: "stop", Side: order.OrderSell, Volume: 1, Trail: &order.Trail{Amount: 5}}
+	if err := m.InsertTrailingStopOrder(stop); err != nil {
+		t.Fatalf("InsertTrailingStopOrder() unexpected error: %v", err)
+	}
+
+	// The best price is above the last one.
+	trade(t, m, "2", 20, order.OrderBuy)
+	trade(t, m, "3", 18, order.OrderBuy)
+
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+
+	snapshot := &market.Snapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+
+	restoredTracker := newEventsTracker(100)
+	restored := market.New(pair, restoredTracker.orderEventsChan, restoredTracker.volumeEventsChan, restoredTracker.matchEventsChan)
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatalf("Restore() unexpected error: %v", err)
+	}
+
+	stopPrice := func(m *market.Market) uint64 {
+		for _, o := range m.Snapshot().Orders {
+			if o.ID == stop.ID {
+				return o.StopPrice
+			}
+		}
+		return 0
+	}
+
+	if got, want := stopPrice(restored), stopPrice(m); got != want || want != 15 {
+		t.Errorf("restored stop price got %d, want %d", got, want)
+	}
+
+	// The stop price keeps trailing the best price, not the last one, and the
+	// reversal triggers both orders.
+	for i, tc := range []struct {
+		price         uint64
+		wantStopPrice uint64
+	}{
+		{price: 19, wantStopPrice: 15},
+		{price: 22, wantStopPrice: 17},
+		{price: 17, wantStopPrice: 0},
+	} {
+		tracker.reset()
+		restoredTracker.reset()
+
+		for _, m := range []*market.Market{m, restored} {
+			trade(t, m, fmt.Sprintf("%d", i+4), tc.price, order.OrderBuy)
+
+			if got := stopPrice(m); got != tc.wantStopPrice {
+				t.Errorf("trade at %d, stop price got %d, want %d", tc.price, got, tc.wantStopPrice)
+			}
+		}
+
+		tracker.flush()
+		restoredTracker.flush()
+
+		if diff := cmp.Diff(tracker.orderEvents, restoredTracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+			t.Errorf("trade at %d, restored order events diff (-want, +got):\n%s", tc.price, diff)
+		}
+	}
+}
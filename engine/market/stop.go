@@ -0,0 +1,113 @@
+package market
+
+import (
+	"exchange/engine/order"
+	"fmt"
+	"time"
+)
+
+// newStopOrders returns a heap of the stop orders of a side, by the order they
+// trigger in: buy stops from the lowest stop price, sell stops from the
+// highest.
+func newStopOrders(side order.OrderSide) *orderHeap {
+	if side == order.OrderSell {
+		return newOrderHeap(func(a, b *order.Order) bool {
+			return a.StopPrice > b.StopPrice
+		})
+	}
+
+	return newOrderHeap(func(a, b *order.Order) bool {
+		return a.StopPrice < b.StopPrice
+	})
+}
+
+// stops returns the stop orders held for a side.
+func (m *Market) stops(side order.OrderSide) *orderHeap {
+	if side == order.OrderSell {
+		return m.sellStops
+	}
+
+	return m.buyStops
+}
+
+// InsertStopOrder holds a stop order until a trade at its stop price, firing an
+// OrderPending event. Then it fires an OrderTriggered event and the order is
+// matched as a market order, or inserted as a limit order if it has a price.
+func (m *Market) InsertStopOrder(o *order.Order) error {
+	if err := m.validateStopOrder(o); err != nil {
+		return fmt.Errorf("InsertStopOrder: %w", err)
+	}
+
+	m.holdStop(o)
+	m.settle()
+
+	return nil
+}
+
+func (m *Market) validateStopOrder(o *order.Order) error {
+	if err := m.validateTakerOrder(o); err != nil {
+		return err
+	}
+
+	if o.StopPrice <= 0 {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedZeroStopPrice, Timestamp: time.Now()}
+		return fmt.Errorf("market %q, order %q, negative or zero stop price %d: %w", m.pair, o.ID, o.StopPrice, InvalidOrderErr)
+	}
+
+	if o.Price > 0 {
+		if err := m.validateOrder(o); err != nil {
+			return err
+		}
+	}
+
+	return m.validateExpiry(o)
+}
+
+// holdStop holds a validated stop order until it triggers.
+func (m *Market) holdStop(o *order.Order) {
+	m.stops(o.Side).push(o)
+	if !o.ExpiresAt.IsZero() {
+		m.expiries.push(o)
+	}
+
+	m.orderEvents <- &OrderEvent{Type: OrderPending, OrderID: o.ID, Timestamp: time.Now()}
+}
+
+// nextTriggeredStop returns the next stop order triggered by the last trade
+// price, buy stops first.
+func (m *Market) nextTriggeredStop() (*order.Order, bool) {
+	if m.lastPrice == 0 {
+		return nil, false
+	}
+
+	if o, ok := m.buyStops.peek(); ok && o.StopPrice <= m.lastPrice {
+		return o, true
+	}
+
+	if o, ok := m.sellStops.peek(); ok && o.StopPrice >= m.lastPrice {
+		return o, true
+	}
+
+	return nil, false
+}
+
+// trigger releases a stop order into the market.
+func (m *Market) trigger(o *order.Order) {
+	m.stops(o.Side).remove(o.ID)
+	m.forget(o.ID)
+
+	m.orderEvents <- &OrderEvent{Type: OrderTriggered, OrderID: o.ID, Timestamp: time.Now()}
+
+	if o.Price > 0 {
+		// The error was already fired as an OrderRejected event.
+		_ = m.insertMakerOrder(o)
+		return
+	}
+
+	makerBook := m.sellBook
+	if o.Side == order.OrderSell {
+		makerBook = m.buyBook
+	}
+
+	m.matchTakerOrder(o, makerBook)
+}
@@ -0,0 +1,194 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_InsertStopOrder(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name            string
+		makers          []*order.Order
+		stops           []*order.Order
+		taker           *order.Order
+		wantOrderEvents []*market.OrderEvent
+		wantMatchEvents []*market.MatchEvent
+	}{
+		{
+			name: "buy_stop_triggers_at_stop_price",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderSell, Volume: 1},
+				{Pair: pair, ID: "2", Price: 11, Side: order.OrderSell, Volume: 5},
+			},
+			stops: []*order.Order{
+				{Pair: pair, ID: "3", StopPrice: 10, Side: order.OrderBuy, Volume: 2},
+			},
+			taker: &order.Order{Pair: pair, ID: "4", Side: order.OrderBuy, Volume: 1},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderTriggered, OrderID: "3", Timestamp: time.Now()},
+			},
+			wantMatchEvents: []*market.MatchEvent{
+				{Pair: pair, TakerOrderID: "4", TakerSide: order.OrderBuy, TakerMatchType: order.OrderFulfilled, MakerOrderID: "1", MakerMatchType: order.OrderFulfilled, MatchedVolume: 1, SettlementPrice: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "3", TakerSide: order.OrderBuy, TakerMatchType: order.OrderFulfilled, MakerOrderID: "2", MakerMatchType: order.OrderPartiallyFulfilled, MatchedVolume: 2, SettlementPrice: 11, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "sell_stop_waits_above_stop_price",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 5},
+			},
+			stops: []*order.Order{
+				{Pair: pair, ID: "2", StopPrice: 9, Side: order.OrderSell, Volume: 2},
+			},
+			taker: &order.Order{Pair: pair, ID: "3", Side: order.OrderSell, Volume: 1},
+			wantMatchEvents: []*market.MatchEvent{
+				{Pair: pair, TakerOrderID: "3", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "1", MakerMatchType: order.OrderPartiallyFulfilled, MatchedVolume: 1, SettlementPrice: 10, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "stop_limit_rests_when_triggered",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderSell, Volume: 1},
+			},
+			stops: []*order.Order{
+				{Pair: pair, ID: "2", StopPrice: 10, Price: 10, Side: order.OrderBuy, Volume: 2},
+			},
+			taker: &order.Order{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 1},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderTriggered, OrderID: "2", Timestamp: time.Now()},
+				{Type: market.MakerOrderInserted, OrderID: "2", Timestamp: time.Now()},
+			},
+			wantMatchEvents: []*market.MatchEvent{
+				{Pair: pair, TakerOrderID: "3", TakerSide: order.OrderBuy, TakerMatchType: order.OrderFulfilled, MakerOrderID: "1", MakerMatchType: order.OrderFulfilled, MatchedVolume: 1, SettlementPrice: 10, Timestamp: time.Now()},
+			},
+		},
+		{
+			name: "triggered_stops_cascade",
+			makers: []*order.Order{
+				{Pair: pair, ID: "1", Price: 10, Side: order.OrderBuy, Volume: 1},
+				{Pair: pair, ID: "2", Price: 9, Side: order.OrderBuy, Volume: 1},
+				{Pair: pair, ID: "3", Price: 8, Side: order.OrderBuy, Volume: 1},
+			},
+			stops: []*order.Order{
+				{Pair: pair, ID: "4", StopPrice: 9, Side: order.OrderSell, Volume: 1},
+				{Pair: pair, ID: "5", StopPrice: 10, Side: order.OrderSell, Volume: 1},
+			},
+			taker: &order.Order{Pair: pair, ID: "6", Side: order.OrderSell, Volume: 1},
+			wantOrderEvents: []*market.OrderEvent{
+				{Type: market.OrderTriggered, OrderID: "5", Timestamp: time.Now()},
+				{Type: market.OrderTriggered, OrderID: "4", Timestamp: time.Now()},
+			},
+			wantMatchEvents: []*market.MatchEvent{
+				{Pair: pair, TakerOrderID: "6", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "1", MakerMatchType: order.OrderFulfilled, MatchedVolume: 1, SettlementPrice: 10, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "5", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "2", MakerMatchType: order.OrderFulfilled, MatchedVolume: 1, SettlementPrice: 9, Timestamp: time.Now()},
+				{Pair: pair, TakerOrderID: "4", TakerSide: order.OrderSell, TakerMatchType: order.OrderFulfilled, MakerOrderID: "3", MakerMatchType: order.OrderFulfilled, MatchedVolume: 1, SettlementPrice: 8, Timestamp: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			for _, o := range tc.makers {
+				if err := m.InsertMakerOrder(o); err != nil {
+					t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			for _, o := range tc.stops {
+				if err := m.InsertStopOrder(o); err != nil {
+					t.Fatalf("InsertStopOrder(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			tracker.reset()
+
+			if err := m.MatchTakerOrder(tc.taker); err != nil {
+				t.Fatalf("MatchTakerOrder(%v) unexpected error: %v", tc.taker, err)
+			}
+
+			tracker.flush()
+
+			opts := cmp.Options{
+				cmpopts.EquateApproxTime(30 * time.Second),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(tc.wantOrderEvents, tracker.orderEvents, opts); diff != "" {
+				t.Errorf("MatchTakerOrder(%v) order events diff (-want, +got):\n%s", tc.taker, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantMatchEvents, tracker.matchEvents, opts); diff != "" {
+				t.Errorf("MatchTakerOrder(%v) match events diff (-want, +got):\n%s", tc.taker, diff)
+			}
+		})
+	}
+}
+
+func Test_InsertStopOrder_Rejected(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	o := &order.Order{Pair: pair, ID: "1", Side: order.OrderBuy, Volume: 5}
+	if err := m.InsertStopOrder(o); err == nil {
+		t.Errorf("InsertStopOrder(%v) want error, got nil", o)
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedZeroStopPrice, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("InsertStopOrder() order events diff (-want, +got):\n%s", diff)
+	}
+}
+
+func Test_Cancel_StopOrder(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	stop := &order.Order{Pair: pair, ID: "1", StopPrice: 10, Side: order.OrderBuy, Volume: 5}
+	if err := m.InsertStopOrder(stop); err != nil {
+		t.Fatalf("InsertStopOrder(%v) unexpected error: %v", stop, err)
+	}
+
+	if err := m.Cancel(stop); err != nil {
+		t.Fatalf("Cancel(%v) unexpected error: %v", stop, err)
+	}
+
+	maker := &order.Order{Pair: pair, ID: "2", Price: 10, Side: order.OrderSell, Volume: 5}
+	if err := m.InsertMakerOrder(maker); err != nil {
+		t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", maker, err)
+	}
+
+	taker := &order.Order{Pair: pair, ID: "3", Side: order.OrderBuy, Volume: 1}
+	if err := m.MatchTakerOrder(taker); err != nil {
+		t.Fatalf("MatchTakerOrder(%v) unexpected error: %v", taker, err)
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.OrderPending, OrderID: "1", Timestamp: time.Now()},
+		{Type: market.OrderCancelled, OrderID: "1", Timestamp: time.Now()},
+		{Type: market.MakerOrderInserted, OrderID: "2", Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("order events diff (-want, +got):\n%s", diff)
+	}
+}
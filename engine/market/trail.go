@@ -0,0 +1,83 @@
+package market
+
+import (
+	"fmt"
+	"time"
+
+	"exchange/engine/order"
+)
+
+// trailingStop is a held stop order whose stop price follows the trades.
+type trailingStop struct {
+	order *order.Order
+
+	// The best trade price since the order was inserted: the highest for sell
+	// orders, the lowest for buy orders.
+	best uint64
+}
+
+// InsertTrailingStopOrder holds a stop order whose stop price trails the best
+// trade price since it was inserted by its Trail: below the highest price for
+// sell orders, above the lowest price for buy orders. It triggers like any
+// stop order, see InsertStopOrder, once the price reverses by the trail.
+//
+// The trail starts from the last trade price, so orders are rejected until the
+// market has a trade.
+func (m *Market) InsertTrailingStopOrder(o *order.Order) error {
+	if err := m.validateTakerOrder(o); err != nil {
+		return fmt.Errorf("InsertTrailingStopOrder: %w", err)
+	}
+
+	if o.Trail == nil || (o.Trail.Amount == 0) == (o.Trail.BasisPoints == 0) || o.Trail.BasisPoints >= 10_000 {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedInvalidTrail, Timestamp: time.Now()}
+		return fmt.Errorf("InsertTrailingStopOrder: market %q, order %q, invalid trail %+v: %w", m.pair, o.ID, o.Trail, InvalidOrderErr)
+	}
+
+	if m.lastPrice == 0 {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedNoLastPrice, Timestamp: time.Now()}
+		return fmt.Errorf("InsertTrailingStopOrder: market %q, order %q, no trade to trail: %w", m.pair, o.ID, InvalidOrderErr)
+	}
+
+	t := &trailingStop{order: o, best: m.lastPrice}
+	o.StopPrice = t.stopPrice()
+
+	if err := m.validateStopOrder(o); err != nil {
+		return fmt.Errorf("InsertTrailingStopOrder: %w", err)
+	}
+
+	m.trailing[o.ID] = t
+	m.holdStop(o)
+	m.settle()
+
+	return nil
+}
+
+// stopPrice returns the stop price trailing the best price, 0 if the trail is
+// longer than the price.
+func (t *trailingStop) stopPrice() uint64 {
+	distance := t.order.Trail.Distance(t.best)
+	if t.order.Side == order.OrderBuy {
+		return t.best + distance
+	}
+
+	if distance >= t.best {
+		return 0
+	}
+
+	return t.best - distance
+}
+
+// trail moves the stop prices of the trailing stops after a trade at price.
+//
+// O(k log n), where k is the number of trailing stops.
+func (m *Market) trail(price uint64) {
+	for _, t := range m.trailing {
+		if t.order.Side == order.OrderSell && price <= t.best || t.order.Side == order.OrderBuy && price >= t.best {
+			continue
+		}
+
+		t.best = price
+		t.order.StopPrice = t.stopPrice()
+		m.stops(t.order.Side).fix(t.order.ID)
+	}
+}
@@ -0,0 +1,164 @@
+package market_test
+
+import (
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// trade matches a maker and a taker order of one unit at a price.
+func trade(t *testing.T, m *market.Market, id string, price uint64, takerSide order.OrderSide) {
+	t.Helper()
+
+	makerSide := order.OrderSell
+	if takerSide == order.OrderSell {
+		makerSide = order.OrderBuy
+	}
+
+	maker := &order.Order{Pair: m.View().Pair, ID: id + "-maker", Price: price, Side: makerSide, Volume: 1}
+	if err := m.InsertMakerOrder(maker); err != nil {
+		t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", maker, err)
+	}
+
+	taker := &order.Order{Pair: m.View().Pair, ID: id + "-taker", Side: takerSide, Volume: 1}
+	if err := m.MatchTakerOrder(taker); err != nil {
+		t.Fatalf("MatchTakerOrder(%v) unexpected error: %v", taker, err)
+	}
+}
+
+func Test_InsertTrailingStopOrder(t *testing.T) {
+	pair := "USD/GBP"
+
+	testCases := []struct {
+		name  string
+		side  order.OrderSide
+		trail order.Trail
+
+		// The trades after the order is inserted, from a last price of 10.
+		trades []uint64
+
+		// The stop price after each trade.
+		wantStopPrices []uint64
+
+		// The index of the trade that triggers the order, -1 if none.
+		wantTriggeredBy int
+	}{
+		{
+			name:            "sell_amount",
+			side:            order.OrderSell,
+			trail:           order.Trail{Amount: 2},
+			trades:          []uint64{12, 11, 10},
+			wantStopPrices:  []uint64{10, 10, 10},
+			wantTriggeredBy: 2,
+		},
+		{
+			name:            "sell_percentage",
+			side:            order.OrderSell,
+			trail:           order.Trail{BasisPoints: 1_000},
+			trades:          []uint64{12, 11},
+			wantStopPrices:  []uint64{11, 11},
+			wantTriggeredBy: 1,
+		},
+		{
+			name:            "buy_amount",
+			side:            order.OrderBuy,
+			trail:           order.Trail{Amount: 2},
+			trades:          []uint64{8, 9, 11},
+			wantStopPrices:  []uint64{10, 10, 10},
+			wantTriggeredBy: 2,
+		},
+		{
+			name:            "never_reverses",
+			side:            order.OrderSell,
+			trail:           order.Trail{Amount: 2},
+			trades:          []uint64{11, 12, 13},
+			wantStopPrices:  []uint64{9, 10, 11},
+			wantTriggeredBy: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newEventsTracker(100)
+			m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+			trade(t, m, "start", 10, order.OrderBuy)
+
+			o := &order.Order{Pair: pair, ID: "trailing", Side: tc.side, Volume: 1, Trail: &tc.trail}
+			if err := m.InsertTrailingStopOrder(o); err != nil {
+				t.Fatalf("InsertTrailingStopOrder(%v) unexpected error: %v", o, err)
+			}
+
+			// A resting order on the opposite side to fill the triggered order.
+			opposite := &order.Order{Pair: pair, ID: "opposite", Price: 1, Side: order.OrderBuy, Volume: 1}
+			if tc.side == order.OrderBuy {
+				opposite.Price, opposite.Side = 100, order.OrderSell
+			}
+			if err := m.InsertMakerOrder(opposite); err != nil {
+				t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", opposite, err)
+			}
+
+			gotStopPrices := []uint64{}
+			gotTriggeredBy := -1
+			for i, price := range tc.trades {
+				tracker.reset()
+
+				takerSide := order.OrderBuy
+				if i > 0 && price < tc.trades[i-1] {
+					takerSide = order.OrderSell
+				}
+				trade(t, m, "trade", price, takerSide)
+
+				gotStopPrices = append(gotStopPrices, o.StopPrice)
+
+				tracker.flush()
+				for _, ev := range tracker.orderEvents {
+					if ev.Type == market.OrderTriggered && ev.OrderID == o.ID {
+						gotTriggeredBy = i
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantStopPrices, gotStopPrices); diff != "" {
+				t.Errorf("stop prices diff (-want, +got):\n%s", diff)
+			}
+
+			if gotTriggeredBy != tc.wantTriggeredBy {
+				t.Errorf("triggered by trade want: %d, got: %d", tc.wantTriggeredBy, gotTriggeredBy)
+			}
+		})
+	}
+}
+
+func Test_InsertTrailingStopOrder_Rejected(t *testing.T) {
+	pair := "USD/GBP"
+
+	tracker := newEventsTracker(10)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	for _, o := range []*order.Order{
+		{Pair: pair, ID: "1", Side: order.OrderSell, Volume: 1, Trail: &order.Trail{Amount: 2}},
+		{Pair: pair, ID: "2", Side: order.OrderSell, Volume: 1, Trail: &order.Trail{Amount: 2, BasisPoints: 100}},
+		{Pair: pair, ID: "3", Side: order.OrderSell, Volume: 1},
+	} {
+		if err := m.InsertTrailingStopOrder(o); err == nil {
+			t.Errorf("InsertTrailingStopOrder(%v) want error, got nil", o)
+		}
+	}
+
+	tracker.flush()
+
+	want := []*market.OrderEvent{
+		{Type: market.OrderRejected, OrderID: "1", Reason: market.RejectedNoLastPrice, Timestamp: time.Now()},
+		{Type: market.OrderRejected, OrderID: "2", Reason: market.RejectedInvalidTrail, Timestamp: time.Now()},
+		{Type: market.OrderRejected, OrderID: "3", Reason: market.RejectedInvalidTrail, Timestamp: time.Now()},
+	}
+	if diff := cmp.Diff(want, tracker.orderEvents, cmpopts.EquateApproxTime(30*time.Second)); diff != "" {
+		t.Errorf("InsertTrailingStopOrder() order events diff (-want, +got):\n%s", diff)
+	}
+}
@@ -1,7 +1,9 @@
 package market
 
 import (
+	"errors"
 	"exchange/engine/order"
+	"exchange/engine/orderbook"
 	"fmt"
 	"time"
 )
@@ -12,10 +14,22 @@ func (m *Market) validateOrder(o *order.Order) error {
 	}
 
 	if o.Price <= 0 {
-		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Timestamp: time.Now()}
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedZeroPrice, Timestamp: time.Now()}
 		return fmt.Errorf("market %q, order %q, negative or zero price %d: %w", m.pair, o.ID, o.Price, InvalidOrderErr)
 	}
 
+	if m.prices != nil {
+		if err := m.prices.Check(o.Price); err != nil {
+			reason := RejectedPriceRange
+			if errors.Is(err, orderbook.PriceOffTickErr) {
+				reason = RejectedPriceTick
+			}
+
+			m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: reason, Timestamp: time.Now()}
+			return fmt.Errorf("market %q, order %q, %v: %w", m.pair, o.ID, err, InvalidOrderErr)
+		}
+	}
+
 	return nil
 }
 
@@ -25,19 +39,24 @@ func (m *Market) validateTakerOrder(o *order.Order) error {
 	}
 
 	if o.ID == "" {
-		m.orderEvents <- &OrderEvent{Type: OrderRejected, Timestamp: time.Now()}
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, Reason: RejectedNoOrderID, Timestamp: time.Now()}
 		return fmt.Errorf("market %q, no order ID: %v: %w", m.pair, o, InvalidOrderErr)
 	}
 
 	if o.Pair != m.pair {
-		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Timestamp: time.Now()}
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedDifferentPair, Timestamp: time.Now()}
 		return fmt.Errorf("market %q, order %q, different pair %q: %w", m.pair, o.ID, o.Pair, InvalidOrderErr)
 	}
 
 	if o.Volume <= 0 {
-		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Timestamp: time.Now()}
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedZeroVolume, Timestamp: time.Now()}
 		return fmt.Errorf("market %q, order %q, negative or zero volume %d: %w", m.pair, o.ID, o.Volume, InvalidOrderErr)
 	}
 
+	if m.lotSize != 0 && o.Volume%m.lotSize != 0 {
+		m.orderEvents <- &OrderEvent{Type: OrderRejected, OrderID: o.ID, Reason: RejectedVolumeLot, Timestamp: time.Now()}
+		return fmt.Errorf("market %q, order %q, volume %d off the lot size %d: %w", m.pair, o.ID, o.Volume, m.lotSize, InvalidOrderErr)
+	}
+
 	return nil
 }
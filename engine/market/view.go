@@ -0,0 +1,55 @@
+package market
+
+import (
+	"time"
+
+	"exchange/engine/orderbook"
+)
+
+// View is an immutable snapshot of the books of a market. Views are published
+// by the goroutine modifying the market and are safe to read from any other
+// goroutine; they must not be modified.
+type View struct {
+	Pair string
+
+	// Increases with each published view.
+	Sequence uint64
+
+	// Up to the requested number of levels per side, best prices first.
+	Bids []orderbook.Level
+	Asks []orderbook.Level
+
+	Timestamp time.Time
+}
+
+// PublishView publishes a view of up to n levels per side, if the books
+// changed since the last published view. It must be called from the goroutine
+// that modifies the market, typically after each batch of orders, so that
+// matching never waits for readers.
+//
+// O(n), O(1) if the books did not change.
+func (m *Market) PublishView(n int) {
+	if !m.viewDirty && n == m.viewLevels {
+		return
+	}
+
+	m.viewSequence++
+	m.view.Store(&View{
+		Pair:      m.pair,
+		Sequence:  m.viewSequence,
+		Bids:      m.buyBook.Depth(n),
+		Asks:      m.sellBook.Depth(n),
+		Timestamp: time.Now(),
+	})
+
+	m.viewDirty = false
+	m.viewLevels = n
+}
+
+// View returns the last published view. It is safe to call from any
+// goroutine, and never blocks the goroutine modifying the market.
+//
+// O(1)
+func (m *Market) View() *View {
+	return m.view.Load()
+}
@@ -0,0 +1,169 @@
+package market_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_PublishView(t *testing.T) {
+	pair := "USD/BTC"
+	tracker := newEventsTracker(100)
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	empty := m.View()
+	if empty == nil || len(empty.Bids) != 0 || len(empty.Asks) != 0 {
+		t.Fatalf("View() of a new market want empty, got: %+v", empty)
+	}
+
+	orders := []*order.Order{
+		{ID: "1", Pair: pair, Price: 10, Volume: 1, Side: order.OrderBuy},
+		{ID: "2", Pair: pair, Price: 9, Volume: 2, Side: order.OrderBuy},
+		{ID: "3", Pair: pair, Price: 11, Volume: 3, Side: order.OrderSell},
+	}
+	for _, o := range orders {
+		if err := m.InsertMakerOrder(o); err != nil {
+			t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	if m.View() != empty {
+		t.Errorf("View() changed before PublishView()")
+	}
+
+	m.PublishView(10)
+	first := m.View()
+
+	wantBids := []orderbook.Level{{Price: 10, Volume: 1, Orders: 1}, {Price: 9, Volume: 2, Orders: 1}}
+	wantAsks := []orderbook.Level{{Price: 11, Volume: 3, Orders: 1}}
+	if diff := cmp.Diff(wantBids, first.Bids); diff != "" {
+		t.Errorf("View() bids diff (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantAsks, first.Asks); diff != "" {
+		t.Errorf("View() asks diff (-want, +got):\n%s", diff)
+	}
+
+	m.PublishView(10)
+	if m.View() != first {
+		t.Errorf("PublishView() without changes published a new view")
+	}
+
+	if err := m.Cancel(orders[0]); err != nil {
+		t.Fatalf("Cancel(%v) unexpected error: %v", orders[0], err)
+	}
+	m.PublishView(10)
+
+	second := m.View()
+	if second.Sequence != first.Sequence+1 {
+		t.Errorf("PublishView() sequence want: %d, got: %d", first.Sequence+1, second.Sequence)
+	}
+
+	if diff := cmp.Diff([]orderbook.Level{{Price: 9, Volume: 2, Orders: 1}}, second.Bids); diff != "" {
+		t.Errorf("View() bids after cancel diff (-want, +got):\n%s", diff)
+	}
+
+	// Published views are never modified.
+	if diff := cmp.Diff(wantBids, first.Bids); diff != "" {
+		t.Errorf("previous View() bids modified (-want, +got):\n%s", diff)
+	}
+
+	m.PublishView(1)
+	if got := m.View(); got == second || len(got.Bids) != 1 || len(got.Asks) != 1 {
+		t.Errorf("PublishView(1) want a new view with one level per side, got: %+v", got)
+	}
+}
+
+// Test_View_ConcurrentReads reads views while the market is being modified.
+// Run with -race to check that reads do not race with matching.
+func Test_View_ConcurrentReads(t *testing.T) {
+	pair := "USD/BTC"
+	tracker := newEventsTracker(10)
+	tracker.ignoreAll()
+	m := market.New(pair, tracker.orderEventsChan, tracker.volumeEventsChan, tracker.matchEventsChan)
+
+	done := make(chan struct{})
+	errs := make(chan error, 4)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var last *market.View
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				v := m.View()
+				if last != nil && v.Sequence < last.Sequence {
+					errs <- fmt.Errorf("sequence went back from %d to %d", last.Sequence, v.Sequence)
+					return
+				}
+
+				if !slices.IsSortedFunc(v.Bids, func(a, b orderbook.Level) int { return int(b.Price) - int(a.Price) }) {
+					errs <- fmt.Errorf("bids not descending: %v", v.Bids)
+					return
+				}
+
+				if !slices.IsSortedFunc(v.Asks, func(a, b orderbook.Level) int { return int(a.Price) - int(b.Price) }) {
+					errs <- fmt.Errorf("asks not ascending: %v", v.Asks)
+					return
+				}
+
+				if len(v.Bids) > 0 && len(v.Asks) > 0 && v.Bids[0].Price >= v.Asks[0].Price {
+					errs <- fmt.Errorf("crossed view: bid %d, ask %d", v.Bids[0].Price, v.Asks[0].Price)
+					return
+				}
+
+				last = v
+			}
+		}()
+	}
+
+	r := rand.New(rand.NewSource(1))
+	resting := []*order.Order{}
+	for i := range 5_000 {
+		if len(resting) > 0 && r.Intn(3) == 0 {
+			j := r.Intn(len(resting))
+			if err := m.Cancel(resting[j]); err != nil {
+				t.Fatalf("Cancel(%v) unexpected error: %v", resting[j], err)
+			}
+			resting = slices.Delete(resting, j, j+1)
+		} else {
+			o := &order.Order{ID: fmt.Sprint(i), Pair: pair, Price: uint64(r.Intn(100)) + 1, Volume: 1, Side: order.OrderBuy}
+			if r.Intn(2) == 0 {
+				o.Side = order.OrderSell
+				o.Price += 100
+			}
+
+			if err := m.InsertMakerOrder(o); err != nil {
+				t.Fatalf("InsertMakerOrder(%v) unexpected error: %v", o, err)
+			}
+			resting = append(resting, o)
+		}
+
+		if i%10 == 0 {
+			m.PublishView(20)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -1,6 +1,9 @@
 package order
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type OrderSide int
 
@@ -9,8 +12,11 @@ const (
 	OrderSell
 )
 
-// Order contains the information needed for the orderbook to operate.
-// It does not contain meta-information, to keep the orderbook lightweight.
+// Order contains the information needed for the market to operate: the
+// price, side and volume matched by the orderbook, the volume filled while
+// resting, and what the market needs to cancel, expire, trigger and reprice
+// it: its account and session, expiry, stop price, peg and trail. Client
+// order IDs, creation times and statuses are kept by the services.
 type Order struct {
 	// A unique identifier for this order.
 	ID string
@@ -18,6 +24,14 @@ type Order struct {
 	// The market pair name the order is transacting in.
 	Pair string
 
+	// The account that placed the order, empty if unknown. Only used to cancel
+	// the orders of an account at once.
+	Account string
+
+	// The session of the account the order is tagged with, if any. Only used
+	// to cancel the orders of a session at once.
+	Session string
+
 	// Whether the order is on the buy or sell side.
 	Side OrderSide
 
@@ -31,6 +45,29 @@ type Order struct {
 	// This is an "in-memory" volume that will be reduced when matching the order.
 	// When the volume is reduced to 0, the order is considered fulfilled.
 	Volume uint64
+
+	// The volume matched so far while resting in a book. Filled plus Volume is
+	// the total volume of a resting order.
+	Filled uint64
+
+	// When a resting limit order is removed from the book, zero for orders
+	// that rest until cancelled. Ignored for market orders.
+	ExpiresAt time.Time
+
+	// The last trade price that triggers a stop order, 0 for any other order.
+	// Buy stops trigger at or above it, sell stops at or below it. A triggered
+	// stop is matched as a market order, or inserted as a limit order if it has
+	// a Price.
+	StopPrice uint64
+
+	// How the price of a pegged order follows the book, nil for orders with a
+	// fixed price. The market keeps the Price of a resting pegged order up to
+	// date.
+	Peg *Peg
+
+	// How the StopPrice of a trailing stop order follows the last trade price,
+	// nil for any other order. The market keeps the StopPrice up to date.
+	Trail *Trail
 }
 
 func New(ID string, pair string, price uint64, volume uint64, side OrderSide) (*Order, error) {
@@ -0,0 +1,28 @@
+package order
+
+// The price a pegged order follows.
+type PegType int
+
+const (
+	// The best price of the side of the order: the best bid for buy orders,
+	// the best offer for sell orders.
+	PegPrimary PegType = iota
+
+	// The best price of the opposite side: the best offer for buy orders, the
+	// best bid for sell orders.
+	PegMarket
+
+	// The midpoint between the best bid and the best offer.
+	PegMidpoint
+)
+
+// Peg defines the price of an order relative to the book, instead of a fixed
+// price.
+type Peg struct {
+	Type PegType
+
+	// Moves the price away from the pegged price, towards the opposite side
+	// when positive: added to the price of buy orders, and subtracted from the
+	// price of sell orders.
+	Offset int64
+}
@@ -0,0 +1,21 @@
+package order
+
+// Trail is how far the stop price of a trailing stop order follows the best
+// last trade price: the highest for sell orders, the lowest for buy orders.
+// Only one of its fields is set.
+type Trail struct {
+	// A fixed distance in price.
+	Amount uint64
+
+	// A distance relative to the best price, in hundredths of a percent.
+	BasisPoints uint64
+}
+
+// Distance returns how far the stop price is from the given best price.
+func (t Trail) Distance(price uint64) uint64 {
+	if t.BasisPoints > 0 {
+		return price/10_000*t.BasisPoints + price%10_000*t.BasisPoints/10_000
+	}
+
+	return t.Amount
+}
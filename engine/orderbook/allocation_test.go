@@ -0,0 +1,92 @@
+package orderbook_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+	"exchange/engine/orderbook/pricelevel"
+)
+
+// Test_Match_Allocator_Properties matches random volumes against books using
+// each allocator. The volume of the matches must always be the volume the
+// levels lost, and levels are consumed from the best price.
+func Test_Match_Allocator_Properties(t *testing.T) {
+	allocators := []struct {
+		name      string
+		allocator pricelevel.Allocator
+	}{
+		{name: "fifo", allocator: pricelevel.FIFO{}},
+		{name: "pro_rata", allocator: pricelevel.ProRata{MinAllocation: 2, Lot: 2}},
+		{name: "top_order_pro_rata", allocator: pricelevel.TopOrderProRata{ProRata: pricelevel.ProRata{Lot: 3}}},
+	}
+
+	for _, a := range allocators {
+		t.Run(a.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+
+			volumes := map[uint64]uint64{}
+			book := orderbook.New(order.OrderSell, nodePool(), func(price uint64, volume uint64) {
+				volumes[price] = volume
+			})
+			book.SetAllocator(a.allocator)
+
+			for i := range 2_000 {
+				o := &order.Order{ID: strconv.Itoa(i), Price: uint64(r.Intn(20)) + 1, Volume: uint64(r.Intn(50)) + 1, Side: order.OrderSell}
+				if err := book.Insert(o); err != nil {
+					t.Fatalf("Insert(%v) unexpected error: %v", o, err)
+				}
+
+				if r.Intn(4) != 0 {
+					continue
+				}
+
+				before := book.Snapshot()
+				total := uint64(0)
+				for _, v := range before {
+					total += v
+				}
+
+				volume := uint64(r.Intn(300)) + 1
+				matches, remaining := book.MatchAndExtract(volume, nil)
+
+				consumed := min(volume, total)
+				if remaining != volume-consumed {
+					t.Fatalf("operation %d: MatchAndExtract(%d) remaining want: %d, got: %d", i, volume, volume-consumed, remaining)
+				}
+
+				matched := map[uint64]uint64{}
+				for _, m := range matches {
+					matched[m.MakerOrder.Price] += m.VolumeTaken
+				}
+
+				after := book.Snapshot()
+				lost := uint64(0)
+				for price, v := range before {
+					if before[price]-after[price] != matched[price] {
+						t.Fatalf("operation %d: price %d lost %d, matched %d", i, price, before[price]-after[price], matched[price])
+					}
+
+					if after[price] != 0 && volumes[price] != after[price] {
+						t.Fatalf("operation %d: price %d volume update %d, want %d", i, price, volumes[price], after[price])
+					}
+
+					lost += v - after[price]
+				}
+
+				if lost != consumed {
+					t.Fatalf("operation %d: levels lost %d, consumed %d", i, lost, consumed)
+				}
+
+				// Only the worst matched price can keep volume.
+				for price := range matched {
+					if after[price] != 0 && price != matches[len(matches)-1].MakerOrder.Price {
+						t.Fatalf("operation %d: price %d matched before a worse price was fully consumed", i, price)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package orderbook_test
+
+import (
+	"fmt"
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+	"exchange/engine/testutils"
+)
+
+// Test_Match_Allocs checks that inserting and matching orders across levels
+// does not allocate once the buffers and pools are warm.
+func Test_Match_Allocs(t *testing.T) {
+	if testutils.RaceEnabled {
+		t.Skip("sync.Pool drops items with -race")
+	}
+
+	for _, index := range benchmarkIndices {
+		t.Run(index.name, func(t *testing.T) {
+			orders := make([]order.Order, 1_000)
+			for i := range orders {
+				orders[i] = order.Order{ID: fmt.Sprint(i), Price: uint64(i%50) + 1, Side: order.OrderSell}
+			}
+
+			book := index.book(order.OrderSell)
+			var matches []order.Match
+
+			for _, allocator := range []pricelevel.Allocator{nil, pricelevel.ProRata{}} {
+				book.SetAllocator(allocator)
+
+				allocs := testing.AllocsPerRun(100, func() {
+					total := uint64(0)
+					for i := range orders {
+						orders[i].Volume = uint64(i%7) + 1
+						total += orders[i].Volume
+						if err := book.Insert(&orders[i]); err != nil {
+							t.Fatalf("Insert() unexpected error: %v", err)
+						}
+					}
+
+					matches, _ = book.MatchAndExtract(total/3, matches[:0])
+					matches, _ = book.MatchAndExtract(total, matches[:0])
+				})
+
+				if allocs != 0 {
+					t.Errorf("AllocsPerRun() with allocator %v want: 0, got: %v", allocator, allocs)
+				}
+
+				if book.HeadPrice() != 0 {
+					t.Errorf("HeadPrice() want: 0, got: %d", book.HeadPrice())
+				}
+			}
+		})
+	}
+}
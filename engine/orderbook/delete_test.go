@@ -3,7 +3,6 @@ package orderbook_test
 import (
 	"exchange/engine/order"
 	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
 	"sync"
 	"testing"
 
@@ -165,7 +164,7 @@ func Test_Delete(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 
@@ -254,7 +253,7 @@ func Test_Delete_VolumeUpdate(t *testing.T) {
 
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 
@@ -363,7 +362,7 @@ func Test_Delete_HeadPrice(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 
@@ -0,0 +1,49 @@
+package orderbook
+
+import "iter"
+
+// Level is the aggregated state of one price of the book.
+type Level struct {
+	Price uint64
+
+	Volume uint64
+
+	// The number of orders resting at this price.
+	Orders int
+}
+
+// Depth returns up to n levels of the book, from the best price to the worst.
+// For a sell-side book prices are ascending, and for a buy-side book they are
+// descending.
+//
+// O(n), independent of the size of the book.
+func (b *OrderBook) Depth(n int) []Level {
+	if n <= 0 {
+		return []Level{}
+	}
+
+	levels := make([]Level, 0, min(n, b.index.Len()))
+
+	for level := range b.Levels() {
+		levels = append(levels, level)
+
+		if len(levels) == n {
+			break
+		}
+	}
+
+	return levels
+}
+
+// Levels returns the levels of the book, from the best price to the worst.
+//
+// O(1) per level.
+func (b *OrderBook) Levels() iter.Seq[Level] {
+	return func(yield func(Level) bool) {
+		for price, level := range b.index.FromHead() {
+			if !yield(Level{Price: price, Volume: level.Volume(), Orders: level.Len()}) {
+				return
+			}
+		}
+	}
+}
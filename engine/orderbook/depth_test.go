@@ -0,0 +1,99 @@
+package orderbook_test
+
+import (
+	"sync"
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Depth(t *testing.T) {
+	insertions := []*order.Order{
+		{ID: "1", Price: 2, Volume: 1},
+		{ID: "2", Price: 2, Volume: 2},
+		{ID: "3", Price: 1, Volume: 3},
+		{ID: "4", Price: 4, Volume: 4},
+		{ID: "5", Price: 3, Volume: 5},
+		{ID: "6", Price: 3, Volume: 6},
+		{ID: "7", Price: 3, Volume: 7},
+	}
+
+	testCases := []struct {
+		name      string
+		side      order.OrderSide
+		n         int
+		wantDepth []orderbook.Level
+	}{
+		{
+			name:      "zero",
+			side:      order.OrderBuy,
+			n:         0,
+			wantDepth: []orderbook.Level{},
+		},
+		{
+			name: "buy_all",
+			side: order.OrderBuy,
+			n:    10,
+			wantDepth: []orderbook.Level{
+				{Price: 4, Volume: 4, Orders: 1},
+				{Price: 3, Volume: 18, Orders: 3},
+				{Price: 2, Volume: 3, Orders: 2},
+				{Price: 1, Volume: 3, Orders: 1},
+			},
+		},
+		{
+			name: "buy_top",
+			side: order.OrderBuy,
+			n:    2,
+			wantDepth: []orderbook.Level{
+				{Price: 4, Volume: 4, Orders: 1},
+				{Price: 3, Volume: 18, Orders: 3},
+			},
+		},
+		{
+			name: "sell_all",
+			side: order.OrderSell,
+			n:    4,
+			wantDepth: []orderbook.Level{
+				{Price: 1, Volume: 3, Orders: 1},
+				{Price: 2, Volume: 3, Orders: 2},
+				{Price: 3, Volume: 18, Orders: 3},
+				{Price: 4, Volume: 4, Orders: 1},
+			},
+		},
+		{
+			name: "sell_top",
+			side: order.OrderSell,
+			n:    1,
+			wantDepth: []orderbook.Level{
+				{Price: 1, Volume: 3, Orders: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &sync.Pool{
+				New: func() any {
+					return orderbook.NewPriceNode()
+				},
+			}
+			b := orderbook.New(tc.side, pool, func(uint64, uint64) {})
+
+			for _, o := range insertions {
+				o := *o
+				o.Side = tc.side
+				if err := b.Insert(&o); err != nil {
+					t.Fatalf("Insert(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantDepth, b.Depth(tc.n)); diff != "" {
+				t.Errorf("Depth(%d) diff (-want, +got):\n%s", tc.n, diff)
+			}
+		})
+	}
+}
@@ -6,10 +6,6 @@ package orderbook
 //
 // O(1)
 func (o OrderBook) HeadPrice() uint64 {
-	head := o.priceTree.Head()
-	if head == nil {
-		return 0
-	}
-
-	return head.Price
+	price, _, _ := o.index.Head()
+	return price
 }
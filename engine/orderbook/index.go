@@ -0,0 +1,32 @@
+package orderbook
+
+import (
+	"iter"
+
+	"exchange/engine/orderbook/pricelevel"
+)
+
+// PriceIndex keeps the price levels of one side of a book, ordered so that
+// the best price is at its head: the minimum for a sell-side book, and the
+// maximum for a buy-side book.
+type PriceIndex interface {
+	// Get returns the level of the price, if it exists.
+	Get(price uint64) (*pricelevel.PriceLevel, bool)
+
+	// Insert returns the level of the price, creating it if it does not exist.
+	Insert(price uint64) (*pricelevel.PriceLevel, error)
+
+	// Delete removes the level of the price, if it exists. The level must not
+	// be used afterwards.
+	Delete(price uint64)
+
+	// Head returns the best price and its level, or false if the index is empty.
+	Head() (uint64, *pricelevel.PriceLevel, bool)
+
+	// FromHead iterates the levels from the best price to the worst. The index
+	// must not be modified during the iteration.
+	FromHead() iter.Seq2[uint64, *pricelevel.PriceLevel]
+
+	// Len returns the number of levels.
+	Len() int
+}
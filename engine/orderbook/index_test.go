@@ -0,0 +1,274 @@
+package orderbook_test
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook"
+	"exchange/engine/orderbook/pricelevel"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// benchmarkIndices are the price indices compared by the benchmarks, covering
+// the prices of every benchmark case.
+var benchmarkIndices = []struct {
+	name string
+	book func(side order.OrderSide) *orderbook.OrderBook
+}{
+	{
+		name: "tree",
+		book: func(side order.OrderSide) *orderbook.OrderBook {
+			return orderbook.New(side, nodePool(), func(uint64, uint64) {})
+		},
+	},
+	{
+		name: "ticks",
+		book: func(side order.OrderSide) *orderbook.OrderBook {
+			index, err := orderbook.NewTickIndex(side, orderbook.PriceRange{Min: 1, Max: 100_000, Tick: 1})
+			if err != nil {
+				panic(err)
+			}
+			return orderbook.NewWithIndex(side, index, func(uint64, uint64) {})
+		},
+	},
+}
+
+func nodePool() *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			return orderbook.NewPriceNode()
+		},
+	}
+}
+
+type indexLevel struct {
+	Price  uint64
+	Volume uint64
+}
+
+// levels returns the levels of the index from the head.
+func levels(index orderbook.PriceIndex) []indexLevel {
+	res := []indexLevel{}
+	for price, level := range index.FromHead() {
+		res = append(res, indexLevel{price, level.Volume()})
+	}
+
+	return res
+}
+
+func Test_PriceRange(t *testing.T) {
+	testCases := []struct {
+		name      string
+		prices    orderbook.PriceRange
+		wantValid bool
+		price     uint64
+		wantErr   error
+		wantTicks int
+	}{
+		{
+			name:   "zero_tick",
+			prices: orderbook.PriceRange{Min: 1, Max: 10},
+		},
+		{
+			name:   "zero_min",
+			prices: orderbook.PriceRange{Min: 0, Max: 10, Tick: 1},
+		},
+		{
+			name:   "empty",
+			prices: orderbook.PriceRange{Min: 10, Max: 5, Tick: 1},
+		},
+		{
+			name:   "max_off_tick",
+			prices: orderbook.PriceRange{Min: 10, Max: 25, Tick: 10},
+		},
+		{
+			name:   "too_many_ticks",
+			prices: orderbook.PriceRange{Min: 1, Max: 1 << 32, Tick: 1},
+		},
+		{
+			name:      "single_price",
+			prices:    orderbook.PriceRange{Min: 5, Max: 5, Tick: 1},
+			wantValid: true,
+			price:     5,
+			wantTicks: 1,
+		},
+		{
+			name:      "on_tick",
+			prices:    orderbook.PriceRange{Min: 10, Max: 100, Tick: 5},
+			wantValid: true,
+			price:     55,
+			wantTicks: 19,
+		},
+		{
+			name:      "off_tick",
+			prices:    orderbook.PriceRange{Min: 10, Max: 100, Tick: 5},
+			wantValid: true,
+			price:     56,
+			wantErr:   orderbook.PriceOffTickErr,
+			wantTicks: 19,
+		},
+		{
+			name:      "below",
+			prices:    orderbook.PriceRange{Min: 10, Max: 100, Tick: 5},
+			wantValid: true,
+			price:     5,
+			wantErr:   orderbook.PriceOutOfRangeErr,
+			wantTicks: 19,
+		},
+		{
+			name:      "above",
+			prices:    orderbook.PriceRange{Min: 10, Max: 100, Tick: 5},
+			wantValid: true,
+			price:     105,
+			wantErr:   orderbook.PriceOutOfRangeErr,
+			wantTicks: 19,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.prices.Valid()
+			if tc.wantValid != (err == nil) {
+				t.Fatalf("Valid() want valid: %v, got error: %v", tc.wantValid, err)
+			}
+
+			if !tc.wantValid {
+				if _, err := orderbook.NewTickIndex(order.OrderBuy, tc.prices); err == nil {
+					t.Errorf("NewTickIndex() want error, got nil")
+				}
+				return
+			}
+
+			if got := tc.prices.Ticks(); got != tc.wantTicks {
+				t.Errorf("Ticks() want: %d, got: %d", tc.wantTicks, got)
+			}
+
+			if err := tc.prices.Check(tc.price); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Check(%d) want error: %v, got: %v", tc.price, tc.wantErr, err)
+			}
+
+			index, err := orderbook.NewTickIndex(order.OrderBuy, tc.prices)
+			if err != nil {
+				t.Fatalf("NewTickIndex() unexpected error: %v", err)
+			}
+
+			if _, err := index.Insert(tc.price); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Insert(%d) want error: %v, got: %v", tc.price, tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// Test_TickIndex_Equivalence applies the same random operations to both
+// indices, which must always hold the same levels in the same order.
+func Test_TickIndex_Equivalence(t *testing.T) {
+	testCases := []struct {
+		name   string
+		side   order.OrderSide
+		prices orderbook.PriceRange
+	}{
+		{
+			name:   "buy_dense",
+			side:   order.OrderBuy,
+			prices: orderbook.PriceRange{Min: 1, Max: 100, Tick: 1},
+		},
+		{
+			name:   "sell_dense",
+			side:   order.OrderSell,
+			prices: orderbook.PriceRange{Min: 1, Max: 100, Tick: 1},
+		},
+		{
+			name:   "buy_sparse",
+			side:   order.OrderBuy,
+			prices: orderbook.PriceRange{Min: 1_000, Max: 101_000, Tick: 10},
+		},
+		{
+			name:   "sell_sparse",
+			side:   order.OrderSell,
+			prices: orderbook.PriceRange{Min: 1_000, Max: 101_000, Tick: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+
+			ticks, err := orderbook.NewTickIndex(tc.side, tc.prices)
+			if err != nil {
+				t.Fatalf("NewTickIndex() unexpected error: %v", err)
+			}
+			tree := orderbook.NewTreeIndex(tc.side, nodePool())
+
+			for i := range 5_000 {
+				price := tc.prices.Min + uint64(r.Intn(tc.prices.Ticks()))*tc.prices.Tick
+
+				if r.Intn(3) == 0 {
+					ticks.Delete(price)
+					tree.Delete(price)
+				} else {
+					for _, index := range []orderbook.PriceIndex{ticks, tree} {
+						level, err := index.Insert(price)
+						if err != nil {
+							t.Fatalf("operation %d: Insert(%d) unexpected error: %v", i, price, err)
+						}
+
+						if err := level.Insert(&order.Order{ID: strconv.Itoa(i), Price: price, Volume: uint64(i) + 1}); err != nil {
+							t.Fatalf("operation %d: level insert unexpected error: %v", i, err)
+						}
+					}
+				}
+
+				if ticks.Len() != tree.Len() {
+					t.Fatalf("operation %d: Len() want: %d, got: %d", i, tree.Len(), ticks.Len())
+				}
+
+				wantPrice, wantLevel, wantOk := tree.Head()
+				gotPrice, gotLevel, gotOk := ticks.Head()
+				if wantPrice != gotPrice || wantOk != gotOk || wantOk && wantLevel.Volume() != gotLevel.Volume() {
+					t.Fatalf("operation %d: Head() want: %d %v, got: %d %v", i, wantPrice, wantOk, gotPrice, gotOk)
+				}
+
+				_, wantExists := tree.Get(price)
+				if _, gotExists := ticks.Get(price); wantExists != gotExists {
+					t.Fatalf("operation %d: Get(%d) want exists: %v, got: %v", i, price, wantExists, gotExists)
+				}
+			}
+
+			if diff := cmp.Diff(levels(tree), levels(ticks)); diff != "" {
+				t.Errorf("FromHead() diff (-tree, +ticks):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_TickIndex_ReusesLevels(t *testing.T) {
+	index, err := orderbook.NewTickIndex(order.OrderSell, orderbook.PriceRange{Min: 1, Max: 10, Tick: 1})
+	if err != nil {
+		t.Fatalf("NewTickIndex() unexpected error: %v", err)
+	}
+
+	var first *pricelevel.PriceLevel
+	first, _ = index.Insert(5)
+	if err := first.Insert(&order.Order{ID: "1", Price: 5, Volume: 3}); err != nil {
+		t.Fatalf("level insert unexpected error: %v", err)
+	}
+
+	index.Delete(5)
+	if _, _, ok := index.Head(); ok {
+		t.Errorf("Head() after deleting the only level, want empty")
+	}
+
+	second, _ := index.Insert(5)
+	if first != second {
+		t.Errorf("Insert() after Delete() did not reuse the level")
+	}
+
+	if second.Volume() != 0 {
+		t.Errorf("Insert() after Delete() want an empty level, got volume: %d", second.Volume())
+	}
+}
@@ -4,37 +4,30 @@ import (
 	"fmt"
 
 	"exchange/engine/order"
-	"exchange/engine/orderbook/rbtree"
 )
 
-// O(log n)
-func (b *OrderBook) insertPriceNode(price uint64) *rbtree.Node {
-	priceNode := b.priceTree.Insert(price) // O(log n)
-	b.priceMap[price] = priceNode
-
-	return priceNode
-}
-
-// Insert colocates an order in its correct price node, creating the node if it
-// doesn't exist.
+// Insert colocates an order in its correct price level, creating the level if
+// it doesn't exist.
 //
-// O(log n):
+// O(log n) with a TreeIndex:
 // O(1) if the price already exists
 // O(log n) to insert a new price node
+//
+// O(1) with a TickIndex.
 func (b *OrderBook) Insert(o *order.Order) error {
 	if o.Side != b.side {
 		return fmt.Errorf("OrderBook.Insert(%q) different sides %v!=%v", o.ID, b.side, o.Side)
 	}
 
-	priceNode, exists := b.priceMap[o.Price] // O(1)
-	if !exists {
-		priceNode = b.insertPriceNode(o.Price) // O(log n)
+	level, err := b.index.Insert(o.Price)
+	if err != nil {
+		return fmt.Errorf("OrderBook.Insert(%q): %w", o.ID, err)
 	}
 
-	if err := priceNode.Orders.Insert(o); err != nil { // O(1)
+	if err := level.Insert(o); err != nil { // O(1)
 		return err
 	}
 
-	b.volumeUpdateCallback(o.Price, priceNode.Orders.Volume())
+	b.volumeUpdateCallback(o.Price, level.Volume())
 	return nil
 }
@@ -2,10 +2,7 @@ package orderbook_test
 
 import (
 	"exchange/engine/order"
-	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
 	"exchange/engine/testutils"
-	"sync"
 	"testing"
 )
 
@@ -49,26 +46,23 @@ func Benchmark_Insert(b *testing.B) {
 	}
 
 	for _, tc := range testCases {
-		b.Run(tc.name, func(b *testing.B) {
-			b.ReportAllocs()
+		for _, index := range benchmarkIndices {
+			b.Run(tc.name+"/"+index.name, func(b *testing.B) {
+				b.ReportAllocs()
 
-			for range b.N {
-				b.StopTimer()
-				pool := &sync.Pool{
-					New: func() any {
-						return rbtree.NewNode()
-					},
-				}
-				book := orderbook.New(order.OrderBuy, pool, func(uint64, uint64) {})
+				for range b.N {
+					b.StopTimer()
+					book := index.book(order.OrderBuy)
 
-				b.StartTimer()
+					b.StartTimer()
 
-				for _, o := range tc.orders {
-					if err := book.Insert(o); err != nil {
-						b.Fatalf("Insert() unexpected error: %v", err)
+					for _, o := range tc.orders {
+						if err := book.Insert(o); err != nil {
+							b.Fatalf("Insert() unexpected error: %v", err)
+						}
 					}
 				}
-			}
-		})
+			})
+		}
 	}
 }
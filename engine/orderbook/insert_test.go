@@ -3,7 +3,6 @@ package orderbook_test
 import (
 	"exchange/engine/order"
 	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
 	"sync"
 	"testing"
 
@@ -71,7 +70,7 @@ func Test_Insert(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(order.OrderBuy, pool, func(uint64, uint64) {})
@@ -132,7 +131,7 @@ func Test_Insert_VolumeUpdate(t *testing.T) {
 
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 
@@ -224,7 +223,7 @@ func Test_Insert_HeadPrice(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 
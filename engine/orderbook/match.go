@@ -4,28 +4,32 @@ import (
 	"exchange/engine/order"
 )
 
-// MatchAndExtract will return all the orders needed to fill the required volume,
-// and returns the volume that could not be extracted.
+// MatchAndExtract will append to matches all the orders needed to fill the
+// required volume, and returns them with the volume that could not be
+// extracted. The levels are matched from the best price, each split between its
+// orders by the allocator of the book.
+//
+// Reusing the matches buffer between calls, matching does not allocate in
+// steady state.
 //
 // O(n)
-func (b *OrderBook) MatchAndExtract(volume uint64) ([]*order.Match, uint64) {
-	totalMatches := make([]*order.Match, 0, 10)
-
-	var matches []*order.Match
+func (b *OrderBook) MatchAndExtract(volume uint64, matches []order.Match) ([]order.Match, uint64) {
 	for volume > 0 {
-		head := b.priceTree.Head() // O(1)
-		if head == nil {
+		price, level, ok := b.index.Head() // O(1)
+		if !ok {
 			break
 		}
 
-		matches, volume = head.Orders.MatchAndExtract(volume) // O(n)
-		b.volumeUpdateCallback(head.Price, head.Orders.Volume())
+		matches, volume = level.MatchAndExtractWith(b.allocator, volume, matches) // O(n)
+		b.volumeUpdateCallback(price, level.Volume())
 
-		if head.Orders.Volume() == 0 {
-			b.deletePriceNode(head) // O(log n)
+		if level.Volume() > 0 {
+			// The volume left was allocated within this level.
+			break
 		}
-		totalMatches = append(totalMatches, matches...)
+
+		b.index.Delete(price)
 	}
 
-	return totalMatches, volume
+	return matches, volume
 }
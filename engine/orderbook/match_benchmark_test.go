@@ -2,10 +2,7 @@ package orderbook_test
 
 import (
 	"exchange/engine/order"
-	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
 	"exchange/engine/testutils"
-	"sync"
 	"testing"
 )
 
@@ -67,32 +64,30 @@ func Benchmark_Match(b *testing.B) {
 	}
 
 	for _, tc := range testCases {
-		b.Run(tc.name, func(b *testing.B) {
-			b.ReportAllocs()
+		for _, index := range benchmarkIndices {
+			b.Run(tc.name+"/"+index.name, func(b *testing.B) {
+				b.ReportAllocs()
 
-			for range b.N {
-				b.StopTimer()
-				pool := &sync.Pool{
-					New: func() any {
-						return rbtree.NewNode()
-					},
-				}
-				book := orderbook.New(order.OrderBuy, pool, func(uint64, uint64) {})
+				var matches []order.Match
+				for range b.N {
+					b.StopTimer()
+					book := index.book(order.OrderBuy)
 
-				orders := testutils.OrdersDeterministic("USD/GBP", order.OrderBuy, 1, tc.maxPrice, 1, tc.maxVolume, tc.orderCount)
-				totalVolume := (tc.maxVolume * (tc.maxVolume + 1)) * (tc.orderCount / tc.maxVolume)
-				extractVolume := uint64(float64(totalVolume) * 0.85)
+					orders := testutils.OrdersDeterministic("USD/GBP", order.OrderBuy, 1, tc.maxPrice, 1, tc.maxVolume, tc.orderCount)
+					totalVolume := (tc.maxVolume * (tc.maxVolume + 1)) * (tc.orderCount / tc.maxVolume)
+					extractVolume := uint64(float64(totalVolume) * 0.85)
 
-				for _, o := range orders {
-					if err := book.Insert(o); err != nil {
-						b.Fatalf("Insert() unexpected error: %v", err)
+					for _, o := range orders {
+						if err := book.Insert(o); err != nil {
+							b.Fatalf("Insert() unexpected error: %v", err)
+						}
 					}
-				}
 
-				b.StartTimer()
+					b.StartTimer()
 
-				book.MatchAndExtract(extractVolume)
-			}
-		})
+					matches, _ = book.MatchAndExtract(extractVolume, matches[:0])
+				}
+			})
+		}
 	}
 }
@@ -3,7 +3,6 @@ package orderbook_test
 import (
 	"exchange/engine/order"
 	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
 	"sync"
 	"testing"
 
@@ -17,7 +16,7 @@ func Test_Match(t *testing.T) {
 		side                order.OrderSide
 		orders              []*order.Order
 		matchVolume         uint64
-		wantMatches         []*order.Match
+		wantMatches         []order.Match
 		wantUnmatchedVolume uint64
 	}{
 		{
@@ -39,8 +38,8 @@ func Test_Match(t *testing.T) {
 				{ID: "1", Price: 1, Volume: 50, Side: order.OrderBuy},
 			},
 			matchVolume: 25,
-			wantMatches: []*order.Match{
-				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+			wantMatches: []order.Match{
+				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 25, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
 			},
 		},
 		{
@@ -50,8 +49,8 @@ func Test_Match(t *testing.T) {
 				{ID: "1", Price: 1, Volume: 50, Side: order.OrderBuy},
 			},
 			matchVolume: 50,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 50},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 50, Side: order.OrderBuy}, VolumeTaken: 50},
 			},
 		},
 		{
@@ -61,8 +60,8 @@ func Test_Match(t *testing.T) {
 				{ID: "1", Price: 1, Volume: 50, Side: order.OrderBuy},
 			},
 			matchVolume: 60,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 50},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 50, Side: order.OrderBuy}, VolumeTaken: 50},
 			},
 			wantUnmatchedVolume: 10,
 		},
@@ -74,8 +73,8 @@ func Test_Match(t *testing.T) {
 				{ID: "2", Price: 1, Volume: 25, Side: order.OrderBuy},
 			},
 			matchVolume: 25,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
 			},
 		},
 		{
@@ -86,9 +85,9 @@ func Test_Match(t *testing.T) {
 				{ID: "2", Price: 1, Volume: 25, Side: order.OrderBuy},
 			},
 			matchVolume: 35,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 15, Side: order.OrderBuy}, VolumeTaken: 10},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 15, Filled: 10, Side: order.OrderBuy}, VolumeTaken: 10},
 			},
 		},
 		{
@@ -99,9 +98,9 @@ func Test_Match(t *testing.T) {
 				{ID: "2", Price: 1, Volume: 25, Side: order.OrderBuy},
 			},
 			matchVolume: 50,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
 			},
 		},
 		{
@@ -112,9 +111,9 @@ func Test_Match(t *testing.T) {
 				{ID: "2", Price: 1, Volume: 25, Side: order.OrderBuy},
 			},
 			matchVolume: 60,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
 			},
 			wantUnmatchedVolume: 10,
 		},
@@ -133,14 +132,14 @@ func Test_Match(t *testing.T) {
 				{ID: "9", Price: 3, Volume: 25, Side: order.OrderSell},
 			},
 			matchVolume: 165,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderSell}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 0, Side: order.OrderSell}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Price: 1, Volume: 0, Side: order.OrderSell}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "6", Price: 1, Volume: 0, Side: order.OrderSell}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Price: 2, Volume: 0, Side: order.OrderSell}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Price: 2, Volume: 0, Side: order.OrderSell}, VolumeTaken: 25},
-				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "7", Price: 3, Volume: 10, Side: order.OrderSell}, VolumeTaken: 15},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 25, Side: order.OrderSell}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 0, Filled: 25, Side: order.OrderSell}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Price: 1, Volume: 0, Filled: 25, Side: order.OrderSell}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "6", Price: 1, Volume: 0, Filled: 25, Side: order.OrderSell}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Price: 2, Volume: 0, Filled: 25, Side: order.OrderSell}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Price: 2, Volume: 0, Filled: 25, Side: order.OrderSell}, VolumeTaken: 25},
+				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "7", Price: 3, Volume: 10, Filled: 15, Side: order.OrderSell}, VolumeTaken: 15},
 			},
 		},
 		{
@@ -158,14 +157,14 @@ func Test_Match(t *testing.T) {
 				{ID: "9", Price: 3, Volume: 25, Side: order.OrderBuy},
 			},
 			matchVolume: 165,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "8", Price: 4, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "7", Price: 3, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "9", Price: 3, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Price: 2, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Price: 2, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Side: order.OrderBuy}, VolumeTaken: 25},
-				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 10, Side: order.OrderBuy}, VolumeTaken: 15},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "8", Price: 4, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "7", Price: 3, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "9", Price: 3, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Price: 2, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Price: 2, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Price: 1, Volume: 0, Filled: 25, Side: order.OrderBuy}, VolumeTaken: 25},
+				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "2", Price: 1, Volume: 10, Filled: 15, Side: order.OrderBuy}, VolumeTaken: 15},
 			},
 		},
 	}
@@ -174,7 +173,7 @@ func Test_Match(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(tc.side, pool, func(uint64, uint64) {})
@@ -185,7 +184,7 @@ func Test_Match(t *testing.T) {
 				}
 			}
 
-			gotMatches, gotUnmatchedVolume := b.MatchAndExtract(tc.matchVolume)
+			gotMatches, gotUnmatchedVolume := b.MatchAndExtract(tc.matchVolume, nil)
 
 			opts := cmp.Options{
 				cmpopts.EquateEmpty(),
@@ -355,7 +354,7 @@ func Test_Match_VolumeUpdate(t *testing.T) {
 
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(tc.side, pool, callback.call)
@@ -368,7 +367,7 @@ func Test_Match_VolumeUpdate(t *testing.T) {
 
 			callback.reset()
 
-			b.MatchAndExtract(tc.matchVolume)
+			b.MatchAndExtract(tc.matchVolume, nil)
 
 			if diff := cmp.Diff(tc.wantUpdates, callback.history, cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("Match() callback diff (-want, +got):\n%s", diff)
@@ -492,7 +491,7 @@ func Test_Match_HeadPrice(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(tc.side, pool, func(uint64, uint64) {})
@@ -503,7 +502,7 @@ func Test_Match_HeadPrice(t *testing.T) {
 				}
 			}
 
-			b.MatchAndExtract(tc.matchVolume)
+			b.MatchAndExtract(tc.matchVolume, nil)
 
 			gotHeadPrice := b.HeadPrice()
 
@@ -2,36 +2,53 @@ package orderbook
 
 import (
 	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
 	"exchange/engine/orderbook/rbtree"
 	"sync"
 )
 
+// PriceNode is a node of the price tree, holding the orders at its price.
+type PriceNode = rbtree.Node[uint64, *pricelevel.PriceLevel]
+
+// NewPriceNode returns an empty price node, to be created by the node pool
+// shared by the books of a market.
+func NewPriceNode() *PriceNode {
+	return rbtree.NewNode[uint64](pricelevel.New())
+}
+
 type OrderBook struct {
 	// If this book is for buy or sell orders
 	side order.OrderSide
 
-	// The binary search tree to optimize price discovery
-	// Searches in O(log n). Inserts in O(log n)
-	priceTree *rbtree.Tree
+	// The price levels, ordered from the best price to the worst
+	index PriceIndex
 
-	// A map of price to tree node to know if a price exists
-	// Reads in O(1). Writes in O(1)
-	priceMap map[uint64]*rbtree.Node
+	// How the volume matched at a level is split between its orders, FIFO if
+	// nil
+	allocator pricelevel.Allocator
 
 	// A function to call when there is a change in volume
 	volumeUpdateCallback func(price uint64, volume uint64)
 }
 
+// New returns a book indexing its prices in a red-black tree, which supports
+// any price.
 func New(side order.OrderSide, nodePool *sync.Pool, volumeUpdateCallback func(uint64, uint64)) *OrderBook {
-	treeOrientation := rbtree.MinFirst
-	if side == order.OrderBuy {
-		treeOrientation = rbtree.MaxFirst
-	}
+	return NewWithIndex(side, NewTreeIndex(side, nodePool), volumeUpdateCallback)
+}
 
+// NewWithIndex returns a book using the given price index, which must be
+// empty and created for the same side.
+func NewWithIndex(side order.OrderSide, index PriceIndex, volumeUpdateCallback func(uint64, uint64)) *OrderBook {
 	return &OrderBook{
 		side:                 side,
-		priceTree:            rbtree.NewTree(treeOrientation, nodePool),
-		priceMap:             make(map[uint64]*rbtree.Node),
+		index:                index,
 		volumeUpdateCallback: volumeUpdateCallback,
 	}
 }
+
+// SetAllocator sets how the volume matched at each level is split between its
+// orders. Nil restores the default, FIFO.
+func (b *OrderBook) SetAllocator(allocator pricelevel.Allocator) {
+	b.allocator = allocator
+}
@@ -0,0 +1,107 @@
+package pricelevel
+
+import (
+	"math/bits"
+
+	"exchange/engine/order"
+)
+
+// Allocator splits the volume taken from a level between the orders queued in
+// it. Each market chooses one, FIFO by default.
+type Allocator interface {
+	// Allocate appends to allocations the volume given to each order, in queue
+	// order, and returns the slice. Orders is the queue front to back, and
+	// levelVolume is the sum of their volumes, which is always greater than
+	// volume. Neither orders nor the orders in it can be modified.
+	//
+	// The allocations must add up to volume, and none can be greater than the
+	// volume of its order. Trailing orders can be left out.
+	Allocate(orders []*order.Order, levelVolume uint64, volume uint64, allocations []uint64) []uint64
+}
+
+// FIFO allocates the volume to the orders in the sequence they arrived, every
+// order is filled before the next one is matched.
+type FIFO struct{}
+
+func (FIFO) Allocate(orders []*order.Order, _ uint64, volume uint64, allocations []uint64) []uint64 {
+	for _, o := range orders {
+		if volume == 0 {
+			break
+		}
+
+		taken := min(volume, o.Volume)
+		allocations = append(allocations, taken)
+		volume -= taken
+	}
+
+	return allocations
+}
+
+// ProRata allocates the volume in proportion to the volume of each order,
+// regardless of when it arrived.
+//
+// Each share is rounded down to a multiple of Lot, and shares lower than
+// MinAllocation are dropped. The volume left by rounding is allocated FIFO.
+type ProRata struct {
+	// The minimum volume allocated to an order by its share, 0 for any.
+	MinAllocation uint64
+
+	// The allocated shares are multiples of Lot, 0 or 1 for any volume.
+	Lot uint64
+}
+
+func (a ProRata) Allocate(orders []*order.Order, levelVolume uint64, volume uint64, allocations []uint64) []uint64 {
+	start := len(allocations)
+	left := volume
+
+	for _, o := range orders {
+		// volume*o.Volume may not fit in 64 bits, but the share always does
+		// because volume < levelVolume.
+		hi, lo := bits.Mul64(volume, o.Volume)
+		share, _ := bits.Div64(hi, lo, levelVolume)
+
+		if a.Lot > 1 {
+			share -= share % a.Lot
+		}
+
+		if share < a.MinAllocation {
+			share = 0
+		}
+
+		allocations = append(allocations, share)
+		left -= share
+	}
+
+	for i, o := range orders {
+		if left == 0 {
+			break
+		}
+
+		taken := min(left, o.Volume-allocations[start+i])
+		allocations[start+i] += taken
+		left -= taken
+	}
+
+	return allocations
+}
+
+// TopOrderProRata fills the order at the front of the queue first, and then
+// allocates the volume left to the rest of the orders pro-rata.
+type TopOrderProRata struct {
+	ProRata
+}
+
+func (a TopOrderProRata) Allocate(orders []*order.Order, levelVolume uint64, volume uint64, allocations []uint64) []uint64 {
+	if len(orders) == 0 {
+		return allocations
+	}
+
+	top := orders[0]
+	taken := min(volume, top.Volume)
+	allocations = append(allocations, taken)
+	if taken == volume {
+		return allocations
+	}
+
+	return a.ProRata.Allocate(orders[1:], levelVolume-top.Volume, volume-taken, allocations)
+}
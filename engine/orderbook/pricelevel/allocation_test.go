@@ -0,0 +1,221 @@
+package pricelevel_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// allocators are the allocators checked by the property tests.
+var allocators = []struct {
+	name      string
+	allocator pricelevel.Allocator
+}{
+	{name: "fifo", allocator: pricelevel.FIFO{}},
+	{name: "pro_rata", allocator: pricelevel.ProRata{}},
+	{name: "pro_rata_lot", allocator: pricelevel.ProRata{Lot: 5}},
+	{name: "pro_rata_min", allocator: pricelevel.ProRata{MinAllocation: 10}},
+	{name: "pro_rata_min_lot", allocator: pricelevel.ProRata{MinAllocation: 10, Lot: 5}},
+	{name: "top_order_pro_rata", allocator: pricelevel.TopOrderProRata{}},
+	{name: "top_order_pro_rata_min_lot", allocator: pricelevel.TopOrderProRata{ProRata: pricelevel.ProRata{MinAllocation: 10, Lot: 5}}},
+}
+
+// taken returns the volume taken from each order by the matches, by order ID.
+func taken(matches []order.Match) map[string]uint64 {
+	res := map[string]uint64{}
+	for _, m := range matches {
+		res[m.MakerOrder.ID] += m.VolumeTaken
+	}
+
+	return res
+}
+
+func Test_MatchAndExtractWith(t *testing.T) {
+	testCases := []struct {
+		name          string
+		allocator     pricelevel.Allocator
+		volumes       []uint64
+		matchVolume   uint64
+		wantTaken     map[string]uint64
+		wantRemaining uint64
+	}{
+		{
+			name:        "fifo",
+			allocator:   pricelevel.FIFO{},
+			volumes:     []uint64{10, 20, 70},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 10, "1": 20, "2": 20},
+		},
+		{
+			name:        "nil_is_fifo",
+			volumes:     []uint64{10, 20, 70},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 10, "1": 20, "2": 20},
+		},
+		{
+			name:        "pro_rata_exact",
+			allocator:   pricelevel.ProRata{},
+			volumes:     []uint64{10, 20, 70},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 5, "1": 10, "2": 35},
+		},
+		{
+			name:        "pro_rata_rounding_left_fifo",
+			allocator:   pricelevel.ProRata{},
+			volumes:     []uint64{1, 1, 1},
+			matchVolume: 2,
+			wantTaken:   map[string]uint64{"0": 1, "1": 1},
+		},
+		{
+			name:        "pro_rata_lot",
+			allocator:   pricelevel.ProRata{Lot: 2},
+			volumes:     []uint64{10, 20, 70},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 6, "1": 10, "2": 34},
+		},
+		{
+			name:        "pro_rata_min_allocation",
+			allocator:   pricelevel.ProRata{MinAllocation: 6},
+			volumes:     []uint64{20, 10, 70},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 15, "2": 35},
+		},
+		{
+			name:        "top_order_pro_rata",
+			allocator:   pricelevel.TopOrderProRata{},
+			volumes:     []uint64{10, 20, 70},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 10, "1": 9, "2": 31},
+		},
+		{
+			name:        "top_order_fills_volume",
+			allocator:   pricelevel.TopOrderProRata{},
+			volumes:     []uint64{60, 20, 20},
+			matchVolume: 50,
+			wantTaken:   map[string]uint64{"0": 50},
+		},
+		{
+			name:          "whole_level",
+			allocator:     pricelevel.ProRata{},
+			volumes:       []uint64{10, 20, 70},
+			matchVolume:   120,
+			wantTaken:     map[string]uint64{"0": 10, "1": 20, "2": 70},
+			wantRemaining: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := pricelevel.New()
+			for i, v := range tc.volumes {
+				if err := p.Insert(&order.Order{ID: fmt.Sprint(i), Volume: v, Price: 1}); err != nil {
+					t.Fatalf("Insert() unexpected error: %v", err)
+				}
+			}
+
+			matches, remaining := p.MatchAndExtractWith(tc.allocator, tc.matchVolume, nil)
+			if remaining != tc.wantRemaining {
+				t.Errorf("MatchAndExtractWith() remaining want: %d, got: %d", tc.wantRemaining, remaining)
+			}
+
+			if diff := cmp.Diff(tc.wantTaken, taken(matches)); diff != "" {
+				t.Errorf("MatchAndExtractWith() taken diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// Test_MatchAndExtractWith_Properties matches random volumes against random
+// levels. Whatever the allocator, the volume allocated to the orders must be
+// the volume consumed from the level.
+func Test_MatchAndExtractWith_Properties(t *testing.T) {
+	for _, a := range allocators {
+		t.Run(a.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+
+			for i := range 2_000 {
+				p := pricelevel.New()
+				orders := []*order.Order{}
+				levelVolume := uint64(0)
+				for j := range r.Intn(20) + 1 {
+					o := &order.Order{ID: fmt.Sprint(j), Volume: uint64(r.Intn(100)) + 1, Price: 1}
+					if r.Intn(10) == 0 {
+						// Large enough for volume*o.Volume to overflow, small
+						// enough for the level volume not to.
+						o.Volume = uint64(r.Int63n(1<<58)) + 1
+					}
+
+					if err := p.Insert(o); err != nil {
+						t.Fatalf("case %d: Insert() unexpected error: %v", i, err)
+					}
+					orders = append(orders, o)
+					levelVolume += o.Volume
+				}
+
+				original := map[string]uint64{}
+				for _, o := range orders {
+					original[o.ID] = o.Volume
+				}
+
+				volume := uint64(r.Int63n(int64(levelVolume))) + 1
+				if r.Intn(10) == 0 {
+					volume = levelVolume + uint64(r.Intn(10))
+				}
+
+				matches, remaining := p.MatchAndExtractWith(a.allocator, volume, nil)
+
+				consumed := min(volume, levelVolume)
+				if remaining != volume-consumed {
+					t.Fatalf("case %d: MatchAndExtractWith(%d) remaining want: %d, got: %d", i, volume, volume-consumed, remaining)
+				}
+
+				if p.Volume() != levelVolume-consumed {
+					t.Fatalf("case %d: Volume() want: %d, got: %d", i, levelVolume-consumed, p.Volume())
+				}
+
+				allocated := uint64(0)
+				for id, v := range taken(matches) {
+					allocated += v
+					if v > original[id] {
+						t.Fatalf("case %d: order %s allocated %d over its volume %d", i, id, v, original[id])
+					}
+				}
+
+				if allocated != consumed {
+					t.Fatalf("case %d: allocated %d, consumed %d", i, allocated, consumed)
+				}
+
+				for _, m := range matches {
+					o := m.MakerOrder
+					if (o.Volume == 0) != (m.Type == order.OrderFulfilled) {
+						t.Fatalf("case %d: order %s with volume %d matched as %v", i, o.ID, o.Volume, m.Type)
+					}
+
+					if _, err := p.Order(o.ID); (err != nil) != (o.Volume == 0) {
+						t.Fatalf("case %d: order %s with volume %d still queued: %v", i, o.ID, o.Volume, err == nil)
+					}
+				}
+
+				for _, o := range orders {
+					if o.Volume == 0 {
+						continue
+					}
+
+					resting, err := p.Order(o.ID)
+					if err != nil {
+						t.Fatalf("case %d: Order(%s) unexpected error: %v", i, o.ID, err)
+					}
+
+					if resting.Filled+o.Volume != resting.OriginalVolume {
+						t.Fatalf("case %d: order %s filled %d and volume %d, want original volume %d", i, o.ID, resting.Filled, o.Volume, resting.OriginalVolume)
+					}
+				}
+			}
+		})
+	}
+}
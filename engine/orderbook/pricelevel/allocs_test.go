@@ -0,0 +1,60 @@
+package pricelevel_test
+
+import (
+	"fmt"
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+	"exchange/engine/testutils"
+)
+
+// Test_MatchAndExtract_Allocs checks that queueing and matching orders does
+// not allocate once the buffers and pools are warm.
+func Test_MatchAndExtract_Allocs(t *testing.T) {
+	if testutils.RaceEnabled {
+		t.Skip("sync.Pool drops items with -race")
+	}
+
+	testCases := []struct {
+		name      string
+		allocator pricelevel.Allocator
+	}{
+		{name: "fifo"},
+		{name: "pro_rata", allocator: pricelevel.ProRata{Lot: 2}},
+		{name: "top_order_pro_rata", allocator: pricelevel.TopOrderProRata{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orders := make([]order.Order, 100)
+			for i := range orders {
+				orders[i] = order.Order{ID: fmt.Sprint(i), Price: 1}
+			}
+
+			p := pricelevel.New()
+			var matches []order.Match
+
+			allocs := testing.AllocsPerRun(100, func() {
+				for i := range orders {
+					orders[i].Volume = uint64(i%10) + 1
+					if err := p.Insert(&orders[i]); err != nil {
+						t.Fatalf("Insert() unexpected error: %v", err)
+					}
+				}
+
+				matches, _ = p.MatchAndExtractWith(tc.allocator, p.Volume()/2, matches[:0])
+				p.Remove(orders[len(orders)-1].ID)
+				matches, _ = p.MatchAndExtractWith(tc.allocator, p.Volume(), matches[:0])
+			})
+
+			if allocs != 0 {
+				t.Errorf("AllocsPerRun() want: 0, got: %v", allocs)
+			}
+
+			if p.Len() != 0 {
+				t.Errorf("Len() want: 0, got: %d", p.Len())
+			}
+		})
+	}
+}
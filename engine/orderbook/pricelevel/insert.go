@@ -2,14 +2,23 @@ package pricelevel
 
 import (
 	"fmt"
+	"time"
 
 	"exchange/engine/order"
 )
 
 func (p *PriceLevel) insert(o *order.Order) {
 	p.volume += o.Volume
-	elem := p.list.PushBack(o)
-	p.orderMap[o.ID] = elem
+	p.sequence++
+
+	r := restingOrderPool.Get().(*RestingOrder)
+	r.Order = o
+	r.OriginalVolume = o.Volume
+	r.Sequence = p.sequence
+	r.Timestamp = time.Now()
+
+	p.pushBack(r)
+	p.orderMap[o.ID] = r
 }
 
 // Push back adds an order to be processed last and updates the Volume.
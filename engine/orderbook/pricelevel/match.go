@@ -4,44 +4,43 @@ import (
 	"exchange/engine/order"
 )
 
-// Extract will return all the orders needed to fill the required volume,
-// and any unmatched volume that could not be extracted from this level.
+// MatchAndExtract appends to matches all the orders needed to fill the
+// required volume, and returns them with any unmatched volume that could not
+// be extracted from this level.
+//
+// Reusing the matches buffer between calls, matching does not allocate.
 //
 // O(n)
-func (b *PriceLevel) MatchAndExtract(volume uint64) ([]*order.Match, uint64) {
-	matches := make([]*order.Match, 0, 10)
-
+func (b *PriceLevel) MatchAndExtract(volume uint64, matches []order.Match) ([]order.Match, uint64) {
 	// O(n)
-	for {
-		if volume == 0 {
-			break
-		}
+	for volume > 0 && b.front != nil {
+		r := b.front
+		o := r.Order
 
-		elem := b.list.Front()
-		if elem == nil {
-			break
-		}
-
-		o := elem.Value.(*order.Order)
 		if volume >= o.Volume {
 			volume -= o.Volume
 			b.volume -= o.Volume
+			r.Filled += o.Volume
+			o.Filled += o.Volume
 
-			b.list.Remove(elem) // removing the pointer to Next
-			delete(b.orderMap, o.ID)
-
-			matches = append(matches, &order.Match{
+			matches = append(matches, order.Match{
 				Type:        order.OrderFulfilled,
 				MakerOrder:  o,
 				VolumeTaken: o.Volume,
 			})
 
 			o.Volume = 0
+
+			b.remove(r)
+			delete(b.orderMap, o.ID)
+			release(r)
 		} else {
 			o.Volume -= volume
 			b.volume -= volume
+			r.Filled += volume
+			o.Filled += volume
 
-			matches = append(matches, &order.Match{
+			matches = append(matches, order.Match{
 				Type:        order.OrderPartiallyFulfilled,
 				MakerOrder:  o,
 				VolumeTaken: volume,
@@ -52,3 +51,70 @@ func (b *PriceLevel) MatchAndExtract(volume uint64) ([]*order.Match, uint64) {
 
 	return matches, volume
 }
+
+// MatchAndExtractWith is MatchAndExtract with the volume split between the
+// orders by the given allocator, FIFO if nil. Orders are matched in queue
+// order, and fully matched orders are removed from the level.
+//
+// O(n)
+func (b *PriceLevel) MatchAndExtractWith(allocator Allocator, volume uint64, matches []order.Match) ([]order.Match, uint64) {
+	if _, ok := allocator.(FIFO); ok || allocator == nil || volume >= b.volume {
+		// Matching the whole level is the same for every allocator.
+		return b.MatchAndExtract(volume, matches)
+	}
+
+	b.queue = b.queue[:0]
+	for r := b.front; r != nil; r = r.next {
+		b.queue = append(b.queue, r.Order)
+	}
+
+	b.allocations = allocator.Allocate(b.queue, b.volume, volume, b.allocations[:0])
+	clear(b.queue)
+
+	r := b.front
+	for _, allocated := range b.allocations {
+		if r == nil {
+			break
+		}
+
+		next := r.next
+		o := r.Order
+
+		allocated = min(allocated, o.Volume, volume)
+		if allocated == 0 {
+			r = next
+			continue
+		}
+
+		volume -= allocated
+		b.volume -= allocated
+		r.Filled += allocated
+		o.Filled += allocated
+
+		if allocated == o.Volume {
+			matches = append(matches, order.Match{
+				Type:        order.OrderFulfilled,
+				MakerOrder:  o,
+				VolumeTaken: allocated,
+			})
+
+			o.Volume = 0
+
+			b.remove(r)
+			delete(b.orderMap, o.ID)
+			release(r)
+		} else {
+			o.Volume -= allocated
+
+			matches = append(matches, order.Match{
+				Type:        order.OrderPartiallyFulfilled,
+				MakerOrder:  o,
+				VolumeTaken: allocated,
+			})
+		}
+
+		r = next
+	}
+
+	return matches, volume
+}
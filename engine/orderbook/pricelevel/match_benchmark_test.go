@@ -49,6 +49,7 @@ func Benchmark_MatchAndExtract(b *testing.B) {
 				orders = append(orders, order.Order{Price: 1, ID: fmt.Sprintf("%d", num), Volume: num})
 			}
 
+			var matches []order.Match
 			for range b.N {
 				b.StopTimer()
 
@@ -65,7 +66,7 @@ func Benchmark_MatchAndExtract(b *testing.B) {
 
 				b.StartTimer()
 
-				p.MatchAndExtract(tc.extract)
+				matches, _ = p.MatchAndExtract(tc.extract, matches[:0])
 			}
 		})
 	}
@@ -14,7 +14,7 @@ func Test_MatchAndExtract(t *testing.T) {
 		name                string
 		orders              []*order.Order
 		extract             uint64
-		wantMatches         []*order.Match
+		wantMatches         []order.Match
 		wantUnmatchedVolume uint64
 	}{
 		{
@@ -37,10 +37,10 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "1", Volume: 1, Price: 1},
 			},
 			extract: 1,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1},
 					VolumeTaken: 1,
 				},
 			},
@@ -52,10 +52,10 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "2", Volume: 1, Price: 1},
 			},
 			extract: 1,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1},
 					VolumeTaken: 1,
 				},
 			},
@@ -66,10 +66,10 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "1", Volume: 1, Price: 1},
 			},
 			extract: 2,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1},
 					VolumeTaken: 1,
 				},
 			},
@@ -81,10 +81,10 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "1", Volume: 10, Price: 1},
 			},
 			extract: 5,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderPartiallyFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 5, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 5, Filled: 5, Price: 1},
 					VolumeTaken: 5,
 				},
 			},
@@ -96,15 +96,15 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "2", Volume: 10, Price: 1},
 			},
 			extract: 15,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 10, Price: 1},
 					VolumeTaken: 10,
 				},
 				{
 					Type:        order.OrderPartiallyFulfilled,
-					MakerOrder:  &order.Order{ID: "2", Volume: 5, Price: 1},
+					MakerOrder:  &order.Order{ID: "2", Volume: 5, Filled: 5, Price: 1},
 					VolumeTaken: 5,
 				},
 			},
@@ -116,10 +116,10 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "2", Volume: 10, Price: 1},
 			},
 			extract: 10,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 10, Price: 1},
 					VolumeTaken: 10,
 				},
 			},
@@ -131,15 +131,15 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "2", Volume: 10, Price: 1},
 			},
 			extract: 20,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 10, Price: 1},
 					VolumeTaken: 10,
 				},
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "2", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "2", Volume: 0, Filled: 10, Price: 1},
 					VolumeTaken: 10,
 				},
 			},
@@ -151,15 +151,15 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "2", Volume: 10, Price: 1},
 			},
 			extract: 30,
-			wantMatches: []*order.Match{
+			wantMatches: []order.Match{
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "1", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "1", Volume: 0, Filled: 10, Price: 1},
 					VolumeTaken: 10,
 				},
 				{
 					Type:        order.OrderFulfilled,
-					MakerOrder:  &order.Order{ID: "2", Volume: 0, Price: 1},
+					MakerOrder:  &order.Order{ID: "2", Volume: 0, Filled: 10, Price: 1},
 					VolumeTaken: 10,
 				},
 			},
@@ -180,12 +180,12 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "10", Volume: 10, Price: 1},
 			},
 			extract: 12,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Price: 1}, VolumeTaken: 1},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Price: 1}, VolumeTaken: 2},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Price: 1}, VolumeTaken: 3},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Price: 1}, VolumeTaken: 4},
-				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 3, Price: 1}, VolumeTaken: 2},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1}, VolumeTaken: 1},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Filled: 2, Price: 1}, VolumeTaken: 2},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Filled: 3, Price: 1}, VolumeTaken: 3},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Filled: 4, Price: 1}, VolumeTaken: 4},
+				{Type: order.OrderPartiallyFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 3, Filled: 2, Price: 1}, VolumeTaken: 2},
 			},
 		},
 		{
@@ -203,12 +203,12 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "10", Volume: 10, Price: 1},
 			},
 			extract: 15,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Price: 1}, VolumeTaken: 1},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Price: 1}, VolumeTaken: 2},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Price: 1}, VolumeTaken: 3},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Price: 1}, VolumeTaken: 4},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 0, Price: 1}, VolumeTaken: 5},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1}, VolumeTaken: 1},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Filled: 2, Price: 1}, VolumeTaken: 2},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Filled: 3, Price: 1}, VolumeTaken: 3},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Filled: 4, Price: 1}, VolumeTaken: 4},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 0, Filled: 5, Price: 1}, VolumeTaken: 5},
 			},
 		},
 		{
@@ -226,17 +226,17 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "10", Volume: 10, Price: 1},
 			},
 			extract: 55,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Price: 1}, VolumeTaken: 1},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Price: 1}, VolumeTaken: 2},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Price: 1}, VolumeTaken: 3},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Price: 1}, VolumeTaken: 4},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 0, Price: 1}, VolumeTaken: 5},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "6", Volume: 0, Price: 1}, VolumeTaken: 6},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "7", Volume: 0, Price: 1}, VolumeTaken: 7},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "8", Volume: 0, Price: 1}, VolumeTaken: 8},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "9", Volume: 0, Price: 1}, VolumeTaken: 9},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "10", Volume: 0, Price: 1}, VolumeTaken: 10},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1}, VolumeTaken: 1},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Filled: 2, Price: 1}, VolumeTaken: 2},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Filled: 3, Price: 1}, VolumeTaken: 3},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Filled: 4, Price: 1}, VolumeTaken: 4},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 0, Filled: 5, Price: 1}, VolumeTaken: 5},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "6", Volume: 0, Filled: 6, Price: 1}, VolumeTaken: 6},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "7", Volume: 0, Filled: 7, Price: 1}, VolumeTaken: 7},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "8", Volume: 0, Filled: 8, Price: 1}, VolumeTaken: 8},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "9", Volume: 0, Filled: 9, Price: 1}, VolumeTaken: 9},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "10", Volume: 0, Filled: 10, Price: 1}, VolumeTaken: 10},
 			},
 		},
 		{
@@ -254,17 +254,17 @@ func Test_MatchAndExtract(t *testing.T) {
 				{ID: "10", Volume: 10, Price: 1},
 			},
 			extract: 65,
-			wantMatches: []*order.Match{
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Price: 1}, VolumeTaken: 1},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Price: 1}, VolumeTaken: 2},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Price: 1}, VolumeTaken: 3},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Price: 1}, VolumeTaken: 4},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 0, Price: 1}, VolumeTaken: 5},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "6", Volume: 0, Price: 1}, VolumeTaken: 6},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "7", Volume: 0, Price: 1}, VolumeTaken: 7},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "8", Volume: 0, Price: 1}, VolumeTaken: 8},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "9", Volume: 0, Price: 1}, VolumeTaken: 9},
-				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "10", Volume: 0, Price: 1}, VolumeTaken: 10},
+			wantMatches: []order.Match{
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "1", Volume: 0, Filled: 1, Price: 1}, VolumeTaken: 1},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "2", Volume: 0, Filled: 2, Price: 1}, VolumeTaken: 2},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "3", Volume: 0, Filled: 3, Price: 1}, VolumeTaken: 3},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "4", Volume: 0, Filled: 4, Price: 1}, VolumeTaken: 4},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "5", Volume: 0, Filled: 5, Price: 1}, VolumeTaken: 5},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "6", Volume: 0, Filled: 6, Price: 1}, VolumeTaken: 6},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "7", Volume: 0, Filled: 7, Price: 1}, VolumeTaken: 7},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "8", Volume: 0, Filled: 8, Price: 1}, VolumeTaken: 8},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "9", Volume: 0, Filled: 9, Price: 1}, VolumeTaken: 9},
+				{Type: order.OrderFulfilled, MakerOrder: &order.Order{ID: "10", Volume: 0, Filled: 10, Price: 1}, VolumeTaken: 10},
 			},
 			wantUnmatchedVolume: 10,
 		},
@@ -280,7 +280,7 @@ func Test_MatchAndExtract(t *testing.T) {
 				}
 			}
 
-			gotMatches, gotUnmatchedVolume := p.MatchAndExtract(tc.extract)
+			gotMatches, gotUnmatchedVolume := p.MatchAndExtract(tc.extract, nil)
 
 			opts := cmp.Options{
 				cmpopts.EquateEmpty(),
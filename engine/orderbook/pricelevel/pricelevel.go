@@ -1,10 +1,43 @@
 package pricelevel
 
 import (
-	"container/list"
 	"exchange/engine/order"
+	"iter"
+	"sync"
+	"time"
 )
 
+// RestingOrder is an order queued in a level, with the metadata the order
+// itself does not carry.
+type RestingOrder struct {
+	// The order, its Volume is the volume left to match.
+	Order *order.Order
+
+	// The volume of the order when it was inserted in the level.
+	OriginalVolume uint64
+
+	// The volume matched since the order was inserted.
+	Filled uint64
+
+	// Increases with each order inserted in the level, so a lower sequence
+	// means a better time priority.
+	Sequence uint64
+
+	// When the order was inserted in the level.
+	Timestamp time.Time
+
+	// The orders queued before and after this one in the level
+	prev, next *RestingOrder
+}
+
+// restingOrderPool recycles the resting orders of every level, so that
+// queueing an order does not allocate in steady state.
+var restingOrderPool = sync.Pool{
+	New: func() any {
+		return &RestingOrder{}
+	},
+}
+
 // The PriceLevel receives orders and processes them FIFO, keeping track of the
 // total Volume.
 // Additions and deletions are O(1).
@@ -12,17 +45,26 @@ type PriceLevel struct {
 	// The up-to-date volume of this whole price level
 	volume uint64
 
-	// The doubly-linked-list to process orders FIFO
+	// The intrusive doubly-linked-list to process orders FIFO, linked through
+	// the resting orders themselves
 	// Length: O(1)
 	// Read front: O(1)
-	// Read back: O(1)
 	// Push back: O(1)
 	// Remove element: O(1)
 	// Finding an element: O(n) <- this is why we keep an orderMap
-	list *list.List
+	front, back *RestingOrder
+	len         int
+
+	// A map from order ID to resting orders for O(1) deletions
+	orderMap map[string]*RestingOrder
 
-	// A map from order ID to list elements for O(1) deletions
-	orderMap map[string]*list.Element
+	// The sequence of the last inserted order
+	sequence uint64
+
+	// Reused by MatchAndExtractWith to hold the queue and the volume allocated
+	// to each order
+	queue       []*order.Order
+	allocations []uint64
 }
 
 // Volume returns the current available volume of this level.
@@ -32,20 +74,37 @@ func (p *PriceLevel) Volume() uint64 {
 	return p.volume
 }
 
+// Len returns the number of orders queued in this level.
+//
+// O(1)
+func (p *PriceLevel) Len() int {
+	return p.len
+}
+
 // Front returns the order that is first in the processing order in O(1).
 func (p *PriceLevel) Front() *order.Order {
-	elem := p.list.Front()
-	if elem == nil {
+	if p.front == nil {
 		return nil
 	}
 
-	return elem.Value.(*order.Order)
+	return p.front.Order
+}
+
+// Orders returns the orders of the level in the processing order. The level
+// must not change during the iteration.
+func (p *PriceLevel) Orders() iter.Seq[*order.Order] {
+	return func(yield func(*order.Order) bool) {
+		for r := p.front; r != nil; r = r.next {
+			if !yield(r.Order) {
+				return
+			}
+		}
+	}
 }
 
 func New() *PriceLevel {
 	return &PriceLevel{
-		list:     list.New(),
-		orderMap: make(map[string]*list.Element),
+		orderMap: make(map[string]*RestingOrder),
 	}
 }
 
@@ -54,9 +113,51 @@ func (p *PriceLevel) Reset() {
 		return
 	}
 
+	for r := p.front; r != nil; {
+		next := r.next
+		release(r)
+		r = next
+	}
+
 	p.volume = 0
-	p.list.Init()
-	for k := range p.orderMap {
-		delete(p.orderMap, k)
+	p.sequence = 0
+	p.front, p.back, p.len = nil, nil, 0
+	clear(p.orderMap)
+}
+
+// pushBack queues the order last.
+func (p *PriceLevel) pushBack(r *RestingOrder) {
+	r.prev, r.next = p.back, nil
+	if p.back == nil {
+		p.front = r
+	} else {
+		p.back.next = r
 	}
+
+	p.back = r
+	p.len++
+}
+
+// remove unlinks the order from the queue.
+func (p *PriceLevel) remove(r *RestingOrder) {
+	if r.prev == nil {
+		p.front = r.next
+	} else {
+		r.prev.next = r.next
+	}
+
+	if r.next == nil {
+		p.back = r.prev
+	} else {
+		r.next.prev = r.prev
+	}
+
+	r.prev, r.next = nil, nil
+	p.len--
+}
+
+// release returns an unlinked resting order to the pool.
+func release(r *RestingOrder) {
+	*r = RestingOrder{}
+	restingOrderPool.Put(r)
 }
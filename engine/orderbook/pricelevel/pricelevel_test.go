@@ -417,7 +417,7 @@ func Test_Match_Volume(t *testing.T) {
 				}
 			}
 
-			p.MatchAndExtract(tc.extract)
+			p.MatchAndExtract(tc.extract, nil)
 
 			if tc.wantVolume != p.Volume() {
 				t.Errorf("Volume() want %d, got %d", tc.wantVolume, p.Volume())
@@ -476,7 +476,7 @@ func Test_Match_Front(t *testing.T) {
 				{ID: "1", Volume: 10, Price: 1},
 			},
 			extract:   5,
-			wantFront: &order.Order{ID: "1", Volume: 5, Price: 1},
+			wantFront: &order.Order{ID: "1", Volume: 5, Filled: 5, Price: 1},
 		},
 		{
 			name: "two_orders_extract_partial",
@@ -485,7 +485,7 @@ func Test_Match_Front(t *testing.T) {
 				{ID: "2", Volume: 10, Price: 1},
 			},
 			extract:   15,
-			wantFront: &order.Order{ID: "2", Volume: 5, Price: 1},
+			wantFront: &order.Order{ID: "2", Volume: 5, Filled: 5, Price: 1},
 		},
 		{
 			name: "two_orders_extract_first_one",
@@ -527,7 +527,7 @@ func Test_Match_Front(t *testing.T) {
 				{ID: "10", Volume: 10, Price: 1},
 			},
 			extract:   12,
-			wantFront: &order.Order{ID: "5", Volume: 3, Price: 1},
+			wantFront: &order.Order{ID: "5", Volume: 3, Filled: 2, Price: 1},
 		},
 		{
 			name: "many_extract_full_orders_less_than",
@@ -590,7 +590,7 @@ func Test_Match_Front(t *testing.T) {
 				}
 			}
 
-			p.MatchAndExtract(tc.extract)
+			p.MatchAndExtract(tc.extract, nil)
 
 			if diff := cmp.Diff(tc.wantFront, p.Front()); diff != "" {
 				t.Errorf("PriceLevel.Front() diff (-want, +got):\n%s", diff)
@@ -0,0 +1,45 @@
+package pricelevel
+
+import "fmt"
+
+// Position is the place of an order in the queue of a level.
+type Position struct {
+	// The number of orders that will be matched before this one.
+	OrdersAhead int
+
+	// The volume that has to be matched before this order.
+	VolumeAhead uint64
+}
+
+// Order returns a copy of the order queued with its metadata.
+//
+// O(1)
+func (p *PriceLevel) Order(orderID string) (RestingOrder, error) {
+	r, ok := p.orderMap[orderID]
+	if !ok {
+		return RestingOrder{}, fmt.Errorf("PriceLevel unknown order ID %s", orderID)
+	}
+
+	res := *r
+	res.prev, res.next = nil, nil
+
+	return res, nil
+}
+
+// Position returns the place of an order in the queue.
+//
+// O(n), where n is the number of orders ahead.
+func (p *PriceLevel) Position(orderID string) (Position, error) {
+	r, ok := p.orderMap[orderID]
+	if !ok {
+		return Position{}, fmt.Errorf("PriceLevel unknown order ID %s", orderID)
+	}
+
+	position := Position{}
+	for ahead := r.prev; ahead != nil; ahead = ahead.prev {
+		position.OrdersAhead++
+		position.VolumeAhead += ahead.Order.Volume
+	}
+
+	return position, nil
+}
@@ -0,0 +1,211 @@
+package pricelevel_test
+
+import (
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Order(t *testing.T) {
+	p := pricelevel.New()
+
+	orders := []*order.Order{
+		{ID: "1", Volume: 10, Price: 1},
+		{ID: "2", Volume: 20, Price: 1},
+	}
+	for _, o := range orders {
+		if err := p.Insert(o); err != nil {
+			t.Fatalf("Insert(%v) unexpected error: %v", o, err)
+		}
+	}
+
+	p.MatchAndExtract(4, nil)
+
+	testCases := []struct {
+		name               string
+		orderID            string
+		wantOrder          *order.Order
+		wantOriginalVolume uint64
+		wantFilled         uint64
+		wantSequence       uint64
+		wantErr            bool
+	}{
+		{
+			name:               "partially_filled",
+			orderID:            "1",
+			wantOrder:          orders[0],
+			wantOriginalVolume: 10,
+			wantFilled:         4,
+			wantSequence:       1,
+		},
+		{
+			name:               "not_filled",
+			orderID:            "2",
+			wantOrder:          orders[1],
+			wantOriginalVolume: 20,
+			wantSequence:       2,
+		},
+		{
+			name:    "unknown",
+			orderID: "3",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := p.Order(tc.orderID)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Order(%q) want error: %v, got: %v", tc.orderID, tc.wantErr, err)
+			}
+
+			if tc.wantErr {
+				return
+			}
+
+			if got.Order != tc.wantOrder {
+				t.Errorf("Order(%q) want order: %v, got: %v", tc.orderID, tc.wantOrder, got.Order)
+			}
+
+			if got.OriginalVolume != tc.wantOriginalVolume {
+				t.Errorf("Order(%q) want original volume: %d, got: %d", tc.orderID, tc.wantOriginalVolume, got.OriginalVolume)
+			}
+
+			if got.Filled != tc.wantFilled {
+				t.Errorf("Order(%q) want filled: %d, got: %d", tc.orderID, tc.wantFilled, got.Filled)
+			}
+
+			if got.Order.Volume+got.Filled != got.OriginalVolume {
+				t.Errorf("Order(%q) volume %d plus filled %d want original volume %d", tc.orderID, got.Order.Volume, got.Filled, got.OriginalVolume)
+			}
+
+			if got.Sequence != tc.wantSequence {
+				t.Errorf("Order(%q) want sequence: %d, got: %d", tc.orderID, tc.wantSequence, got.Sequence)
+			}
+
+			if got.Timestamp.IsZero() {
+				t.Errorf("Order(%q) want timestamp, got zero", tc.orderID)
+			}
+		})
+	}
+}
+
+func Test_Position(t *testing.T) {
+	testCases := []struct {
+		name           string
+		insertOrders   []*order.Order
+		removeOrderIDs []string
+		matchVolume    uint64
+		orderID        string
+		wantPosition   pricelevel.Position
+		wantErr        bool
+	}{
+		{
+			name: "front",
+			insertOrders: []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+				{ID: "2", Volume: 20, Price: 1},
+			},
+			orderID:      "1",
+			wantPosition: pricelevel.Position{},
+		},
+		{
+			name: "back",
+			insertOrders: []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+				{ID: "2", Volume: 20, Price: 1},
+				{ID: "3", Volume: 30, Price: 1},
+			},
+			orderID:      "3",
+			wantPosition: pricelevel.Position{OrdersAhead: 2, VolumeAhead: 30},
+		},
+		{
+			name: "after_removal",
+			insertOrders: []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+				{ID: "2", Volume: 20, Price: 1},
+				{ID: "3", Volume: 30, Price: 1},
+			},
+			removeOrderIDs: []string{"2"},
+			orderID:        "3",
+			wantPosition:   pricelevel.Position{OrdersAhead: 1, VolumeAhead: 10},
+		},
+		{
+			name: "after_partial_match",
+			insertOrders: []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+				{ID: "2", Volume: 20, Price: 1},
+				{ID: "3", Volume: 30, Price: 1},
+			},
+			matchVolume:  15,
+			orderID:      "3",
+			wantPosition: pricelevel.Position{OrdersAhead: 1, VolumeAhead: 15},
+		},
+		{
+			name: "unknown",
+			insertOrders: []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+			},
+			orderID: "2",
+			wantErr: true,
+		},
+		{
+			name: "matched",
+			insertOrders: []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+				{ID: "2", Volume: 20, Price: 1},
+			},
+			matchVolume: 10,
+			orderID:     "1",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := pricelevel.New()
+
+			for _, o := range tc.insertOrders {
+				if err := p.Insert(o); err != nil {
+					t.Fatalf("Insert(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			for _, id := range tc.removeOrderIDs {
+				if err := p.Remove(id); err != nil {
+					t.Fatalf("Remove(%q) unexpected error: %v", id, err)
+				}
+			}
+
+			p.MatchAndExtract(tc.matchVolume, nil)
+
+			got, err := p.Position(tc.orderID)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Position(%q) want error: %v, got: %v", tc.orderID, tc.wantErr, err)
+			}
+
+			if diff := cmp.Diff(tc.wantPosition, got); diff != "" {
+				t.Errorf("Position(%q) diff (-want, +got):\n%s", tc.orderID, diff)
+			}
+		})
+	}
+}
+
+func Test_Reset_Sequence(t *testing.T) {
+	p := pricelevel.New()
+	p.Insert(&order.Order{ID: "1", Volume: 1, Price: 1})
+	p.Reset()
+	p.Insert(&order.Order{ID: "2", Volume: 1, Price: 1})
+
+	got, err := p.Order("2")
+	if err != nil {
+		t.Fatalf("Order(%q) unexpected error: %v", "2", err)
+	}
+
+	if got.Sequence != 1 {
+		t.Errorf("Order(%q) after Reset() want sequence: 1, got: %d", "2", got.Sequence)
+	}
+}
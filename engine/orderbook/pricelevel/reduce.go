@@ -0,0 +1,24 @@
+package pricelevel
+
+import "fmt"
+
+// Reduce lowers the volume left of an order to a lower, positive volume,
+// keeping its place in the queue.
+//
+// O(1).
+func (p *PriceLevel) Reduce(orderID string, volume uint64) error {
+	r, ok := p.orderMap[orderID]
+	if !ok {
+		return fmt.Errorf("PriceLevel unknown order ID %s", orderID)
+	}
+
+	if volume == 0 || volume >= r.Order.Volume {
+		return fmt.Errorf("PriceLevel order ID %s volume %d, want between 0 and %d", orderID, volume, r.Order.Volume)
+	}
+
+	p.volume -= r.Order.Volume - volume
+	r.OriginalVolume -= r.Order.Volume - volume
+	r.Order.Volume = volume
+
+	return nil
+}
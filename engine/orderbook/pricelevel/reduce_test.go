@@ -0,0 +1,75 @@
+package pricelevel_test
+
+import (
+	"testing"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+)
+
+func Test_Reduce(t *testing.T) {
+	testCases := []struct {
+		name       string
+		orderID    string
+		volume     uint64
+		wantErr    bool
+		wantVolume uint64
+	}{
+		{
+			name:       "reduce",
+			orderID:    "2",
+			volume:     4,
+			wantVolume: 14,
+		},
+		{
+			name:       "same_volume",
+			orderID:    "2",
+			volume:     10,
+			wantErr:    true,
+			wantVolume: 20,
+		},
+		{
+			name:       "zero_volume",
+			orderID:    "2",
+			volume:     0,
+			wantErr:    true,
+			wantVolume: 20,
+		},
+		{
+			name:       "unknown",
+			orderID:    "3",
+			volume:     4,
+			wantErr:    true,
+			wantVolume: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := pricelevel.New()
+
+			for _, o := range []*order.Order{
+				{ID: "1", Volume: 10, Price: 1},
+				{ID: "2", Volume: 10, Price: 1},
+			} {
+				if err := p.Insert(o); err != nil {
+					t.Fatalf("PriceLevel.Insert(%v) unexpected error: %v", o, err)
+				}
+			}
+
+			err := p.Reduce(tc.orderID, tc.volume)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Reduce() want error: %t, got: %v", tc.wantErr, err)
+			}
+
+			if got := p.Volume(); got != tc.wantVolume {
+				t.Errorf("Volume() want: %d, got: %d", tc.wantVolume, got)
+			}
+
+			// The order keeps its place in the queue.
+			if position, err := p.Position("2"); err != nil || position.OrdersAhead != 1 {
+				t.Errorf("Position() want 1 order ahead, got: %v, %v", position, err)
+			}
+		})
+	}
+}
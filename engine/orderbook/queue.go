@@ -0,0 +1,63 @@
+package orderbook
+
+import (
+	"fmt"
+	"iter"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+)
+
+// level returns the price level holding the given order.
+//
+// O(1) with both indexes, the TreeIndex keeps a map of its prices.
+func (b *OrderBook) level(o *order.Order) (*pricelevel.PriceLevel, error) {
+	if o.Side != b.side {
+		return nil, fmt.Errorf("OrderBook different sides %v!=%v for order %q", b.side, o.Side, o.ID)
+	}
+
+	level, exists := b.index.Get(o.Price)
+	if !exists {
+		return nil, fmt.Errorf("OrderBook price node %d does not exist for order %q", o.Price, o.ID)
+	}
+
+	return level, nil
+}
+
+// Order returns a copy of the resting order with its metadata: original
+// volume, volume filled, sequence and entry time.
+func (b *OrderBook) Order(o *order.Order) (pricelevel.RestingOrder, error) {
+	level, err := b.level(o)
+	if err != nil {
+		return pricelevel.RestingOrder{}, err
+	}
+
+	return level.Order(o.ID)
+}
+
+// Position returns the place of a resting order in the queue of its price.
+//
+// O(n), where n is the number of orders ahead at the same price.
+func (b *OrderBook) Position(o *order.Order) (pricelevel.Position, error) {
+	level, err := b.level(o)
+	if err != nil {
+		return pricelevel.Position{}, err
+	}
+
+	return level.Position(o.ID)
+}
+
+// Orders returns the resting orders of the book, from the best price to the
+// worst, and in the processing order for the same price. The book must not
+// change during the iteration.
+func (b *OrderBook) Orders() iter.Seq[*order.Order] {
+	return func(yield func(*order.Order) bool) {
+		for _, level := range b.index.FromHead() {
+			for o := range level.Orders() {
+				if !yield(o) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -1,15 +1,15 @@
 package rbtree
 
-// Delete may or may not actually delete the requested price. If the price
+// Delete may or may not actually delete the requested key. If the key
 // exists, the node is deleted, if it doesn't then the function returns.
 //
-// Do not search if the price exists before calling Delete. This function will
+// Do not search if the key exists before calling Delete. This function will
 // have to find its place in the tree anyways, so call this function directly
 // instead for efficient programming.
 //
 // O(log n)
-func (t *Tree) Delete(price uint64) {
-	holder, holderSide, holderParent := t.find(price)
+func (t *Tree[K, V]) Delete(key K) {
+	holder, holderSide, holderParent := t.find(key)
 
 	t.delete(holder, holderSide, holderParent)
 }
@@ -22,7 +22,7 @@ func (t *Tree) Delete(price uint64) {
 // It is responsibility of the caller to send a node that exists in this tree.
 //
 // O(log n)
-func (t *Tree) DeleteNode(node *Node) {
+func (t *Tree[K, V]) DeleteNode(node *Node[K, V]) {
 	nodeSide := LeftChild
 	if node.Parent != nil && node.Parent.Right == node {
 		nodeSide = RightChild
@@ -32,7 +32,7 @@ func (t *Tree) DeleteNode(node *Node) {
 }
 
 // O(log n)
-func (t *Tree) delete(holder *Node, holderSide ChildSide, holderParent *Node) {
+func (t *Tree[K, V]) delete(holder *Node[K, V], holderSide ChildSide, holderParent *Node[K, V]) {
 	if holder == nil {
 		return
 	}
@@ -55,8 +55,13 @@ func (t *Tree) delete(holder *Node, holderSide ChildSide, holderParent *Node) {
 
 	originalRed := holder.Red
 
-	var x, xParent *Node
+	var x, xParent *Node[K, V]
 	var xSide ChildSide
+	if holder.Left == nil || holder.Right == nil {
+		// The holder is removed from its position
+		t.shrinkPath(holder.Parent)
+	}
+
 	if holder.Left == nil {
 		// case 1: left is nil
 		x = holder.Right // x can be nil
@@ -80,6 +85,9 @@ func (t *Tree) delete(holder *Node, holderSide ChildSide, holderParent *Node) {
 		y := t.min(holder.Right)
 		originalRed = y.Red
 
+		// y is removed from its position, and takes the place of the holder
+		t.shrinkPath(y.Parent)
+
 		// x can be nil but not always
 		// y is the leftmost node, but it can have a right child
 		x = y.Right
@@ -105,6 +113,7 @@ func (t *Tree) delete(holder *Node, holderSide ChildSide, holderParent *Node) {
 		y.Left = holder.Left
 		y.Left.Parent = y
 		y.Red = holder.Red
+		y.Size = holder.Size
 	}
 
 	if !originalRed {
@@ -114,7 +123,14 @@ func (t *Tree) delete(holder *Node, holderSide ChildSide, holderParent *Node) {
 	t.putNode(holder)
 }
 
-func (t *Tree) safeChild(parent *Node, side ChildSide) *Node {
+// shrinkPath decrements the size of every node from n up to the root.
+func (t *Tree[K, V]) shrinkPath(n *Node[K, V]) {
+	for ; n != nil; n = n.Parent {
+		n.Size--
+	}
+}
+
+func (t *Tree[K, V]) safeChild(parent *Node[K, V], side ChildSide) *Node[K, V] {
 	if parent == nil {
 		return nil
 	}
@@ -124,7 +140,7 @@ func (t *Tree) safeChild(parent *Node, side ChildSide) *Node {
 	return parent.Right
 }
 
-func (t *Tree) deleteFixup(x *Node, xParent *Node, xSide ChildSide) {
+func (t *Tree[K, V]) deleteFixup(x *Node[K, V], xParent *Node[K, V], xSide ChildSide) {
 	if x == nil && xParent == nil {
 		return
 	}
@@ -136,7 +152,7 @@ func (t *Tree) deleteFixup(x *Node, xParent *Node, xSide ChildSide) {
 	// case 3: when w is black and w.right is red and w.left is black
 	// case 4: when w is black and w.right is red
 
-	var w *Node
+	var w *Node[K, V]
 	for t.Root != x && x.IsBlack() {
 		if xSide == LeftChild {
 			w = t.safeChild(xParent, RightChild)
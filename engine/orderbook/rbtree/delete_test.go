@@ -11,9 +11,9 @@ import (
 func Test_Delete(t *testing.T) {
 	testCases := []struct {
 		name     string
-		tree     *rbtree.Tree
+		tree     *Tree
 		price    uint64
-		wantTree *rbtree.Tree
+		wantTree *Tree
 	}{
 		{
 			name:     "no_root",
@@ -23,303 +23,303 @@ func Test_Delete(t *testing.T) {
 		},
 		{
 			name: "not_found",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 			price: 10,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name:     "root",
-			tree:     tree(&rbtree.Node{Price: 5}),
+			tree:     tree(&Node{Key: 5}),
 			price:    5,
 			wantTree: tree(nil),
 		},
 		{
 			name: "root_with_left_child",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3, Red: true}}),
 			price:    5,
-			wantTree: tree(&rbtree.Node{Price: 3}),
+			wantTree: tree(&Node{Key: 3}),
 		},
 		{
 			name: "root_with_right_child",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
 			price:    5,
-			wantTree: tree(&rbtree.Node{Price: 7}),
+			wantTree: tree(&Node{Key: 7}),
 		},
 		{
 			name: "root_with_children",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 6},
-				Left:  &rbtree.Node{Price: 4}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 6},
+				Left:  &Node{Key: 4}}),
 			price: 5,
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Left: &rbtree.Node{Price: 4, Red: true}}),
+			wantTree: tree(&Node{Key: 6,
+				Left: &Node{Key: 4, Red: true}}),
 		},
 		{
 			name: "root_with_grandchildren",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8},
-					Left:  &rbtree.Node{Price: 6}},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8},
+					Left:  &Node{Key: 6}},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
 			price: 5,
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}},
-				Left: &rbtree.Node{Price: 3, Red: true,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
+			wantTree: tree(&Node{Key: 6,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}},
+				Left: &Node{Key: 3, Red: true,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
 		},
 		{
 			name: "root_whose_successor_has_right_child",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 8,
-					Right: &rbtree.Node{Price: 9},
-					Left: &rbtree.Node{Price: 6,
-						Right: &rbtree.Node{Price: 7, Red: true}}},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 8,
+					Right: &Node{Key: 9},
+					Left: &Node{Key: 6,
+						Right: &Node{Key: 7, Red: true}}},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
 			price: 5,
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Right: &rbtree.Node{Price: 8,
-					Right: &rbtree.Node{Price: 9},
-					Left:  &rbtree.Node{Price: 7}},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
+			wantTree: tree(&Node{Key: 6,
+				Right: &Node{Key: 8,
+					Right: &Node{Key: 9},
+					Left:  &Node{Key: 7}},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
 		},
 		{
 			name: "left_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3, Red: true}}),
 			price:    3,
-			wantTree: tree(&rbtree.Node{Price: 5}),
+			wantTree: tree(&Node{Key: 5}),
 		},
 		{
 			name: "right_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
 			price:    7,
-			wantTree: tree(&rbtree.Node{Price: 5}),
+			wantTree: tree(&Node{Key: 5}),
 		},
 		{
 			name: "left_leaf_red_sibling",
-			tree: tree(&rbtree.Node{Price: 7,
-				Right: &rbtree.Node{Price: 8, Red: true},
-				Left:  &rbtree.Node{Price: 5, Red: true}}),
+			tree: tree(&Node{Key: 7,
+				Right: &Node{Key: 8, Red: true},
+				Left:  &Node{Key: 5, Red: true}}),
 			price: 5,
-			wantTree: tree(&rbtree.Node{Price: 7,
-				Right: &rbtree.Node{Price: 8, Red: true}}),
+			wantTree: tree(&Node{Key: 7,
+				Right: &Node{Key: 8, Red: true}}),
 		},
 		{
 			name: "right_lead_red_sibling",
-			tree: tree(&rbtree.Node{Price: 7,
-				Right: &rbtree.Node{Price: 8, Red: true},
-				Left:  &rbtree.Node{Price: 5, Red: true}}),
+			tree: tree(&Node{Key: 7,
+				Right: &Node{Key: 8, Red: true},
+				Left:  &Node{Key: 5, Red: true}}),
 			price: 8,
-			wantTree: tree(&rbtree.Node{Price: 7,
-				Left: &rbtree.Node{Price: 5, Red: true}}),
+			wantTree: tree(&Node{Key: 7,
+				Left: &Node{Key: 5, Red: true}}),
 		},
 		{
 			name: "left_left_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Left: &rbtree.Node{Price: 1, Red: true}}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Left: &Node{Key: 1, Red: true}}}),
 			price: 1,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "left_right_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}}}),
 			price: 4,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_left_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Left: &rbtree.Node{Price: 6, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Left: &Node{Key: 6, Red: true}},
+				Left: &Node{Key: 3}}),
 			price: 6,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_right_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}},
+				Left: &Node{Key: 3}}),
 			price: 8,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "left_line_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Left: &rbtree.Node{Price: 1, Red: true}}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Left: &Node{Key: 1, Red: true}}}),
 			price: 1,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "left_arrow_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}}}),
 			price: 4,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_line_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}},
+				Left: &Node{Key: 3}}),
 			price: 8,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_arrow_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Left: &rbtree.Node{Price: 6, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Left: &Node{Key: 6, Red: true}},
+				Left: &Node{Key: 3}}),
 			price: 6,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "black_no_children_right_far_red_nephew",
-			tree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}},
-				Left: &rbtree.Node{Price: 1}}),
+			tree: tree(&Node{Key: 2,
+				Right: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}},
+				Left: &Node{Key: 1}}),
 			price: 1,
-			wantTree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left:  &rbtree.Node{Price: 2}}),
+			wantTree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left:  &Node{Key: 2}}),
 		},
 		{
 			name: "black_no_children_right_close_red_nephew",
-			tree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 4,
-					Left: &rbtree.Node{Price: 3, Red: true}},
-				Left: &rbtree.Node{Price: 1}}),
+			tree: tree(&Node{Key: 2,
+				Right: &Node{Key: 4,
+					Left: &Node{Key: 3, Red: true}},
+				Left: &Node{Key: 1}}),
 			price: 1,
-			wantTree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left:  &rbtree.Node{Price: 2}}),
+			wantTree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left:  &Node{Key: 2}}),
 		},
 		{
 			name: "black_no_children_left_far_red_nephew",
-			tree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left: &rbtree.Node{Price: 2,
-					Left: &rbtree.Node{Price: 1, Red: true}}}),
+			tree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left: &Node{Key: 2,
+					Left: &Node{Key: 1, Red: true}}}),
 			price: 4,
-			wantTree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 3},
-				Left:  &rbtree.Node{Price: 1}}),
+			wantTree: tree(&Node{Key: 2,
+				Right: &Node{Key: 3},
+				Left:  &Node{Key: 1}}),
 		},
 		{
 			name: "black_no_children_left_close_red_nephew",
-			tree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left: &rbtree.Node{Price: 1,
-					Right: &rbtree.Node{Price: 2, Red: true}}}),
+			tree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left: &Node{Key: 1,
+					Right: &Node{Key: 2, Red: true}}}),
 			price: 4,
-			wantTree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 3},
-				Left:  &rbtree.Node{Price: 1}}),
+			wantTree: tree(&Node{Key: 2,
+				Right: &Node{Key: 3},
+				Left:  &Node{Key: 1}}),
 		},
 		{
 			name: "black_no_children_black_nephews",
-			tree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Right: &rbtree.Node{Price: 8, Red: true,
-						Right: &rbtree.Node{Price: 9,
-							Right: &rbtree.Node{Price: 10, Red: true}},
-						Left: &rbtree.Node{Price: 7}},
-					Left: &rbtree.Node{Price: 5}},
-				Left: &rbtree.Node{Price: 2,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
+			tree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Right: &Node{Key: 8, Red: true,
+						Right: &Node{Key: 9,
+							Right: &Node{Key: 10, Red: true}},
+						Left: &Node{Key: 7}},
+					Left: &Node{Key: 5}},
+				Left: &Node{Key: 2,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
 			price: 1,
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Right: &rbtree.Node{Price: 8,
-					Right: &rbtree.Node{Price: 9,
-						Right: &rbtree.Node{Price: 10, Red: true}},
-					Left: &rbtree.Node{Price: 7}},
-				Left: &rbtree.Node{Price: 4,
-					Right: &rbtree.Node{Price: 5},
-					Left: &rbtree.Node{Price: 2,
-						Right: &rbtree.Node{Price: 3, Red: true}}}}),
+			wantTree: tree(&Node{Key: 6,
+				Right: &Node{Key: 8,
+					Right: &Node{Key: 9,
+						Right: &Node{Key: 10, Red: true}},
+					Left: &Node{Key: 7}},
+				Left: &Node{Key: 4,
+					Right: &Node{Key: 5},
+					Left: &Node{Key: 2,
+						Right: &Node{Key: 3, Red: true}}}}),
 		},
 		{
 			name: "two_level_black_tree_delete_rightmost",
-			tree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Right: &rbtree.Node{Price: 7},
-					Left:  &rbtree.Node{Price: 5}},
-				Left: &rbtree.Node{Price: 2,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
+			tree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Right: &Node{Key: 7},
+					Left:  &Node{Key: 5}},
+				Left: &Node{Key: 2,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
 			price: 7,
-			wantTree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Left: &rbtree.Node{Price: 5, Red: true}},
-				Left: &rbtree.Node{Price: 2, Red: true,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
+			wantTree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Left: &Node{Key: 5, Red: true}},
+				Left: &Node{Key: 2, Red: true,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
 		},
 		{
 			name: "random_case_1",
-			tree: tree(&rbtree.Node{Price: 8,
-				Right: &rbtree.Node{Price: 10,
-					Right: &rbtree.Node{Price: 12},
-					Left:  &rbtree.Node{Price: 9}},
-				Left: &rbtree.Node{Price: 4,
-					Right: &rbtree.Node{Price: 6,
-						Right: &rbtree.Node{Price: 7, Red: true}},
-					Left: &rbtree.Node{Price: 3,
-						Left: &rbtree.Node{Price: 1, Red: true}}}}),
+			tree: tree(&Node{Key: 8,
+				Right: &Node{Key: 10,
+					Right: &Node{Key: 12},
+					Left:  &Node{Key: 9}},
+				Left: &Node{Key: 4,
+					Right: &Node{Key: 6,
+						Right: &Node{Key: 7, Red: true}},
+					Left: &Node{Key: 3,
+						Left: &Node{Key: 1, Red: true}}}}),
 			price: 8,
-			wantTree: tree(&rbtree.Node{Price: 9,
-				Right: &rbtree.Node{Price: 10,
-					Right: &rbtree.Node{Price: 12, Red: true}},
-				Left: &rbtree.Node{Price: 4, Red: true,
-					Right: &rbtree.Node{Price: 6,
-						Right: &rbtree.Node{Price: 7, Red: true}},
-					Left: &rbtree.Node{Price: 3,
-						Left: &rbtree.Node{Price: 1, Red: true}}}}),
+			wantTree: tree(&Node{Key: 9,
+				Right: &Node{Key: 10,
+					Right: &Node{Key: 12, Red: true}},
+				Left: &Node{Key: 4, Red: true,
+					Right: &Node{Key: 6,
+						Right: &Node{Key: 7, Red: true}},
+					Left: &Node{Key: 3,
+						Left: &Node{Key: 1, Red: true}}}}),
 		},
 	}
 
@@ -353,349 +353,349 @@ func Test_Delete(t *testing.T) {
 func Test_DeleteNode(t *testing.T) {
 	testCases := []struct {
 		name     string
-		tree     *rbtree.Tree
-		getNode  func(t *rbtree.Tree) *rbtree.Node
-		wantTree *rbtree.Tree
+		tree     *Tree
+		getNode  func(t *Tree) *Node
+		wantTree *Tree
 	}{
 		{
 			name: "root",
-			tree: tree(&rbtree.Node{Price: 5}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
 			wantTree: tree(nil),
 		},
 		{
 			name: "root_with_left_child",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3, Red: true}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3, Red: true}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
-			wantTree: tree(&rbtree.Node{Price: 3}),
+			wantTree: tree(&Node{Key: 3}),
 		},
 		{
 			name: "root_with_right_child",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
-			wantTree: tree(&rbtree.Node{Price: 7}),
+			wantTree: tree(&Node{Key: 7}),
 		},
 		{
 			name: "root_with_children",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 6},
-				Left:  &rbtree.Node{Price: 4}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 6},
+				Left:  &Node{Key: 4}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Left: &rbtree.Node{Price: 4, Red: true}}),
+			wantTree: tree(&Node{Key: 6,
+				Left: &Node{Key: 4, Red: true}}),
 		},
 		{
 			name: "root_with_grandchildren",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8},
-					Left:  &rbtree.Node{Price: 6}},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8},
+					Left:  &Node{Key: 6}},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}},
-				Left: &rbtree.Node{Price: 3, Red: true,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
+			wantTree: tree(&Node{Key: 6,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}},
+				Left: &Node{Key: 3, Red: true,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
 		},
 		{
 			name: "root_whose_successor_has_right_child",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 8,
-					Right: &rbtree.Node{Price: 9},
-					Left: &rbtree.Node{Price: 6,
-						Right: &rbtree.Node{Price: 7, Red: true}}},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 8,
+					Right: &Node{Key: 9},
+					Left: &Node{Key: 6,
+						Right: &Node{Key: 7, Red: true}}},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Right: &rbtree.Node{Price: 8,
-					Right: &rbtree.Node{Price: 9},
-					Left:  &rbtree.Node{Price: 7}},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4},
-					Left:  &rbtree.Node{Price: 2}}}),
+			wantTree: tree(&Node{Key: 6,
+				Right: &Node{Key: 8,
+					Right: &Node{Key: 9},
+					Left:  &Node{Key: 7}},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4},
+					Left:  &Node{Key: 2}}}),
 		},
 		{
 			name: "left_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3, Red: true}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3, Red: true}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 5}),
+			wantTree: tree(&Node{Key: 5}),
 		},
 		{
 			name: "right_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 5}),
+			wantTree: tree(&Node{Key: 5}),
 		},
 		{
 			name: "left_leaf_red_sibling",
-			tree: tree(&rbtree.Node{Price: 7,
-				Right: &rbtree.Node{Price: 8, Red: true},
-				Left:  &rbtree.Node{Price: 5, Red: true}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 7,
+				Right: &Node{Key: 8, Red: true},
+				Left:  &Node{Key: 5, Red: true}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 7,
-				Right: &rbtree.Node{Price: 8, Red: true}}),
+			wantTree: tree(&Node{Key: 7,
+				Right: &Node{Key: 8, Red: true}}),
 		},
 		{
 			name: "right_lead_red_sibling",
-			tree: tree(&rbtree.Node{Price: 7,
-				Right: &rbtree.Node{Price: 8, Red: true},
-				Left:  &rbtree.Node{Price: 5, Red: true}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 7,
+				Right: &Node{Key: 8, Red: true},
+				Left:  &Node{Key: 5, Red: true}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 7,
-				Left: &rbtree.Node{Price: 5, Red: true}}),
+			wantTree: tree(&Node{Key: 7,
+				Left: &Node{Key: 5, Red: true}}),
 		},
 		{
 			name: "left_left_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Left: &rbtree.Node{Price: 1, Red: true}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Left: &Node{Key: 1, Red: true}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "left_right_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_left_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Left: &rbtree.Node{Price: 6, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Left: &Node{Key: 6, Red: true}},
+				Left: &Node{Key: 3}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_right_leaf",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}},
+				Left: &Node{Key: 3}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "left_line_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Left: &rbtree.Node{Price: 1, Red: true}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Left: &Node{Key: 1, Red: true}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "left_arrow_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_line_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}},
+				Left: &Node{Key: 3}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "right_arrow_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7,
-					Left: &rbtree.Node{Price: 6, Red: true}},
-				Left: &rbtree.Node{Price: 3}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7,
+					Left: &Node{Key: 6, Red: true}},
+				Left: &Node{Key: 3}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7},
-				Left:  &rbtree.Node{Price: 3}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7},
+				Left:  &Node{Key: 3}}),
 		},
 		{
 			name: "black_no_children_right_far_red_nephew",
-			tree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}},
-				Left: &rbtree.Node{Price: 1}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 2,
+				Right: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}},
+				Left: &Node{Key: 1}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left:  &rbtree.Node{Price: 2}}),
+			wantTree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left:  &Node{Key: 2}}),
 		},
 		{
 			name: "black_no_children_right_close_red_nephew",
-			tree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 4,
-					Left: &rbtree.Node{Price: 3, Red: true}},
-				Left: &rbtree.Node{Price: 1}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 2,
+				Right: &Node{Key: 4,
+					Left: &Node{Key: 3, Red: true}},
+				Left: &Node{Key: 1}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left:  &rbtree.Node{Price: 2}}),
+			wantTree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left:  &Node{Key: 2}}),
 		},
 		{
 			name: "black_no_children_left_far_red_nephew",
-			tree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left: &rbtree.Node{Price: 2,
-					Left: &rbtree.Node{Price: 1, Red: true}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left: &Node{Key: 2,
+					Left: &Node{Key: 1, Red: true}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 3},
-				Left:  &rbtree.Node{Price: 1}}),
+			wantTree: tree(&Node{Key: 2,
+				Right: &Node{Key: 3},
+				Left:  &Node{Key: 1}}),
 		},
 		{
 			name: "black_no_children_left_close_red_nephew",
-			tree: tree(&rbtree.Node{Price: 3,
-				Right: &rbtree.Node{Price: 4},
-				Left: &rbtree.Node{Price: 1,
-					Right: &rbtree.Node{Price: 2, Red: true}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 3,
+				Right: &Node{Key: 4},
+				Left: &Node{Key: 1,
+					Right: &Node{Key: 2, Red: true}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 2,
-				Right: &rbtree.Node{Price: 3},
-				Left:  &rbtree.Node{Price: 1}}),
+			wantTree: tree(&Node{Key: 2,
+				Right: &Node{Key: 3},
+				Left:  &Node{Key: 1}}),
 		},
 		{
 			name: "black_no_children_black_nephews",
-			tree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Right: &rbtree.Node{Price: 8, Red: true,
-						Right: &rbtree.Node{Price: 9,
-							Right: &rbtree.Node{Price: 10, Red: true}},
-						Left: &rbtree.Node{Price: 7}},
-					Left: &rbtree.Node{Price: 5}},
-				Left: &rbtree.Node{Price: 2,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Right: &Node{Key: 8, Red: true,
+						Right: &Node{Key: 9,
+							Right: &Node{Key: 10, Red: true}},
+						Left: &Node{Key: 7}},
+					Left: &Node{Key: 5}},
+				Left: &Node{Key: 2,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Left.Left
 			},
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Right: &rbtree.Node{Price: 8,
-					Right: &rbtree.Node{Price: 9,
-						Right: &rbtree.Node{Price: 10, Red: true}},
-					Left: &rbtree.Node{Price: 7}},
-				Left: &rbtree.Node{Price: 4,
-					Right: &rbtree.Node{Price: 5},
-					Left: &rbtree.Node{Price: 2,
-						Right: &rbtree.Node{Price: 3, Red: true}}}}),
+			wantTree: tree(&Node{Key: 6,
+				Right: &Node{Key: 8,
+					Right: &Node{Key: 9,
+						Right: &Node{Key: 10, Red: true}},
+					Left: &Node{Key: 7}},
+				Left: &Node{Key: 4,
+					Right: &Node{Key: 5},
+					Left: &Node{Key: 2,
+						Right: &Node{Key: 3, Red: true}}}}),
 		},
 		{
 			name: "two_level_black_tree_delete_rightmost",
-			tree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Right: &rbtree.Node{Price: 7},
-					Left:  &rbtree.Node{Price: 5}},
-				Left: &rbtree.Node{Price: 2,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Right: &Node{Key: 7},
+					Left:  &Node{Key: 5}},
+				Left: &Node{Key: 2,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root.Right.Right
 			},
-			wantTree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Left: &rbtree.Node{Price: 5, Red: true}},
-				Left: &rbtree.Node{Price: 2, Red: true,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
+			wantTree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Left: &Node{Key: 5, Red: true}},
+				Left: &Node{Key: 2, Red: true,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
 		},
 		{
 			name: "random_case_1",
-			tree: tree(&rbtree.Node{Price: 8,
-				Right: &rbtree.Node{Price: 10,
-					Right: &rbtree.Node{Price: 12},
-					Left:  &rbtree.Node{Price: 9}},
-				Left: &rbtree.Node{Price: 4,
-					Right: &rbtree.Node{Price: 6,
-						Right: &rbtree.Node{Price: 7, Red: true}},
-					Left: &rbtree.Node{Price: 3,
-						Left: &rbtree.Node{Price: 1, Red: true}}}}),
-			getNode: func(t *rbtree.Tree) *rbtree.Node {
+			tree: tree(&Node{Key: 8,
+				Right: &Node{Key: 10,
+					Right: &Node{Key: 12},
+					Left:  &Node{Key: 9}},
+				Left: &Node{Key: 4,
+					Right: &Node{Key: 6,
+						Right: &Node{Key: 7, Red: true}},
+					Left: &Node{Key: 3,
+						Left: &Node{Key: 1, Red: true}}}}),
+			getNode: func(t *Tree) *Node {
 				return t.Root
 			},
-			wantTree: tree(&rbtree.Node{Price: 9,
-				Right: &rbtree.Node{Price: 10,
-					Right: &rbtree.Node{Price: 12, Red: true}},
-				Left: &rbtree.Node{Price: 4, Red: true,
-					Right: &rbtree.Node{Price: 6,
-						Right: &rbtree.Node{Price: 7, Red: true}},
-					Left: &rbtree.Node{Price: 3,
-						Left: &rbtree.Node{Price: 1, Red: true}}}}),
+			wantTree: tree(&Node{Key: 9,
+				Right: &Node{Key: 10,
+					Right: &Node{Key: 12, Red: true}},
+				Left: &Node{Key: 4, Red: true,
+					Right: &Node{Key: 6,
+						Right: &Node{Key: 7, Red: true}},
+					Left: &Node{Key: 3,
+						Left: &Node{Key: 1, Red: true}}}}),
 		},
 	}
 
@@ -818,10 +818,10 @@ func Test_Delete_Head(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return newNode()
 				},
 			}
-			tree := rbtree.NewTree(tc.orientation, pool)
+			tree := newTree(tc.orientation, pool)
 			for _, num := range tc.insertions {
 				tree.Insert(num)
 			}
@@ -840,8 +840,8 @@ func Test_Delete_Head(t *testing.T) {
 					t.Fatalf("Head() wanted head with price %d, got nil head", tc.wantHeadPrice)
 				}
 
-				if tc.wantHeadPrice != head.Price {
-					t.Errorf("Head() wanted price %d, got price %d", tc.wantHeadPrice, head.Price)
+				if tc.wantHeadPrice != head.Key {
+					t.Errorf("Head() wanted price %d, got price %d", tc.wantHeadPrice, head.Key)
 				}
 			}
 		})
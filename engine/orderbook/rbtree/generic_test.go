@@ -0,0 +1,78 @@
+package rbtree_test
+
+import (
+	"sync"
+	"testing"
+
+	"exchange/engine/orderbook/rbtree"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// counter is a value that is reused along with its node.
+type counter struct {
+	n int
+}
+
+func (c *counter) Reset() {
+	c.n = 0
+}
+
+func Test_Generic_Keys(t *testing.T) {
+	pool := &sync.Pool{
+		New: func() any {
+			return rbtree.NewNode[string, int](0)
+		},
+	}
+	tr := rbtree.NewTree[string, int](rbtree.MaxFirst, pool)
+
+	for i, key := range []string{"b", "d", "a", "c"} {
+		tr.Insert(key).Value = i + 1
+	}
+
+	if err := tr.Valid(); err != nil {
+		t.Fatalf("Valid() unexpected error: %v", err)
+	}
+
+	got := []string{}
+	for n := range tr.FromHead() {
+		got = append(got, n.Key)
+	}
+
+	if diff := cmp.Diff([]string{"d", "c", "b", "a"}, got); diff != "" {
+		t.Errorf("FromHead() diff (-want, +got):\n%s", diff)
+	}
+
+	if got := tr.Count("b", "c"); got != 2 {
+		t.Errorf("Count(b, c) want: 2, got: %d", got)
+	}
+
+	n, err := tr.Get("d")
+	if err != nil {
+		t.Fatalf("Get(d) unexpected error: %v", err)
+	}
+
+	if n.Value != 2 {
+		t.Errorf("Get(d) value want: 2, got: %d", n.Value)
+	}
+
+	n.Reset()
+	if n.Key != "" || n.Value != 0 {
+		t.Errorf("Reset() want zero key and value, got: %q, %d", n.Key, n.Value)
+	}
+}
+
+func Test_Generic_Resetter(t *testing.T) {
+	value := &counter{n: 5}
+	n := rbtree.NewNode[uint64](value)
+
+	n.Reset()
+
+	if n.Value != value {
+		t.Errorf("Reset() replaced a Resetter value")
+	}
+
+	if value.n != 0 {
+		t.Errorf("Reset() did not reset the value, got: %d", value.n)
+	}
+}
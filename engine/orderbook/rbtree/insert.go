@@ -1,29 +1,30 @@
 package rbtree
 
-// Insert may or may not actually insert the requested price. If the price
+// Insert may or may not actually insert the requested key. If the key
 // already exists, its node is returned, if it doesn't, the new node is
-// inserted, rules are fixed, and the node containing the price is returned.
+// inserted, rules are fixed, and the node containing the key is returned.
 //
-// Do not search if the price exists before calling Insert. This function will
+// Do not search if the key exists before calling Insert. This function will
 // have to find its place in the tree anyways, so call this function directly
 // instead for efficient programming.
 //
 // O(log n)
-func (t *Tree) Insert(price uint64) *Node {
-	holder, side, parent := t.find(price)
+func (t *Tree[K, V]) Insert(key K) *Node[K, V] {
+	holder, side, parent := t.find(key)
 	if holder != nil {
 		return holder
 	}
 
 	holder = t.getNode()
-	holder.Price = price
+	holder.Key = key
+	holder.Size = 1
 
 	if t.orientation == MinFirst {
-		if t.head == nil || price < t.head.Price {
+		if t.head == nil || key < t.head.Key {
 			t.head = holder
 		}
 	} else {
-		if t.head == nil || price > t.head.Price {
+		if t.head == nil || key > t.head.Key {
 			t.head = holder
 		}
 	}
@@ -41,11 +42,15 @@ func (t *Tree) Insert(price uint64) *Node {
 	}
 	holder.Parent = parent
 
+	for p := parent; p != nil; p = p.Parent {
+		p.Size++
+	}
+
 	if parent.IsBlack() {
 		return holder
 	}
 
-	var y *Node
+	var y *Node[K, V]
 	z := holder
 	for {
 		if z.Parent.IsBlack() {
@@ -11,190 +11,190 @@ import (
 func Test_Insert(t *testing.T) {
 	testCases := []struct {
 		name     string
-		tree     *rbtree.Tree
+		tree     *Tree
 		price    uint64
 		wantRed  bool
-		wantTree *rbtree.Tree
+		wantTree *Tree
 	}{
 		{
 			name:     "no_root",
 			tree:     tree(nil),
 			price:    10,
-			wantTree: tree(&rbtree.Node{Price: 10}),
+			wantTree: tree(&Node{Key: 10}),
 		},
 		{
 			name:    "left",
-			tree:    tree(&rbtree.Node{Price: 5}),
+			tree:    tree(&Node{Key: 5}),
 			price:   2,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 2, Red: true}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 2, Red: true}}),
 		},
 		{
 			name:    "right",
-			tree:    tree(&rbtree.Node{Price: 5}),
+			tree:    tree(&Node{Key: 5}),
 			price:   7,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			wantTree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
 		},
 		{
 			name: "left_line",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3, Red: true}}),
 			price:   1,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 3,
-				Left:  &rbtree.Node{Price: 1, Red: true},
-				Right: &rbtree.Node{Price: 5, Red: true}}),
+			wantTree: tree(&Node{Key: 3,
+				Left:  &Node{Key: 1, Red: true},
+				Right: &Node{Key: 5, Red: true}}),
 		},
 		{
 			name: "left_arrow",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3, Red: true}}),
 			price: 4,
-			wantTree: tree(&rbtree.Node{Price: 4,
-				Left:  &rbtree.Node{Price: 3, Red: true},
-				Right: &rbtree.Node{Price: 5, Red: true}}),
+			wantTree: tree(&Node{Key: 4,
+				Left:  &Node{Key: 3, Red: true},
+				Right: &Node{Key: 5, Red: true}}),
 		},
 		{
 			name: "right_arrow",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
 			price: 6,
-			wantTree: tree(&rbtree.Node{Price: 6,
-				Left:  &rbtree.Node{Price: 5, Red: true},
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			wantTree: tree(&Node{Key: 6,
+				Left:  &Node{Key: 5, Red: true},
+				Right: &Node{Key: 7, Red: true}}),
 		},
 		{
 			name: "right_line",
-			tree: tree(&rbtree.Node{Price: 5,
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Right: &Node{Key: 7, Red: true}}),
 			price:   8,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 7,
-				Left:  &rbtree.Node{Price: 5, Red: true},
-				Right: &rbtree.Node{Price: 8, Red: true}}),
+			wantTree: tree(&Node{Key: 7,
+				Left:  &Node{Key: 5, Red: true},
+				Right: &Node{Key: 8, Red: true}}),
 		},
 		{
 			name: "left_left",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3},
+				Right: &Node{Key: 7}}),
 			price:   1,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3,
-					Left: &rbtree.Node{Price: 1, Red: true}},
-				Right: &rbtree.Node{Price: 7}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3,
+					Left: &Node{Key: 1, Red: true}},
+				Right: &Node{Key: 7}}),
 		},
 		{
 			name: "left_right",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3},
+				Right: &Node{Key: 7}}),
 			price:   4,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}},
-				Right: &rbtree.Node{Price: 7}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}},
+				Right: &Node{Key: 7}}),
 		},
 		{
 			name: "right_left",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3},
+				Right: &Node{Key: 7}}),
 			price:   6,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7,
-					Left: &rbtree.Node{Price: 6, Red: true}}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3},
+				Right: &Node{Key: 7,
+					Left: &Node{Key: 6, Red: true}}}),
 		},
 		{
 			name: "right_right",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3},
+				Right: &Node{Key: 7}}),
 			price:   8,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3},
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}}}),
 		},
 		{
 			name: "left_line_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3, Red: true},
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3, Red: true},
+				Right: &Node{Key: 7, Red: true}}),
 			price:   1,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3,
-					Left: &rbtree.Node{Price: 1, Red: true}},
-				Right: &rbtree.Node{Price: 7}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3,
+					Left: &Node{Key: 1, Red: true}},
+				Right: &Node{Key: 7}}),
 		},
 		{
 			name: "left_arrow_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3, Red: true},
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3, Red: true},
+				Right: &Node{Key: 7, Red: true}}),
 			price:   4,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3,
-					Right: &rbtree.Node{Price: 4, Red: true}},
-				Right: &rbtree.Node{Price: 7}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3,
+					Right: &Node{Key: 4, Red: true}},
+				Right: &Node{Key: 7}}),
 		},
 		{
 			name: "right_line_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3, Red: true},
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3, Red: true},
+				Right: &Node{Key: 7, Red: true}}),
 			price:   8,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7,
-					Right: &rbtree.Node{Price: 8, Red: true}}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3},
+				Right: &Node{Key: 7,
+					Right: &Node{Key: 8, Red: true}}}),
 		},
 		{
 			name: "right_arrow_extra_node",
-			tree: tree(&rbtree.Node{Price: 5,
-				Left:  &rbtree.Node{Price: 3, Red: true},
-				Right: &rbtree.Node{Price: 7, Red: true}}),
+			tree: tree(&Node{Key: 5,
+				Left:  &Node{Key: 3, Red: true},
+				Right: &Node{Key: 7, Red: true}}),
 			price:   6,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 5,
-				Left: &rbtree.Node{Price: 3},
-				Right: &rbtree.Node{Price: 7,
-					Left: &rbtree.Node{Price: 6, Red: true}}}),
+			wantTree: tree(&Node{Key: 5,
+				Left: &Node{Key: 3},
+				Right: &Node{Key: 7,
+					Left: &Node{Key: 6, Red: true}}}),
 		},
 		{
 			name: "nested_right_line",
-			tree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6, Red: true,
-					Right: &rbtree.Node{Price: 8,
-						Right: &rbtree.Node{Price: 9, Red: true},
-						Left:  &rbtree.Node{Price: 7, Red: true}},
-					Left: &rbtree.Node{Price: 5}},
-				Left: &rbtree.Node{Price: 2, Red: true,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
+			tree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6, Red: true,
+					Right: &Node{Key: 8,
+						Right: &Node{Key: 9, Red: true},
+						Left:  &Node{Key: 7, Red: true}},
+					Left: &Node{Key: 5}},
+				Left: &Node{Key: 2, Red: true,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
 			price:   10,
 			wantRed: true,
-			wantTree: tree(&rbtree.Node{Price: 4,
-				Right: &rbtree.Node{Price: 6,
-					Right: &rbtree.Node{Price: 8, Red: true,
-						Right: &rbtree.Node{Price: 9,
-							Right: &rbtree.Node{Price: 10, Red: true}},
-						Left: &rbtree.Node{Price: 7}},
-					Left: &rbtree.Node{Price: 5}},
-				Left: &rbtree.Node{Price: 2,
-					Right: &rbtree.Node{Price: 3},
-					Left:  &rbtree.Node{Price: 1}}}),
+			wantTree: tree(&Node{Key: 4,
+				Right: &Node{Key: 6,
+					Right: &Node{Key: 8, Red: true,
+						Right: &Node{Key: 9,
+							Right: &Node{Key: 10, Red: true}},
+						Left: &Node{Key: 7}},
+					Left: &Node{Key: 5}},
+				Left: &Node{Key: 2,
+					Right: &Node{Key: 3},
+					Left:  &Node{Key: 1}}}),
 		},
 	}
 
@@ -215,8 +215,8 @@ func Test_Insert(t *testing.T) {
 				t.Fatalf("Invalid got tree: %v\ngot:\n%s", err, tc.tree.PrintTree())
 			}
 
-			if gotNode.Price != tc.price {
-				t.Errorf("Insert(%v) expected node with price: %v, got price: %v", tc.price, tc.price, gotNode.Price)
+			if gotNode.Key != tc.price {
+				t.Errorf("Insert(%v) expected node with price: %v, got price: %v", tc.price, tc.price, gotNode.Key)
 			}
 
 			if gotNode.Red != tc.wantRed {
@@ -324,10 +324,10 @@ func Test_Insert_Head(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return newNode()
 				},
 			}
-			tree := rbtree.NewTree(tc.orientation, pool)
+			tree := newTree(tc.orientation, pool)
 			for _, num := range tc.insertions {
 				tree.Insert(num)
 			}
@@ -342,8 +342,8 @@ func Test_Insert_Head(t *testing.T) {
 					t.Fatalf("Head() wanted head with price %d, got nil head", tc.wantHeadPrice)
 				}
 
-				if tc.wantHeadPrice != head.Price {
-					t.Errorf("Head() wanted price %d, got price %d", tc.wantHeadPrice, head.Price)
+				if tc.wantHeadPrice != head.Key {
+					t.Errorf("Head() wanted price %d, got price %d", tc.wantHeadPrice, head.Key)
 				}
 			}
 		})
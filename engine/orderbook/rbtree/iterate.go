@@ -0,0 +1,112 @@
+package rbtree
+
+import "iter"
+
+// Successor returns the node with the smallest key greater than this node's,
+// or nil if it is the maximum. It follows parent references, so it does not
+// allocate.
+//
+// O(log n), amortized O(1) when iterating the whole tree.
+func (n *Node[K, V]) Successor() *Node[K, V] {
+	if n.Right != nil {
+		n = n.Right
+		for n.Left != nil {
+			n = n.Left
+		}
+		return n
+	}
+
+	for n.Parent != nil && n == n.Parent.Right {
+		n = n.Parent
+	}
+
+	return n.Parent
+}
+
+// Predecessor returns the node with the greatest key smaller than this
+// node's, or nil if it is the minimum.
+//
+// O(log n), amortized O(1) when iterating the whole tree.
+func (n *Node[K, V]) Predecessor() *Node[K, V] {
+	if n.Left != nil {
+		n = n.Left
+		for n.Right != nil {
+			n = n.Right
+		}
+		return n
+	}
+
+	for n.Parent != nil && n == n.Parent.Left {
+		n = n.Parent
+	}
+
+	return n.Parent
+}
+
+// Ascend iterates the nodes from the minimum to the maximum key.
+//
+// The tree must not be modified during the iteration.
+//
+// O(n)
+func (t *Tree[K, V]) Ascend() iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		for n := t.Min(); n != nil; n = n.Successor() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Descend iterates the nodes from the maximum to the minimum key.
+//
+// The tree must not be modified during the iteration.
+//
+// O(n)
+func (t *Tree[K, V]) Descend() iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		for n := t.Max(); n != nil; n = n.Predecessor() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// FromHead iterates the nodes starting from Head, ascending for a MinFirst
+// tree and descending for a MaxFirst tree. For an order book this is from the
+// best price to the worst.
+//
+// The tree must not be modified during the iteration.
+//
+// O(n), O(k) to stop after k nodes.
+func (t *Tree[K, V]) FromHead() iter.Seq[*Node[K, V]] {
+	step := (*Node[K, V]).Successor
+	if t.orientation == MaxFirst {
+		step = (*Node[K, V]).Predecessor
+	}
+
+	return func(yield func(*Node[K, V]) bool) {
+		for n := t.head; n != nil; n = step(n) {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Range iterates the nodes with keys between lo and hi, both included, from
+// the minimum to the maximum key.
+//
+// The tree must not be modified during the iteration.
+//
+// O(log n + k) for k nodes in the range.
+func (t *Tree[K, V]) Range(lo K, hi K) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		for n := t.Ceiling(lo); n != nil && n.Key <= hi; n = n.Successor() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
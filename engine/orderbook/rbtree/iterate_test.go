@@ -0,0 +1,137 @@
+package rbtree_test
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+
+	"exchange/engine/orderbook/rbtree"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func prices(seq iter.Seq[*Node], limit int) []uint64 {
+	res := []uint64{}
+	for n := range seq {
+		if len(res) == limit {
+			break
+		}
+		res = append(res, n.Key)
+	}
+
+	return res
+}
+
+func Test_Iterate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		orientation  rbtree.TreeOrientation
+		insertions   []uint64
+		limit        int
+		wantAscend   []uint64
+		wantDescend  []uint64
+		wantFromHead []uint64
+	}{
+		{
+			name:         "empty",
+			orientation:  rbtree.MinFirst,
+			limit:        10,
+			wantAscend:   []uint64{},
+			wantDescend:  []uint64{},
+			wantFromHead: []uint64{},
+		},
+		{
+			name:         "root",
+			orientation:  rbtree.MinFirst,
+			insertions:   []uint64{4},
+			limit:        10,
+			wantAscend:   []uint64{4},
+			wantDescend:  []uint64{4},
+			wantFromHead: []uint64{4},
+		},
+		{
+			name:         "min_first",
+			orientation:  rbtree.MinFirst,
+			insertions:   []uint64{5, 3, 8, 1, 4, 7, 9, 2, 6},
+			limit:        10,
+			wantAscend:   []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			wantDescend:  []uint64{9, 8, 7, 6, 5, 4, 3, 2, 1},
+			wantFromHead: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:         "max_first",
+			orientation:  rbtree.MaxFirst,
+			insertions:   []uint64{5, 3, 8, 1, 4, 7, 9, 2, 6},
+			limit:        10,
+			wantAscend:   []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			wantDescend:  []uint64{9, 8, 7, 6, 5, 4, 3, 2, 1},
+			wantFromHead: []uint64{9, 8, 7, 6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:         "stop_early",
+			orientation:  rbtree.MaxFirst,
+			insertions:   []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			limit:        3,
+			wantAscend:   []uint64{1, 2, 3},
+			wantDescend:  []uint64{9, 8, 7},
+			wantFromHead: []uint64{9, 8, 7},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &sync.Pool{
+				New: func() any {
+					return newNode()
+				},
+			}
+			tree := newTree(tc.orientation, pool)
+			for _, price := range tc.insertions {
+				tree.Insert(price)
+			}
+
+			if diff := cmp.Diff(tc.wantAscend, prices(tree.Ascend(), tc.limit)); diff != "" {
+				t.Errorf("Ascend() diff (-want, +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantDescend, prices(tree.Descend(), tc.limit)); diff != "" {
+				t.Errorf("Descend() diff (-want, +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantFromHead, prices(tree.FromHead(), tc.limit)); diff != "" {
+				t.Errorf("FromHead() diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Iterate_AfterDeletions(t *testing.T) {
+	pool := &sync.Pool{
+		New: func() any {
+			return newNode()
+		},
+	}
+	tree := newTree(rbtree.MinFirst, pool)
+	for i := uint64(1); i <= 100; i++ {
+		tree.Insert(i)
+	}
+
+	want := []uint64{}
+	for i := uint64(1); i <= 100; i++ {
+		if i%3 == 0 {
+			tree.Delete(i)
+			continue
+		}
+		want = append(want, i)
+	}
+
+	if diff := cmp.Diff(want, prices(tree.Ascend(), 100)); diff != "" {
+		t.Errorf("Ascend() diff (-want, +got):\n%s", diff)
+	}
+
+	slices.Reverse(want)
+	if diff := cmp.Diff(want, prices(tree.Descend(), 100)); diff != "" {
+		t.Errorf("Descend() diff (-want, +got):\n%s", diff)
+	}
+}
@@ -2,8 +2,8 @@ package rbtree
 
 import (
 	"bytes"
-	"exchange/engine/orderbook/pricelevel"
-	"strconv"
+	"cmp"
+	"fmt"
 )
 
 // ChildSide is a type to identify the direction of a child
@@ -18,25 +18,38 @@ const (
 // data of generic type.
 //
 // A nil node is evaluated as being black.
-type Node struct {
-	Parent *Node
-	Left   *Node
-	Right  *Node
+type Node[K cmp.Ordered, V any] struct {
+	Parent *Node[K, V]
+	Left   *Node[K, V]
+	Right  *Node[K, V]
 	Red    bool
 
-	Price  uint64
-	Orders *pricelevel.PriceLevel
+	// The number of nodes in the subtree rooted at this node, itself included.
+	// Maintained by Insert, Delete and the rotations to answer rank queries.
+	Size int
+
+	Key   K
+	Value V
+}
+
+// Resetter is implemented by values that are kept with their node when it is
+// recycled through the pool, and only need to be cleared instead of replaced.
+type Resetter interface {
+	Reset()
 }
 
-func NewNode() *Node {
-	return &Node{
-		Orders: pricelevel.New(),
+// NewNode returns a node holding the value. Nodes are meant to be created by
+// the New function of the pool given to NewTree, so that values which
+// implement Resetter are reused along with their node.
+func NewNode[K cmp.Ordered, V any](value V) *Node[K, V] {
+	return &Node[K, V]{
+		Value: value,
 	}
 }
 
 // PrintTree uses recursion to create a text representation of the Node's subtree.
 // To get the string of an entire tree, call PrintTree on its node.
-func (n *Node) PrintTree(buffer *bytes.Buffer, prefix []byte, childrenPrefix []byte) {
+func (n *Node[K, V]) PrintTree(buffer *bytes.Buffer, prefix []byte, childrenPrefix []byte) {
 	color := []byte("BLK")
 	if n.Red {
 		color = []byte("RED")
@@ -45,7 +58,7 @@ func (n *Node) PrintTree(buffer *bytes.Buffer, prefix []byte, childrenPrefix []b
 	buffer.Write(prefix)
 	buffer.Write(color)
 	buffer.WriteByte(':')
-	buffer.WriteString(strconv.FormatUint(n.Price, 10))
+	fmt.Fprint(buffer, n.Key)
 	buffer.WriteByte('\n')
 
 	if n.Right != nil {
@@ -107,19 +120,33 @@ func (n *Node) PrintTree(buffer *bytes.Buffer, prefix []byte, childrenPrefix []b
 
 }
 
-func (n *Node) IsRoot() bool {
+func (n *Node[K, V]) IsRoot() bool {
 	return n.Parent == nil
 }
 
-func (n *Node) IsRed() bool {
+// size returns the size of the subtree, 0 for a nil node.
+func (n *Node[K, V]) size() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.Size
+}
+
+// updateSize recomputes the size of the node from its children.
+func (n *Node[K, V]) updateSize() {
+	n.Size = n.Left.size() + n.Right.size() + 1
+}
+
+func (n *Node[K, V]) IsRed() bool {
 	return n != nil && n.Red
 }
 
-func (n *Node) IsBlack() bool {
+func (n *Node[K, V]) IsBlack() bool {
 	return n == nil || !n.Red
 }
 
-func (n *Node) Reset() {
+func (n *Node[K, V]) Reset() {
 	if n == nil {
 		return
 	}
@@ -128,9 +155,15 @@ func (n *Node) Reset() {
 	n.Left = nil
 	n.Right = nil
 	n.Red = false
+	n.Size = 0
 
-	n.Price = 0
-	n.Orders.Reset()
+	var key K
+	n.Key = key
 
-	// n.orders.Init()
+	if r, ok := any(n.Value).(Resetter); ok {
+		r.Reset()
+	} else {
+		var value V
+		n.Value = value
+	}
 }
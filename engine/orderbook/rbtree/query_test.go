@@ -0,0 +1,241 @@
+package rbtree_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// validSizes returns an error if the size of any node in the subtree does not
+// match the number of nodes below it. It returns the size of the subtree.
+func validSizes(n *Node) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+
+	left, err := validSizes(n.Left)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := validSizes(n.Right)
+	if err != nil {
+		return 0, err
+	}
+
+	if n.Size != left+right+1 {
+		return 0, fmt.Errorf("invalid size of node %d: want %d, got %d", n.Key, left+right+1, n.Size)
+	}
+
+	return n.Size, nil
+}
+
+// evens returns a tree with the even prices from 2 to 20.
+func evens() *Tree {
+	tr := tree(nil)
+	for price := uint64(2); price <= 20; price += 2 {
+		tr.Insert(price)
+	}
+
+	return tr
+}
+
+func Test_Floor_Ceiling(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tree        *Tree
+		price       uint64
+		wantFloor   uint64
+		wantCeiling uint64
+	}{
+		{
+			name:  "empty",
+			tree:  tree(nil),
+			price: 5,
+		},
+		{
+			name:        "exact",
+			tree:        evens(),
+			price:       8,
+			wantFloor:   8,
+			wantCeiling: 8,
+		},
+		{
+			name:        "between",
+			tree:        evens(),
+			price:       9,
+			wantFloor:   8,
+			wantCeiling: 10,
+		},
+		{
+			name:        "below_min",
+			tree:        evens(),
+			price:       1,
+			wantCeiling: 2,
+		},
+		{
+			name:      "above_max",
+			tree:      evens(),
+			price:     21,
+			wantFloor: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			floor := tc.tree.Floor(tc.price)
+			if floor == nil && tc.wantFloor != 0 || floor != nil && floor.Key != tc.wantFloor {
+				t.Errorf("Floor(%d) want: %d, got: %v", tc.price, tc.wantFloor, floor)
+			}
+
+			ceiling := tc.tree.Ceiling(tc.price)
+			if ceiling == nil && tc.wantCeiling != 0 || ceiling != nil && ceiling.Key != tc.wantCeiling {
+				t.Errorf("Ceiling(%d) want: %d, got: %v", tc.price, tc.wantCeiling, ceiling)
+			}
+		})
+	}
+}
+
+func Test_Successor_Predecessor(t *testing.T) {
+	tr := evens()
+
+	for price := uint64(2); price <= 20; price += 2 {
+		n, err := tr.Get(price)
+		if err != nil {
+			t.Fatalf("Get(%d) unexpected error: %v", price, err)
+		}
+
+		if succ := n.Successor(); price == 20 && succ != nil || price < 20 && (succ == nil || succ.Key != price+2) {
+			t.Errorf("Successor() of %d got: %v", price, succ)
+		}
+
+		if pred := n.Predecessor(); price == 2 && pred != nil || price > 2 && (pred == nil || pred.Key != price-2) {
+			t.Errorf("Predecessor() of %d got: %v", price, pred)
+		}
+	}
+}
+
+func Test_Range(t *testing.T) {
+	testCases := []struct {
+		name      string
+		tree      *Tree
+		lo        uint64
+		hi        uint64
+		wantRange []uint64
+		wantCount int
+	}{
+		{
+			name:      "empty",
+			tree:      tree(nil),
+			lo:        1,
+			hi:        10,
+			wantRange: []uint64{},
+		},
+		{
+			name:      "inclusive",
+			tree:      evens(),
+			lo:        4,
+			hi:        10,
+			wantRange: []uint64{4, 6, 8, 10},
+			wantCount: 4,
+		},
+		{
+			name:      "between_prices",
+			tree:      evens(),
+			lo:        5,
+			hi:        11,
+			wantRange: []uint64{6, 8, 10},
+			wantCount: 3,
+		},
+		{
+			name:      "single",
+			tree:      evens(),
+			lo:        12,
+			hi:        12,
+			wantRange: []uint64{12},
+			wantCount: 1,
+		},
+		{
+			name:      "no_prices",
+			tree:      evens(),
+			lo:        13,
+			hi:        13,
+			wantRange: []uint64{},
+		},
+		{
+			name:      "inverted",
+			tree:      evens(),
+			lo:        10,
+			hi:        4,
+			wantRange: []uint64{},
+		},
+		{
+			name:      "whole_tree",
+			tree:      evens(),
+			lo:        0,
+			hi:        ^uint64(0),
+			wantRange: []uint64{2, 4, 6, 8, 10, 12, 14, 16, 18, 20},
+			wantCount: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.wantRange, prices(tc.tree.Range(tc.lo, tc.hi), 100)); diff != "" {
+				t.Errorf("Range(%d, %d) diff (-want, +got):\n%s", tc.lo, tc.hi, diff)
+			}
+
+			if got := tc.tree.Count(tc.lo, tc.hi); got != tc.wantCount {
+				t.Errorf("Count(%d, %d) want: %d, got: %d", tc.lo, tc.hi, tc.wantCount, got)
+			}
+		})
+	}
+}
+
+// Test_Rank_Count compares the augmented queries with a linear scan while the
+// tree is randomly modified.
+func Test_Rank_Count(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := tree(nil)
+	present := map[uint64]bool{}
+
+	for i := range 2_000 {
+		price := uint64(r.Intn(200))
+		if r.Intn(3) == 0 {
+			tr.Delete(price)
+			delete(present, price)
+		} else {
+			tr.Insert(price)
+			present[price] = true
+		}
+
+		if _, err := validSizes(tr.Root); err != nil {
+			t.Fatalf("operation %d: %v", i, err)
+		}
+
+		if tr.Len() != len(present) {
+			t.Fatalf("operation %d: Len() want: %d, got: %d", i, len(present), tr.Len())
+		}
+
+		lo, hi := uint64(r.Intn(210)), uint64(r.Intn(210))
+		wantRank, wantCount := 0, 0
+		for p := range present {
+			if p < lo {
+				wantRank++
+			}
+			if lo <= p && p <= hi {
+				wantCount++
+			}
+		}
+
+		if got := tr.Rank(lo); got != wantRank {
+			t.Fatalf("operation %d: Rank(%d) want: %d, got: %d", i, lo, wantRank, got)
+		}
+
+		if got := tr.Count(lo, hi); got != wantCount {
+			t.Fatalf("operation %d: Count(%d, %d) want: %d, got: %d", i, lo, hi, wantCount, got)
+		}
+	}
+}
@@ -23,6 +23,10 @@ func Fuzz_Insert_Delete(f *testing.F) {
 			if err := tr.Valid(); err != nil {
 				t.Fatalf("Insert(%v). Invalid tree after sequence: %v. Error: %v", v, prices[:i], err)
 			}
+
+			if _, err := validSizes(tr.Root); err != nil {
+				t.Fatalf("Insert(%v). Invalid sizes after sequence: %v. Error: %v", v, prices[:i], err)
+			}
 		}
 
 		for i, v := range prices {
@@ -31,6 +35,10 @@ func Fuzz_Insert_Delete(f *testing.F) {
 			if err := tr.Valid(); err != nil {
 				t.Fatalf("Delete(%v). Invalid tree after insert sequence: %v, and delete sequence: %v. Error: %v", v, prices, prices[:i], err)
 			}
+
+			if _, err := validSizes(tr.Root); err != nil {
+				t.Fatalf("Delete(%v). Invalid sizes after insert sequence: %v, and delete sequence: %v. Error: %v", v, prices, prices[:i], err)
+			}
 		}
 	})
 }
@@ -1,17 +1,32 @@
 package rbtree_test
 
 import (
+	"exchange/engine/orderbook/pricelevel"
 	"exchange/engine/orderbook/rbtree"
 	"sync"
 	"testing"
 )
 
-func connectParents(t *rbtree.Tree) {
+// The trees under test hold price levels, like the ones of the order books.
+type (
+	Node = rbtree.Node[uint64, *pricelevel.PriceLevel]
+	Tree = rbtree.Tree[uint64, *pricelevel.PriceLevel]
+)
+
+func newNode() *Node {
+	return rbtree.NewNode[uint64](pricelevel.New())
+}
+
+func newTree(orientation rbtree.TreeOrientation, pool *sync.Pool) *Tree {
+	return rbtree.NewTree[uint64, *pricelevel.PriceLevel](orientation, pool)
+}
+
+func connectParents(t *Tree) {
 	if t == nil || t.Root == nil {
 		return
 	}
 
-	visited := []*rbtree.Node{t.Root}
+	visited := []*Node{t.Root}
 	for {
 		if len(visited) == 0 {
 			return
@@ -33,13 +48,13 @@ func connectParents(t *rbtree.Tree) {
 }
 
 // tree is an easy way to create trees in tests
-func tree(root *rbtree.Node) *rbtree.Tree {
+func tree(root *Node) *Tree {
 	pool := &sync.Pool{
 		New: func() any {
-			return rbtree.NewNode()
+			return newNode()
 		},
 	}
-	t := rbtree.NewTree(rbtree.MinFirst, pool)
+	t := newTree(rbtree.MinFirst, pool)
 	t.Root = root
 	return t
 }
@@ -47,102 +62,102 @@ func tree(root *rbtree.Node) *rbtree.Tree {
 func Test_connectParents(t *testing.T) {
 	testCases := []struct {
 		name string
-		tree *rbtree.Tree
-		want func() *rbtree.Tree
+		tree *Tree
+		want func() *Tree
 	}{
 		{
 			name: "empty",
-			tree: &rbtree.Tree{},
-			want: func() *rbtree.Tree {
-				return &rbtree.Tree{}
+			tree: &Tree{},
+			want: func() *Tree {
+				return &Tree{}
 			},
 		},
 		{
 			name: "few_nodes",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key:   2,
+					Left:  &Node{Key: 1},
+					Right: &Node{Key: 3},
 				},
 			},
-			want: func() *rbtree.Tree {
-				root := &rbtree.Node{Price: 2}
-				root.Left = &rbtree.Node{Price: 1}
+			want: func() *Tree {
+				root := &Node{Key: 2}
+				root.Left = &Node{Key: 1}
 				root.Left.Parent = root
 
-				root.Right = &rbtree.Node{Price: 3}
+				root.Right = &Node{Key: 3}
 				root.Right.Parent = root
-				return &rbtree.Tree{Root: root}
+				return &Tree{Root: root}
 			},
 		},
 		{
 			name: "multiple_nodes",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 10,
-					Left: &rbtree.Node{
-						Price: 5,
-						Left: &rbtree.Node{
-							Price: 3,
-							Left:  &rbtree.Node{Price: 1},
-							Right: &rbtree.Node{Price: 4},
+			tree: &Tree{
+				Root: &Node{
+					Key: 10,
+					Left: &Node{
+						Key: 5,
+						Left: &Node{
+							Key:   3,
+							Left:  &Node{Key: 1},
+							Right: &Node{Key: 4},
 						},
-						Right: &rbtree.Node{
-							Price: 7,
-							Right: &rbtree.Node{Price: 8},
+						Right: &Node{
+							Key:   7,
+							Right: &Node{Key: 8},
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 15,
-						Left: &rbtree.Node{
-							Price: 13,
-							Left:  &rbtree.Node{Price: 11},
+					Right: &Node{
+						Key: 15,
+						Left: &Node{
+							Key:  13,
+							Left: &Node{Key: 11},
 						},
-						Right: &rbtree.Node{
-							Price: 16,
-							Right: &rbtree.Node{Price: 17},
+						Right: &Node{
+							Key:   16,
+							Right: &Node{Key: 17},
 						},
 					},
 				},
 			},
-			want: func() *rbtree.Tree {
-				root := &rbtree.Node{Price: 10}
+			want: func() *Tree {
+				root := &Node{Key: 10}
 
-				rl := &rbtree.Node{Price: 5}
+				rl := &Node{Key: 5}
 				root.Left = rl
 				rl.Parent = root
 
-				rll := &rbtree.Node{Price: 3}
+				rll := &Node{Key: 3}
 				rl.Left = rll
 				rll.Parent = rl
-				rll.Left = &rbtree.Node{Price: 1}
+				rll.Left = &Node{Key: 1}
 				rll.Left.Parent = rll
-				rll.Right = &rbtree.Node{Price: 4}
+				rll.Right = &Node{Key: 4}
 				rll.Right.Parent = rll
 
-				rlr := &rbtree.Node{Price: 7}
+				rlr := &Node{Key: 7}
 				rl.Right = rlr
 				rlr.Parent = rl
-				rlr.Right = &rbtree.Node{Price: 8}
+				rlr.Right = &Node{Key: 8}
 				rlr.Right.Parent = rlr
 
-				rr := &rbtree.Node{Price: 15}
+				rr := &Node{Key: 15}
 				root.Right = rr
 				rr.Parent = root
 
-				rrl := &rbtree.Node{Price: 13}
+				rrl := &Node{Key: 13}
 				rr.Left = rrl
 				rrl.Parent = rr
-				rrl.Left = &rbtree.Node{Price: 11}
+				rrl.Left = &Node{Key: 11}
 				rrl.Left.Parent = rrl
-				rrr := &rbtree.Node{Price: 16}
+				rrr := &Node{Key: 16}
 				rr.Right = rrr
 				rrr.Parent = rr
-				rrr.Right = &rbtree.Node{Price: 17}
+				rrr.Right = &Node{Key: 17}
 				rrr.Right.Parent = rrr
 
-				return &rbtree.Tree{Root: root}
+				return &Tree{Root: root}
 			},
 		},
 	}
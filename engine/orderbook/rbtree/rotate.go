@@ -8,7 +8,7 @@ package rbtree
 // It takes the node x, and makes its right child y the
 // new root of the subtree. x becomes the left child of y and y's left subtree
 // becomes the right subtree of x.
-func (t *Tree) RotateLeft(x *Node) {
+func (t *Tree[K, V]) RotateLeft(x *Node[K, V]) {
 	if x == nil {
 		return
 	}
@@ -34,6 +34,9 @@ func (t *Tree) RotateLeft(x *Node) {
 
 	y.Left = x
 	x.Parent = y
+
+	y.Size = x.Size
+	x.updateSize()
 }
 
 // RotateRight is an elementary operation of binary trees that keeps
@@ -42,7 +45,7 @@ func (t *Tree) RotateLeft(x *Node) {
 // It takes the node x, and makes its left child y the
 // new root of the subtree. x becomes the right child of y and y's right subtree
 // becomes the left subtree of x.
-func (t *Tree) RotateRight(x *Node) {
+func (t *Tree[K, V]) RotateRight(x *Node[K, V]) {
 	if x == nil || x.Left == nil {
 		return
 	}
@@ -64,4 +67,7 @@ func (t *Tree) RotateRight(x *Node) {
 
 	y.Right = x
 	x.Parent = y
+
+	y.Size = x.Size
+	x.updateSize()
 }
@@ -1,187 +1,186 @@
 package rbtree_test
 
 import (
-	"exchange/engine/orderbook/rbtree"
 	"testing"
 )
 
 func Test_RotateLeft(t *testing.T) {
 	testCases := []struct {
 		name   string
-		tree   func() *rbtree.Tree
-		rotate func(tree *rbtree.Tree) *rbtree.Node
-		want   *rbtree.Tree
+		tree   func() *Tree
+		rotate func(tree *Tree) *Node
+		want   *Tree
 	}{
 		{
 			name: "nil",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{}
+			tree: func() *Tree {
+				return &Tree{}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{},
+			want: &Tree{},
 		},
 		{
 			name: "no_right_child",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{Root: &rbtree.Node{Price: 1}}
+			tree: func() *Tree {
+				return &Tree{Root: &Node{Key: 1}}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 1}},
+			want: &Tree{Root: &Node{Key: 1}},
 		},
 		{
 			name: "root_no_subtree",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{Root: &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 2}}}
+			tree: func() *Tree {
+				return &Tree{Root: &Node{Key: 1, Right: &Node{Key: 2}}}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 1}}},
+			want: &Tree{Root: &Node{Key: 2, Left: &Node{Key: 1}}},
 		},
 		{
 			name: "root_subtree",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{Root: &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 3}}}}
+			tree: func() *Tree {
+				return &Tree{Root: &Node{Key: 1, Right: &Node{Key: 2, Left: &Node{Key: 3}}}}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 3}}}},
+			want: &Tree{Root: &Node{Key: 2, Left: &Node{Key: 1, Right: &Node{Key: 3}}}},
 		},
 		{
 			name: "root_multiple_subtrees",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{
-					Root: &rbtree.Node{Price: 1, // x
-						Left: &rbtree.Node{Price: 5}, // a
-						Right: &rbtree.Node{Price: 2, // y
-							Left:  &rbtree.Node{Price: 4},  // _b
-							Right: &rbtree.Node{Price: 3}}, // _y
+			tree: func() *Tree {
+				return &Tree{
+					Root: &Node{Key: 1, // x
+						Left: &Node{Key: 5}, // a
+						Right: &Node{Key: 2, // y
+							Left:  &Node{Key: 4},  // _b
+							Right: &Node{Key: 3}}, // _y
 					},
 				}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{
-				Root: &rbtree.Node{Price: 2, // y
-					Left: &rbtree.Node{Price: 1, // x
-						Left:  &rbtree.Node{Price: 5},  // a
-						Right: &rbtree.Node{Price: 4}}, // _b
-					Right: &rbtree.Node{Price: 3}, // _y
+			want: &Tree{
+				Root: &Node{Key: 2, // y
+					Left: &Node{Key: 1, // x
+						Left:  &Node{Key: 5},  // a
+						Right: &Node{Key: 4}}, // _b
+					Right: &Node{Key: 3}, // _y
 				},
 			},
 		},
 		{
 			name: "left_child_no_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Left = &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 2}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Left = &Node{Key: 1, Right: &Node{Key: 2}}
 				tr.Root.Left.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Left
 			},
-			want: &rbtree.Tree{
-				Root: &rbtree.Node{Price: 10,
-					Left: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 1}}}},
+			want: &Tree{
+				Root: &Node{Key: 10,
+					Left: &Node{Key: 2, Left: &Node{Key: 1}}}},
 		},
 		{
 			name: "right_child_no_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Right = &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 2}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Right = &Node{Key: 1, Right: &Node{Key: 2}}
 				tr.Root.Right.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Right
 			},
-			want: &rbtree.Tree{
-				Root: &rbtree.Node{Price: 10,
-					Right: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 1}}}},
+			want: &Tree{
+				Root: &Node{Key: 10,
+					Right: &Node{Key: 2, Left: &Node{Key: 1}}}},
 		},
 		{
 			name: "left_child_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Left = &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 3}}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Left = &Node{Key: 1, Right: &Node{Key: 2, Left: &Node{Key: 3}}}
 				tr.Root.Left.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Left
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Left: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 3}}}}},
+			want: &Tree{Root: &Node{Key: 10, Left: &Node{Key: 2, Left: &Node{Key: 1, Right: &Node{Key: 3}}}}},
 		},
 		{
 			name: "right_child_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Right = &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 3}}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Right = &Node{Key: 1, Right: &Node{Key: 2, Left: &Node{Key: 3}}}
 				tr.Root.Right.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Right
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Right: &rbtree.Node{Price: 2, Left: &rbtree.Node{Price: 1, Right: &rbtree.Node{Price: 3}}}}},
+			want: &Tree{Root: &Node{Key: 10, Right: &Node{Key: 2, Left: &Node{Key: 1, Right: &Node{Key: 3}}}}},
 		},
 		{
 			name: "left_child_multiple_subtrees",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Left = &rbtree.Node{Price: 1, // x
-					Left: &rbtree.Node{Price: 5}, // a
-					Right: &rbtree.Node{Price: 2, // y
-						Left:  &rbtree.Node{Price: 4},  // _b
-						Right: &rbtree.Node{Price: 3}}, // _y
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Left = &Node{Key: 1, // x
+					Left: &Node{Key: 5}, // a
+					Right: &Node{Key: 2, // y
+						Left:  &Node{Key: 4},  // _b
+						Right: &Node{Key: 3}}, // _y
 				}
 				tr.Root.Left.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Left
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Left: &rbtree.Node{Price: 2, // y
-				Left: &rbtree.Node{Price: 1, // x
-					Left:  &rbtree.Node{Price: 5},  // a
-					Right: &rbtree.Node{Price: 4}}, // _b
-				Right: &rbtree.Node{Price: 3}, // _y
+			want: &Tree{Root: &Node{Key: 10, Left: &Node{Key: 2, // y
+				Left: &Node{Key: 1, // x
+					Left:  &Node{Key: 5},  // a
+					Right: &Node{Key: 4}}, // _b
+				Right: &Node{Key: 3}, // _y
 			}}},
 		},
 		{
 			name: "right_child_multiple_subtrees",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Right = &rbtree.Node{Price: 1, // x
-					Left: &rbtree.Node{Price: 5}, // a
-					Right: &rbtree.Node{Price: 2, // y
-						Left:  &rbtree.Node{Price: 4},  // _b
-						Right: &rbtree.Node{Price: 3}}, // _y
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Right = &Node{Key: 1, // x
+					Left: &Node{Key: 5}, // a
+					Right: &Node{Key: 2, // y
+						Left:  &Node{Key: 4},  // _b
+						Right: &Node{Key: 3}}, // _y
 				}
 				tr.Root.Right.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Right
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Right: &rbtree.Node{Price: 2, // y
-				Left: &rbtree.Node{Price: 1, // x
-					Left:  &rbtree.Node{Price: 5},  // a
-					Right: &rbtree.Node{Price: 4}}, // _b
-				Right: &rbtree.Node{Price: 3}, // _y
+			want: &Tree{Root: &Node{Key: 10, Right: &Node{Key: 2, // y
+				Left: &Node{Key: 1, // x
+					Left:  &Node{Key: 5},  // a
+					Right: &Node{Key: 4}}, // _b
+				Right: &Node{Key: 3}, // _y
 			}}},
 		},
 	}
@@ -206,181 +205,181 @@ func Test_RotateLeft(t *testing.T) {
 func Test_RotateRight(t *testing.T) {
 	testCases := []struct {
 		name   string
-		tree   func() *rbtree.Tree
-		rotate func(tree *rbtree.Tree) *rbtree.Node
-		want   *rbtree.Tree
+		tree   func() *Tree
+		rotate func(tree *Tree) *Node
+		want   *Tree
 	}{
 		{
 			name: "nil",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{}
+			tree: func() *Tree {
+				return &Tree{}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{},
+			want: &Tree{},
 		},
 		{
 			name: "no_left_child",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{Root: &rbtree.Node{Price: 1}}
+			tree: func() *Tree {
+				return &Tree{Root: &Node{Key: 1}}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 1}},
+			want: &Tree{Root: &Node{Key: 1}},
 		},
 		{
 			name: "root_no_subtree",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{Root: &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 2}}}
+			tree: func() *Tree {
+				return &Tree{Root: &Node{Key: 1, Left: &Node{Key: 2}}}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 1}}},
+			want: &Tree{Root: &Node{Key: 2, Right: &Node{Key: 1}}},
 		},
 		{
 			name: "root_subtree",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{Root: &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 3}}}}
+			tree: func() *Tree {
+				return &Tree{Root: &Node{Key: 1, Left: &Node{Key: 2, Right: &Node{Key: 3}}}}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 3}}}},
+			want: &Tree{Root: &Node{Key: 2, Right: &Node{Key: 1, Left: &Node{Key: 3}}}},
 		},
 		{
 			name: "root_multiple_subtrees",
-			tree: func() *rbtree.Tree {
-				return &rbtree.Tree{
-					Root: &rbtree.Node{Price: 2, // y
-						Left: &rbtree.Node{Price: 1, // x
-							Left:  &rbtree.Node{Price: 5},  // a
-							Right: &rbtree.Node{Price: 4}}, // _b
-						Right: &rbtree.Node{Price: 3}, // _y
+			tree: func() *Tree {
+				return &Tree{
+					Root: &Node{Key: 2, // y
+						Left: &Node{Key: 1, // x
+							Left:  &Node{Key: 5},  // a
+							Right: &Node{Key: 4}}, // _b
+						Right: &Node{Key: 3}, // _y
 					},
 				}
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root
 			},
-			want: &rbtree.Tree{
-				Root: &rbtree.Node{Price: 1, // x
-					Left: &rbtree.Node{Price: 5}, // a
-					Right: &rbtree.Node{Price: 2, // y
-						Left:  &rbtree.Node{Price: 4},  // _b
-						Right: &rbtree.Node{Price: 3}}, // _y
+			want: &Tree{
+				Root: &Node{Key: 1, // x
+					Left: &Node{Key: 5}, // a
+					Right: &Node{Key: 2, // y
+						Left:  &Node{Key: 4},  // _b
+						Right: &Node{Key: 3}}, // _y
 				},
 			},
 		},
 		{
 			name: "left_child_no_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Left = &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 2}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Left = &Node{Key: 1, Left: &Node{Key: 2}}
 				tr.Root.Left.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Left
 			},
-			want: &rbtree.Tree{
-				Root: &rbtree.Node{Price: 10,
-					Left: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 1}}}},
+			want: &Tree{
+				Root: &Node{Key: 10,
+					Left: &Node{Key: 2, Right: &Node{Key: 1}}}},
 		},
 		{
 			name: "right_child_no_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Right = &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 2}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Right = &Node{Key: 1, Left: &Node{Key: 2}}
 				tr.Root.Right.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Right
 			},
-			want: &rbtree.Tree{
-				Root: &rbtree.Node{Price: 10,
-					Right: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 1}}}},
+			want: &Tree{
+				Root: &Node{Key: 10,
+					Right: &Node{Key: 2, Right: &Node{Key: 1}}}},
 		},
 		{
 			name: "left_child_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Left = &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 3}}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Left = &Node{Key: 1, Left: &Node{Key: 2, Right: &Node{Key: 3}}}
 				tr.Root.Left.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Left
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Left: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 3}}}}},
+			want: &Tree{Root: &Node{Key: 10, Left: &Node{Key: 2, Right: &Node{Key: 1, Left: &Node{Key: 3}}}}},
 		},
 		{
 			name: "right_child_subtree",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Right = &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 3}}}
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Right = &Node{Key: 1, Left: &Node{Key: 2, Right: &Node{Key: 3}}}
 				tr.Root.Right.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Right
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Right: &rbtree.Node{Price: 2, Right: &rbtree.Node{Price: 1, Left: &rbtree.Node{Price: 3}}}}},
+			want: &Tree{Root: &Node{Key: 10, Right: &Node{Key: 2, Right: &Node{Key: 1, Left: &Node{Key: 3}}}}},
 		},
 		{
 			name: "left_child_multiple_subtrees",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Left = &rbtree.Node{Price: 2, // y
-					Left: &rbtree.Node{Price: 1, // x
-						Left:  &rbtree.Node{Price: 5},  // a
-						Right: &rbtree.Node{Price: 4}}, // _b
-					Right: &rbtree.Node{Price: 3}, // _y
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Left = &Node{Key: 2, // y
+					Left: &Node{Key: 1, // x
+						Left:  &Node{Key: 5},  // a
+						Right: &Node{Key: 4}}, // _b
+					Right: &Node{Key: 3}, // _y
 				}
 				tr.Root.Left.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Left
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10,
-				Left: &rbtree.Node{Price: 1, // x
-					Left: &rbtree.Node{Price: 5}, // a
-					Right: &rbtree.Node{Price: 2, // y
-						Left:  &rbtree.Node{Price: 4},  // _b
-						Right: &rbtree.Node{Price: 3}}, // _y
+			want: &Tree{Root: &Node{Key: 10,
+				Left: &Node{Key: 1, // x
+					Left: &Node{Key: 5}, // a
+					Right: &Node{Key: 2, // y
+						Left:  &Node{Key: 4},  // _b
+						Right: &Node{Key: 3}}, // _y
 				}}},
 		},
 		{
 			name: "right_child_multiple_subtrees",
-			tree: func() *rbtree.Tree {
-				tr := &rbtree.Tree{}
-				tr.Root = &rbtree.Node{Price: 10}
-				tr.Root.Right = &rbtree.Node{Price: 2, // y
-					Left: &rbtree.Node{Price: 1, // x
-						Left:  &rbtree.Node{Price: 5},  // a
-						Right: &rbtree.Node{Price: 4}}, // _b
-					Right: &rbtree.Node{Price: 3}, // _y
+			tree: func() *Tree {
+				tr := &Tree{}
+				tr.Root = &Node{Key: 10}
+				tr.Root.Right = &Node{Key: 2, // y
+					Left: &Node{Key: 1, // x
+						Left:  &Node{Key: 5},  // a
+						Right: &Node{Key: 4}}, // _b
+					Right: &Node{Key: 3}, // _y
 				}
 				tr.Root.Right.Parent = tr.Root
 				return tr
 			},
-			rotate: func(tree *rbtree.Tree) *rbtree.Node {
+			rotate: func(tree *Tree) *Node {
 				return tree.Root.Right
 			},
-			want: &rbtree.Tree{Root: &rbtree.Node{Price: 10, Right: &rbtree.Node{Price: 1, // x
-				Left: &rbtree.Node{Price: 5}, // a
-				Right: &rbtree.Node{Price: 2, // y
-					Left:  &rbtree.Node{Price: 4},  // _b
-					Right: &rbtree.Node{Price: 3}}, // _y
+			want: &Tree{Root: &Node{Key: 10, Right: &Node{Key: 1, // x
+				Left: &Node{Key: 5}, // a
+				Right: &Node{Key: 2, // y
+					Left:  &Node{Key: 4},  // _b
+					Right: &Node{Key: 3}}, // _y
 			}}},
 		},
 	}
@@ -7,43 +7,136 @@ import (
 // Min returns the tree's minimum node
 //
 // O(log n)
-func (t *Tree) Min() *Node {
+func (t *Tree[K, V]) Min() *Node[K, V] {
 	return t.min(t.Root)
 }
 
 // Max returns the tree's maximum node
 //
 // O(log n)
-func (t *Tree) Max() *Node {
+func (t *Tree[K, V]) Max() *Node[K, V] {
 	return t.max(t.Root)
 }
 
 // Head returns the minimum or maximum node depending on the tree's orientation.
 //
 // O(1)
-func (t *Tree) Head() *Node {
+func (t *Tree[K, V]) Head() *Node[K, V] {
 	return t.head
 }
 
 // Orientation returns whether this tree is keeping track of the minimum or
 // the maximum node.
-func (t *Tree) Orientation() TreeOrientation {
+func (t *Tree[K, V]) Orientation() TreeOrientation {
 	return t.orientation
 }
 
-// Get returns the node containing the requested price if it exists
+// Get returns the node containing the requested key if it exists
 //
 // O(log n)
-func (t *Tree) Get(price uint64) (*Node, error) {
-	node, _, _ := t.find(price)
+func (t *Tree[K, V]) Get(key K) (*Node[K, V], error) {
+	node, _, _ := t.find(key)
 	if node == nil {
-		return nil, fmt.Errorf("price %v does not exist in tree", price)
+		return nil, fmt.Errorf("key %v does not exist in tree", key)
 	}
 
 	return node, nil
 }
 
-func (t *Tree) min(min *Node) *Node {
+// Floor returns the node with the greatest key less than or equal to the
+// requested key, or nil if there is none.
+//
+// O(log n)
+func (t *Tree[K, V]) Floor(key K) *Node[K, V] {
+	var floor *Node[K, V]
+	for n := t.Root; n != nil; {
+		if n.Key == key {
+			return n
+		}
+
+		if n.Key < key {
+			floor = n
+			n = n.Right
+		} else {
+			n = n.Left
+		}
+	}
+
+	return floor
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to
+// the requested key, or nil if there is none.
+//
+// O(log n)
+func (t *Tree[K, V]) Ceiling(key K) *Node[K, V] {
+	var ceiling *Node[K, V]
+	for n := t.Root; n != nil; {
+		if n.Key == key {
+			return n
+		}
+
+		if n.Key > key {
+			ceiling = n
+			n = n.Left
+		} else {
+			n = n.Right
+		}
+	}
+
+	return ceiling
+}
+
+// Len returns the number of nodes in the tree.
+//
+// O(1)
+func (t *Tree[K, V]) Len() int {
+	return t.Root.size()
+}
+
+// Rank returns the number of nodes with a key strictly less than the
+// requested key, which is the position the key has, or would have, in
+// ascending order.
+//
+// O(log n)
+func (t *Tree[K, V]) Rank(key K) int {
+	rank := 0
+	for n := t.Root; n != nil; {
+		if n.Key < key {
+			rank += n.Left.size() + 1
+			n = n.Right
+		} else {
+			n = n.Left
+		}
+	}
+
+	return rank
+}
+
+// Count returns the number of nodes with keys between lo and hi, both
+// included.
+//
+// O(log n)
+func (t *Tree[K, V]) Count(lo K, hi K) int {
+	if lo > hi {
+		return 0
+	}
+
+	// The nodes less than or equal to hi, minus the ones strictly less than lo.
+	count := -t.Rank(lo)
+	for n := t.Root; n != nil; {
+		if n.Key <= hi {
+			count += n.Left.size() + 1
+			n = n.Right
+		} else {
+			n = n.Left
+		}
+	}
+
+	return count
+}
+
+func (t *Tree[K, V]) min(min *Node[K, V]) *Node[K, V] {
 	if min == nil {
 		return nil
 	}
@@ -56,7 +149,7 @@ func (t *Tree) min(min *Node) *Node {
 	}
 }
 
-func (t *Tree) max(max *Node) *Node {
+func (t *Tree[K, V]) max(max *Node[K, V]) *Node[K, V] {
 	if max == nil {
 		return nil
 	}
@@ -69,30 +162,30 @@ func (t *Tree) max(max *Node) *Node {
 	}
 }
 
-// Find receives the target price and returns a reference to the node that
-// holds said price if it exists, whether said node is the left or right child,
+// Find receives the target key and returns a reference to the node that
+// holds said key if it exists, whether said node is the left or right child,
 // and a reference to its parent. This information can be used to insert a new
 // node immediately if needed.
 //
 // If the holder is nil, then the searched element is not in the tree.
 // If the parent is nil, then the returned element is the root.
-func (t *Tree) find(price uint64) (holder *Node, side ChildSide, parent *Node) {
+func (t *Tree[K, V]) find(key K) (holder *Node[K, V], side ChildSide, parent *Node[K, V]) {
 	if t.Root == nil {
 		return nil, LeftChild, nil
 	}
 
-	if t.Root.Price == price {
+	if t.Root.Key == key {
 		return t.Root, LeftChild, nil
 	}
 
 	parent = t.Root
 	for {
-		if price > parent.Price {
+		if key > parent.Key {
 			if parent.Right == nil {
 				return nil, RightChild, parent
 			}
 
-			if parent.Right.Price == price {
+			if parent.Right.Key == key {
 				return parent.Right, RightChild, parent
 			}
 
@@ -102,7 +195,7 @@ func (t *Tree) find(price uint64) (holder *Node, side ChildSide, parent *Node) {
 				return nil, LeftChild, parent
 			}
 
-			if parent.Left.Price == price {
+			if parent.Left.Key == key {
 				return parent.Left, LeftChild, parent
 			}
 
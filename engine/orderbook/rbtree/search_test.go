@@ -1,33 +1,32 @@
 package rbtree_test
 
 import (
-	"exchange/engine/orderbook/rbtree"
 	"testing"
 )
 
 func Test_Min(t *testing.T) {
 	testCases := []struct {
 		name       string
-		tree       *rbtree.Tree
+		tree       *Tree
 		want       uint64
 		wantExists bool
 	}{
 		{
 			name: "empty",
-			tree: &rbtree.Tree{},
+			tree: &Tree{},
 		},
 		{
 			name:       "root",
-			tree:       &rbtree.Tree{Root: &rbtree.Node{Price: 41}},
+			tree:       &Tree{Root: &Node{Key: 41}},
 			want:       41,
 			wantExists: true,
 		},
 		{
 			name: "root_with_right",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1, Red: true},
 				},
 			},
 			want:       1,
@@ -35,10 +34,10 @@ func Test_Min(t *testing.T) {
 		},
 		{
 			name: "root_with_left",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Right: &rbtree.Node{Price: 2, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:   1,
+					Right: &Node{Key: 2, Red: true},
 				},
 			},
 			want:       1,
@@ -46,18 +45,18 @@ func Test_Min(t *testing.T) {
 		},
 		{
 			name: "one_level",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 4,
-					Left: &rbtree.Node{
-						Price: 2,
-						Left:  &rbtree.Node{Price: 1},
-						Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 4,
+					Left: &Node{
+						Key:   2,
+						Left:  &Node{Key: 1},
+						Right: &Node{Key: 3},
 					},
-					Right: &rbtree.Node{
-						Price: 6,
-						Left:  &rbtree.Node{Price: 5},
-						Right: &rbtree.Node{Price: 7},
+					Right: &Node{
+						Key:   6,
+						Left:  &Node{Key: 5},
+						Right: &Node{Key: 7},
 					},
 				},
 			},
@@ -66,33 +65,33 @@ func Test_Min(t *testing.T) {
 		},
 		{
 			name: "two_levels",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 8,
-					Left: &rbtree.Node{
-						Price: 4,
-						Left: &rbtree.Node{
-							Price: 2,
-							Left:  &rbtree.Node{Price: 1},
-							Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 8,
+					Left: &Node{
+						Key: 4,
+						Left: &Node{
+							Key:   2,
+							Left:  &Node{Key: 1},
+							Right: &Node{Key: 3},
 						},
-						Right: &rbtree.Node{
-							Price: 6,
-							Left:  &rbtree.Node{Price: 5},
-							Right: &rbtree.Node{Price: 7},
+						Right: &Node{
+							Key:   6,
+							Left:  &Node{Key: 5},
+							Right: &Node{Key: 7},
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 12,
-						Left: &rbtree.Node{
-							Price: 10,
-							Left:  &rbtree.Node{Price: 9},
-							Right: &rbtree.Node{Price: 11},
+					Right: &Node{
+						Key: 12,
+						Left: &Node{
+							Key:   10,
+							Left:  &Node{Key: 9},
+							Right: &Node{Key: 11},
 						},
-						Right: &rbtree.Node{
-							Price: 14,
-							Left:  &rbtree.Node{Price: 13},
-							Right: &rbtree.Node{Price: 15},
+						Right: &Node{
+							Key:   14,
+							Left:  &Node{Key: 13},
+							Right: &Node{Key: 15},
 						},
 					},
 				},
@@ -116,7 +115,7 @@ func Test_Min(t *testing.T) {
 					t.Fatalf("Min() expected the node NOT to exist, but it does: %v", got)
 				}
 
-				if tc.want != got.Price {
+				if tc.want != got.Key {
 					t.Errorf("Min(), want price: %v, got node: %+v", tc.want, got)
 				}
 			} else if tc.wantExists {
@@ -129,26 +128,26 @@ func Test_Min(t *testing.T) {
 func Test_Max(t *testing.T) {
 	testCases := []struct {
 		name       string
-		tree       *rbtree.Tree
+		tree       *Tree
 		want       uint64
 		wantExists bool
 	}{
 		{
 			name: "empty",
-			tree: &rbtree.Tree{},
+			tree: &Tree{},
 		},
 		{
 			name:       "root",
-			tree:       &rbtree.Tree{Root: &rbtree.Node{Price: 41}},
+			tree:       &Tree{Root: &Node{Key: 41}},
 			want:       41,
 			wantExists: true,
 		},
 		{
 			name: "root_with_right",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1, Red: true},
 				},
 			},
 			want:       2,
@@ -156,10 +155,10 @@ func Test_Max(t *testing.T) {
 		},
 		{
 			name: "root_with_left",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Right: &rbtree.Node{Price: 2, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:   1,
+					Right: &Node{Key: 2, Red: true},
 				},
 			},
 			want:       2,
@@ -167,18 +166,18 @@ func Test_Max(t *testing.T) {
 		},
 		{
 			name: "one_level",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 4,
-					Left: &rbtree.Node{
-						Price: 2,
-						Left:  &rbtree.Node{Price: 1},
-						Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 4,
+					Left: &Node{
+						Key:   2,
+						Left:  &Node{Key: 1},
+						Right: &Node{Key: 3},
 					},
-					Right: &rbtree.Node{
-						Price: 6,
-						Left:  &rbtree.Node{Price: 5},
-						Right: &rbtree.Node{Price: 7},
+					Right: &Node{
+						Key:   6,
+						Left:  &Node{Key: 5},
+						Right: &Node{Key: 7},
 					},
 				},
 			},
@@ -187,33 +186,33 @@ func Test_Max(t *testing.T) {
 		},
 		{
 			name: "two_levels",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 8,
-					Left: &rbtree.Node{
-						Price: 4,
-						Left: &rbtree.Node{
-							Price: 2,
-							Left:  &rbtree.Node{Price: 1},
-							Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 8,
+					Left: &Node{
+						Key: 4,
+						Left: &Node{
+							Key:   2,
+							Left:  &Node{Key: 1},
+							Right: &Node{Key: 3},
 						},
-						Right: &rbtree.Node{
-							Price: 6,
-							Left:  &rbtree.Node{Price: 5},
-							Right: &rbtree.Node{Price: 7},
+						Right: &Node{
+							Key:   6,
+							Left:  &Node{Key: 5},
+							Right: &Node{Key: 7},
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 12,
-						Left: &rbtree.Node{
-							Price: 10,
-							Left:  &rbtree.Node{Price: 9},
-							Right: &rbtree.Node{Price: 11},
+					Right: &Node{
+						Key: 12,
+						Left: &Node{
+							Key:   10,
+							Left:  &Node{Key: 9},
+							Right: &Node{Key: 11},
 						},
-						Right: &rbtree.Node{
-							Price: 14,
-							Left:  &rbtree.Node{Price: 13},
-							Right: &rbtree.Node{Price: 15},
+						Right: &Node{
+							Key:   14,
+							Left:  &Node{Key: 13},
+							Right: &Node{Key: 15},
 						},
 					},
 				},
@@ -237,7 +236,7 @@ func Test_Max(t *testing.T) {
 					t.Fatalf("Max() expected the node NOT to exist, but it does: %v", got)
 				}
 
-				if tc.want != got.Price {
+				if tc.want != got.Key {
 					t.Errorf("Max(), want price: %v, got node: %+v", tc.want, got)
 				}
 			} else if tc.wantExists {
@@ -250,43 +249,43 @@ func Test_Max(t *testing.T) {
 func Test_Get(t *testing.T) {
 	testCases := []struct {
 		name    string
-		tree    *rbtree.Tree
+		tree    *Tree
 		price   uint64
 		wantErr bool
 	}{
 		{
 			name:    "empty",
-			tree:    &rbtree.Tree{},
+			tree:    &Tree{},
 			price:   1,
 			wantErr: true,
 		},
 		{
 			name:  "root_exists",
-			tree:  &rbtree.Tree{Root: &rbtree.Node{Price: 41}},
+			tree:  &Tree{Root: &Node{Key: 41}},
 			price: 41,
 		},
 		{
 			name:    "root_unknown",
-			tree:    &rbtree.Tree{Root: &rbtree.Node{Price: 41}},
+			tree:    &Tree{Root: &Node{Key: 41}},
 			price:   2,
 			wantErr: true,
 		},
 		{
 			name: "root_with_right_exists",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1, Red: true},
 				},
 			},
 			price: 1,
 		},
 		{
 			name: "root_with_right_unknown",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1, Red: true},
 				},
 			},
 			price:   41,
@@ -294,20 +293,20 @@ func Test_Get(t *testing.T) {
 		},
 		{
 			name: "root_with_left_exists",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Right: &rbtree.Node{Price: 2, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:   1,
+					Right: &Node{Key: 2, Red: true},
 				},
 			},
 			price: 2,
 		},
 		{
 			name: "root_with_left_unknown",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Right: &rbtree.Node{Price: 2, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:   1,
+					Right: &Node{Key: 2, Red: true},
 				},
 			},
 			price:   41,
@@ -315,18 +314,18 @@ func Test_Get(t *testing.T) {
 		},
 		{
 			name: "one_level_exists",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 4,
-					Left: &rbtree.Node{
-						Price: 2,
-						Left:  &rbtree.Node{Price: 1},
-						Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 4,
+					Left: &Node{
+						Key:   2,
+						Left:  &Node{Key: 1},
+						Right: &Node{Key: 3},
 					},
-					Right: &rbtree.Node{
-						Price: 6,
-						Left:  &rbtree.Node{Price: 5},
-						Right: &rbtree.Node{Price: 7},
+					Right: &Node{
+						Key:   6,
+						Left:  &Node{Key: 5},
+						Right: &Node{Key: 7},
 					},
 				},
 			},
@@ -334,18 +333,18 @@ func Test_Get(t *testing.T) {
 		},
 		{
 			name: "one_level_unknown",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 4,
-					Left: &rbtree.Node{
-						Price: 2,
-						Left:  &rbtree.Node{Price: 1},
-						Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 4,
+					Left: &Node{
+						Key:   2,
+						Left:  &Node{Key: 1},
+						Right: &Node{Key: 3},
 					},
-					Right: &rbtree.Node{
-						Price: 6,
-						Left:  &rbtree.Node{Price: 5},
-						Right: &rbtree.Node{Price: 7},
+					Right: &Node{
+						Key:   6,
+						Left:  &Node{Key: 5},
+						Right: &Node{Key: 7},
 					},
 				},
 			},
@@ -354,33 +353,33 @@ func Test_Get(t *testing.T) {
 		},
 		{
 			name: "two_levels_exists",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 8,
-					Left: &rbtree.Node{
-						Price: 4,
-						Left: &rbtree.Node{
-							Price: 2,
-							Left:  &rbtree.Node{Price: 1},
-							Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 8,
+					Left: &Node{
+						Key: 4,
+						Left: &Node{
+							Key:   2,
+							Left:  &Node{Key: 1},
+							Right: &Node{Key: 3},
 						},
-						Right: &rbtree.Node{
-							Price: 6,
-							Left:  &rbtree.Node{Price: 5},
-							Right: &rbtree.Node{Price: 7},
+						Right: &Node{
+							Key:   6,
+							Left:  &Node{Key: 5},
+							Right: &Node{Key: 7},
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 12,
-						Left: &rbtree.Node{
-							Price: 10,
-							Left:  &rbtree.Node{Price: 9},
-							Right: &rbtree.Node{Price: 11},
+					Right: &Node{
+						Key: 12,
+						Left: &Node{
+							Key:   10,
+							Left:  &Node{Key: 9},
+							Right: &Node{Key: 11},
 						},
-						Right: &rbtree.Node{
-							Price: 14,
-							Left:  &rbtree.Node{Price: 13},
-							Right: &rbtree.Node{Price: 15},
+						Right: &Node{
+							Key:   14,
+							Left:  &Node{Key: 13},
+							Right: &Node{Key: 15},
 						},
 					},
 				},
@@ -389,33 +388,33 @@ func Test_Get(t *testing.T) {
 		},
 		{
 			name: "two_levels_unknown",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 8,
-					Left: &rbtree.Node{
-						Price: 4,
-						Left: &rbtree.Node{
-							Price: 2,
-							Left:  &rbtree.Node{Price: 1},
-							Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key: 8,
+					Left: &Node{
+						Key: 4,
+						Left: &Node{
+							Key:   2,
+							Left:  &Node{Key: 1},
+							Right: &Node{Key: 3},
 						},
-						Right: &rbtree.Node{
-							Price: 6,
-							Left:  &rbtree.Node{Price: 5},
-							Right: &rbtree.Node{Price: 7},
+						Right: &Node{
+							Key:   6,
+							Left:  &Node{Key: 5},
+							Right: &Node{Key: 7},
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 12,
-						Left: &rbtree.Node{
-							Price: 10,
-							Left:  &rbtree.Node{Price: 9},
-							Right: &rbtree.Node{Price: 11},
+					Right: &Node{
+						Key: 12,
+						Left: &Node{
+							Key:   10,
+							Left:  &Node{Key: 9},
+							Right: &Node{Key: 11},
 						},
-						Right: &rbtree.Node{
-							Price: 14,
-							Left:  &rbtree.Node{Price: 13},
-							Right: &rbtree.Node{Price: 15},
+						Right: &Node{
+							Key:   14,
+							Left:  &Node{Key: 13},
+							Right: &Node{Key: 15},
 						},
 					},
 				},
@@ -439,8 +438,8 @@ func Test_Get(t *testing.T) {
 					t.Fatalf("Get(%v) expected error, got OK: %v", tc.price, got)
 				}
 
-				if got.Price != tc.price {
-					t.Fatalf("Get(%v) got %v: %+v", tc.price, got.Price, got)
+				if got.Key != tc.price {
+					t.Fatalf("Get(%v) got %v: %+v", tc.price, got.Key, got)
 				}
 			} else {
 				if !tc.wantErr {
@@ -2,6 +2,7 @@ package rbtree
 
 import (
 	"bytes"
+	"cmp"
 	"sync"
 )
 
@@ -17,24 +18,27 @@ const (
 // 1 - root must always be black
 // 2 - a red node must have a black parent and black children
 // 3 - every path from root to nils passes << exactly >> the same number of black nodes
-type Tree struct {
+//
+// Nodes are ordered by keys of type K, and hold a value of type V.
+type Tree[K cmp.Ordered, V any] struct {
 	// The root of the tree
-	Root *Node
+	Root *Node[K, V]
 
-	// A pool to optimize memory allocations for nodes
+	// A pool to optimize memory allocations for nodes. It must create nodes of
+	// type *Node[K, V].
 	pool *sync.Pool
 
 	// Whether to point the head of the tree to its minimum or maximum node.
 	orientation TreeOrientation
 
-	// A pointer to the node with the minimum or maximum price depending on the
+	// A pointer to the node with the minimum or maximum key depending on the
 	// tree's orientation.
 	// Reads in O(1). Refreshes in O(log n)
-	head *Node
+	head *Node[K, V]
 }
 
 // PrintTree prints the tree in a string that can be easily visualized in the console
-func (t *Tree) PrintTree() string {
+func (t *Tree[K, V]) PrintTree() string {
 
 	if t.Root != nil {
 		var buffer bytes.Buffer
@@ -45,22 +49,22 @@ func (t *Tree) PrintTree() string {
 	return "nil"
 }
 
-func NewTree(orientation TreeOrientation, pool *sync.Pool) *Tree {
-	t := &Tree{
+func NewTree[K cmp.Ordered, V any](orientation TreeOrientation, pool *sync.Pool) *Tree[K, V] {
+	t := &Tree[K, V]{
 		orientation: orientation,
 		pool:        pool,
 	}
 	return t
 }
 
-func (t *Tree) getNode() *Node {
-	n := t.pool.Get().(*Node)
+func (t *Tree[K, V]) getNode() *Node[K, V] {
+	n := t.pool.Get().(*Node[K, V])
 	// Reset the node in case it was not clean
 	n.Reset()
 	return n
 }
 
-func (t *Tree) putNode(n *Node) {
+func (t *Tree[K, V]) putNode(n *Node[K, V]) {
 	// Reset the node to push a clean object
 	n.Reset()
 	t.pool.Put(n)
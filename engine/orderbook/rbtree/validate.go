@@ -8,7 +8,7 @@ import (
 // explanation of the reason why it is invalid. This function should only be used
 // in tests, or to debug errors if they arise. The Red-Black tree implementation
 // should never reach an invalid state.
-func (t *Tree) Valid() error {
+func (t *Tree[K, V]) Valid() error {
 	if t == nil {
 		return fmt.Errorf("invalid tree: tree is a null pointer")
 	}
@@ -21,8 +21,8 @@ func (t *Tree) Valid() error {
 		return fmt.Errorf("invalid tree: root must be black")
 	}
 
-	visited := map[*Node]bool{}
-	stack := []*Node{t.Root}
+	visited := map[*Node[K, V]]bool{}
+	stack := []*Node[K, V]{t.Root}
 	countStack := []int{0}
 	blackPathLength := -1
 
@@ -46,22 +46,22 @@ func (t *Tree) Valid() error {
 				if node.Parent.Right == node {
 					side = "R"
 				}
-				return fmt.Errorf("invalid tree: two directly related red nodes: %d -%s-> %d", node.Parent.Price, side, node.Price)
+				return fmt.Errorf("invalid tree: two directly related red nodes: %v -%s-> %v", node.Parent.Key, side, node.Key)
 			}
 
 			if node.Parent != nil {
-				if node == node.Parent.Left && node.Price >= node.Parent.Price {
-					return fmt.Errorf("invalid tree: left child is not less than: %d -> %d", node.Parent.Price, node.Price)
+				if node == node.Parent.Left && node.Key >= node.Parent.Key {
+					return fmt.Errorf("invalid tree: left child is not less than: %v -> %v", node.Parent.Key, node.Key)
 				}
 
-				if node == node.Parent.Right && node.Price <= node.Parent.Price {
-					return fmt.Errorf("invalid tree: right child is not bigger than: %d -> %d", node.Parent.Price, node.Price)
+				if node == node.Parent.Right && node.Key <= node.Parent.Key {
+					return fmt.Errorf("invalid tree: right child is not bigger than: %v -> %v", node.Parent.Key, node.Key)
 				}
 			}
 
 			if node.Right != nil { // right first, so that it's processed last
 				if node.Right.Parent != node {
-					return fmt.Errorf("invalid tree: right child not referencing parent: %d <- %d", node.Price, node.Right.Price)
+					return fmt.Errorf("invalid tree: right child not referencing parent: %v <- %v", node.Key, node.Right.Key)
 				}
 
 				stack = append(stack, node.Right)
@@ -75,7 +75,7 @@ func (t *Tree) Valid() error {
 
 			if node.Left != nil {
 				if node.Left.Parent != node {
-					return fmt.Errorf("invalid tree: left child not referencing parent: %d <- %d", node.Price, node.Right.Price)
+					return fmt.Errorf("invalid tree: left child not referencing parent: %v <- %v", node.Key, node.Right.Key)
 				}
 
 				stack = append(stack, node.Left)
@@ -91,7 +91,7 @@ func (t *Tree) Valid() error {
 				if blackPathLength == -1 {
 					blackPathLength = blackCount
 				} else if blackCount != blackPathLength {
-					return fmt.Errorf("invalid tree: unequal black counts, want %d, got %d, from root to %d", blackCount, blackPathLength, node.Price)
+					return fmt.Errorf("invalid tree: unequal black counts, want %v, got %v, from root to %v", blackCount, blackPathLength, node.Key)
 				}
 			}
 		}
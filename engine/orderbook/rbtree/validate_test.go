@@ -1,14 +1,13 @@
 package rbtree_test
 
 import (
-	"exchange/engine/orderbook/rbtree"
 	"testing"
 )
 
 func Test_Valid(t *testing.T) {
 	testCases := []struct {
 		name   string
-		tree   *rbtree.Tree
+		tree   *Tree
 		wantOk bool
 	}{
 		{
@@ -16,153 +15,153 @@ func Test_Valid(t *testing.T) {
 		},
 		{
 			name:   "empty",
-			tree:   &rbtree.Tree{},
+			tree:   &Tree{},
 			wantOk: true,
 		},
 		{
 			name: "red_root",
-			tree: &rbtree.Tree{Root: &rbtree.Node{Price: 1, Red: true}},
+			tree: &Tree{Root: &Node{Key: 1, Red: true}},
 		},
 		{
 			name: "larger_left",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Left:  &rbtree.Node{Price: 2},
+			tree: &Tree{
+				Root: &Node{
+					Key:  1,
+					Left: &Node{Key: 2},
 				},
 			},
 		},
 		{
 			name: "smaller_right",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Right: &rbtree.Node{Price: 1},
+			tree: &Tree{
+				Root: &Node{
+					Key:   2,
+					Right: &Node{Key: 1},
 				},
 			},
 		},
 		{
 			name: "equal_left",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Left:  &rbtree.Node{Price: 1},
+			tree: &Tree{
+				Root: &Node{
+					Key:  1,
+					Left: &Node{Key: 1},
 				},
 			},
 		},
 		{
 			name: "equal_right",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 1,
-					Right: &rbtree.Node{Price: 1},
+			tree: &Tree{
+				Root: &Node{
+					Key:   1,
+					Right: &Node{Key: 1},
 				},
 			},
 		},
 		{
 			name: "non_root_larger_left",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 99}, // fail
-					Right: &rbtree.Node{
-						Price: 4,
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 99}, // fail
+					Right: &Node{
+						Key:   4,
 						Red:   true,
-						Left:  &rbtree.Node{Price: 3},
-						Right: &rbtree.Node{Price: 5},
+						Left:  &Node{Key: 3},
+						Right: &Node{Key: 5},
 					},
 				},
 			},
 		},
 		{
 			name: "non_root_smaller_right",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:   4,
 						Red:   true,
-						Left:  &rbtree.Node{Price: 3},
-						Right: &rbtree.Node{Price: 0}, // fail
+						Left:  &Node{Key: 3},
+						Right: &Node{Key: 0}, // fail
 					},
 				},
 			},
 		},
 		{
 			name: "red_node_red_left",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:   4,
 						Red:   true,
-						Left:  &rbtree.Node{Price: 3, Red: true}, // fail
-						Right: &rbtree.Node{Price: 5},
+						Left:  &Node{Key: 3, Red: true}, // fail
+						Right: &Node{Key: 5},
 					},
 				},
 			},
 		},
 		{
 			name: "red_node_red_right",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:   4,
 						Red:   true,
-						Left:  &rbtree.Node{Price: 3},
-						Right: &rbtree.Node{Price: 5, Red: true}, // fail
+						Left:  &Node{Key: 3},
+						Right: &Node{Key: 5, Red: true}, // fail
 					},
 				},
 			},
 		},
 		{
 			name: "two_levels",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{Price: 3},
+			tree: &Tree{
+				Root: &Node{
+					Key:   2,
+					Left:  &Node{Key: 1},
+					Right: &Node{Key: 3},
 				},
 			},
 			wantOk: true,
 		},
 		{
 			name: "two_levels_with_red",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1, Red: true},
-					Right: &rbtree.Node{Price: 3, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:   2,
+					Left:  &Node{Key: 1, Red: true},
+					Right: &Node{Key: 3, Red: true},
 				},
 			},
 			wantOk: true,
 		},
 		{
 			name: "unequal_black_counts",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
-						Left:  &rbtree.Node{Price: 3}, // should be red
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:  4,
+						Left: &Node{Key: 3}, // should be red
 					},
 				},
 			},
 		},
 		{
 			name: "three_levels_one_red",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
-						Left:  &rbtree.Node{Price: 3, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:  4,
+						Left: &Node{Key: 3, Red: true},
 					},
 				},
 			},
@@ -170,14 +169,14 @@ func Test_Valid(t *testing.T) {
 		},
 		{
 			name: "three_levels_two_reds",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
-						Left:  &rbtree.Node{Price: 3, Red: true},
-						Right: &rbtree.Node{Price: 5, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:   4,
+						Left:  &Node{Key: 3, Red: true},
+						Right: &Node{Key: 5, Red: true},
 					},
 				},
 			},
@@ -185,15 +184,15 @@ func Test_Valid(t *testing.T) {
 		},
 		{
 			name: "three_levels_one_middle_red",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 2,
-					Left:  &rbtree.Node{Price: 1},
-					Right: &rbtree.Node{
-						Price: 4,
+			tree: &Tree{
+				Root: &Node{
+					Key:  2,
+					Left: &Node{Key: 1},
+					Right: &Node{
+						Key:   4,
 						Red:   true,
-						Left:  &rbtree.Node{Price: 3},
-						Right: &rbtree.Node{Price: 5},
+						Left:  &Node{Key: 3},
+						Right: &Node{Key: 5},
 					},
 				},
 			},
@@ -201,25 +200,25 @@ func Test_Valid(t *testing.T) {
 		},
 		{
 			name: "four_levels_balanced",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 16,
-					Left: &rbtree.Node{
-						Price: 8,
-						Red:   true,
-						Left:  &rbtree.Node{Price: 3},
-						Right: &rbtree.Node{
-							Price: 9,
-							Right: &rbtree.Node{Price: 10, Red: true},
+			tree: &Tree{
+				Root: &Node{
+					Key: 16,
+					Left: &Node{
+						Key:  8,
+						Red:  true,
+						Left: &Node{Key: 3},
+						Right: &Node{
+							Key:   9,
+							Right: &Node{Key: 10, Red: true},
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 21,
-						Red:   true,
-						Left:  &rbtree.Node{Price: 18},
-						Right: &rbtree.Node{
-							Price: 27,
-							Right: &rbtree.Node{Price: 29, Red: true},
+					Right: &Node{
+						Key:  21,
+						Red:  true,
+						Left: &Node{Key: 18},
+						Right: &Node{
+							Key:   27,
+							Right: &Node{Key: 29, Red: true},
 						},
 					},
 				},
@@ -229,38 +228,38 @@ func Test_Valid(t *testing.T) {
 		{
 			// https://en.wikipedia.org/wiki/Red%E2%80%93black_tree
 			name: "four_levels_example",
-			tree: &rbtree.Tree{
-				Root: &rbtree.Node{
-					Price: 13,
-					Left: &rbtree.Node{
-						Price: 8,
-						Red:   true,
-						Left: &rbtree.Node{
-							Price: 1,
-							Right: &rbtree.Node{
-								Price: 6,
-								Red:   true,
+			tree: &Tree{
+				Root: &Node{
+					Key: 13,
+					Left: &Node{
+						Key: 8,
+						Red: true,
+						Left: &Node{
+							Key: 1,
+							Right: &Node{
+								Key: 6,
+								Red: true,
 							},
 						},
-						Right: &rbtree.Node{
-							Price: 11,
+						Right: &Node{
+							Key: 11,
 						},
 					},
-					Right: &rbtree.Node{
-						Price: 17,
-						Red:   true,
-						Left: &rbtree.Node{
-							Price: 15,
+					Right: &Node{
+						Key: 17,
+						Red: true,
+						Left: &Node{
+							Key: 15,
 						},
-						Right: &rbtree.Node{
-							Price: 25,
-							Left: &rbtree.Node{
-								Price: 22,
-								Red:   true,
+						Right: &Node{
+							Key: 25,
+							Left: &Node{
+								Key: 22,
+								Red: true,
 							},
-							Right: &rbtree.Node{
-								Price: 27,
-								Red:   true,
+							Right: &Node{
+								Key: 27,
+								Red: true,
 							},
 						},
 					},
@@ -0,0 +1,24 @@
+package orderbook
+
+import (
+	"fmt"
+
+	"exchange/engine/order"
+)
+
+// Reduce lowers the volume left of a resting order, keeping its time priority.
+//
+// O(1) with both indexes.
+func (b *OrderBook) Reduce(o *order.Order, volume uint64) error {
+	level, err := b.level(o)
+	if err != nil {
+		return fmt.Errorf("OrderBook.Reduce(%q): %w", o.ID, err)
+	}
+
+	if err := level.Reduce(o.ID, volume); err != nil {
+		return fmt.Errorf("OrderBook.Reduce(%q): %w", o.ID, err)
+	}
+
+	b.volumeUpdateCallback(o.Price, level.Volume())
+	return nil
+}
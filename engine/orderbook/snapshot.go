@@ -4,9 +4,9 @@ package orderbook
 //
 // O(n)
 func (o *OrderBook) Snapshot() map[uint64]uint64 {
-	volumes := make(map[uint64]uint64, len(o.priceMap))
-	for price, priceNode := range o.priceMap {
-		volumes[price] = priceNode.Orders.Volume()
+	volumes := make(map[uint64]uint64, o.index.Len())
+	for price, level := range o.index.FromHead() {
+		volumes[price] = level.Volume()
 	}
 
 	return volumes
@@ -6,7 +6,6 @@ import (
 
 	"exchange/engine/order"
 	"exchange/engine/orderbook"
-	"exchange/engine/orderbook/rbtree"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -105,7 +104,7 @@ func Test_Insert_Snapshot(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(order.OrderBuy, pool, func(uint64, uint64) {})
@@ -289,7 +288,7 @@ func Test_Delete_Snapshot(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(order.OrderBuy, pool, func(uint64, uint64) {})
@@ -453,7 +452,7 @@ func Test_Match_Snapshot(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool := &sync.Pool{
 				New: func() any {
-					return rbtree.NewNode()
+					return orderbook.NewPriceNode()
 				},
 			}
 			b := orderbook.New(tc.side, pool, func(uint64, uint64) {})
@@ -464,7 +463,7 @@ func Test_Match_Snapshot(t *testing.T) {
 				}
 			}
 
-			b.MatchAndExtract(tc.matchVolume)
+			b.MatchAndExtract(tc.matchVolume, nil)
 
 			opts := cmp.Options{
 				cmpopts.EquateEmpty(),
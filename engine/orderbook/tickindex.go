@@ -0,0 +1,321 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+)
+
+// The maximum number of ticks of a PriceRange, which bounds the memory of a
+// TickIndex to a few tens of megabytes per book.
+const maxTicks = 1 << 22
+
+var (
+	PriceOutOfRangeErr = errors.New("price is outside of the market range")
+	PriceOffTickErr    = errors.New("price is not a multiple of the tick size")
+)
+
+// PriceRange bounds the prices of a market to Min, Max and every multiple of
+// Tick in between.
+type PriceRange struct {
+	Min uint64
+
+	Max uint64
+
+	Tick uint64
+}
+
+// Valid returns an error if the range is empty, Max is not on the tick grid,
+// or it has more than maxTicks prices.
+func (r PriceRange) Valid() error {
+	if r.Tick == 0 {
+		return fmt.Errorf("invalid price range %+v: zero tick size", r)
+	}
+
+	if r.Min == 0 || r.Min > r.Max {
+		return fmt.Errorf("invalid price range %+v: empty range", r)
+	}
+
+	if (r.Max-r.Min)%r.Tick != 0 {
+		return fmt.Errorf("invalid price range %+v: maximum price is not on a tick", r)
+	}
+
+	if (r.Max-r.Min)/r.Tick >= maxTicks {
+		return fmt.Errorf("invalid price range %+v: more than %d ticks", r, maxTicks)
+	}
+
+	return nil
+}
+
+// Ticks returns the number of prices in the range.
+func (r PriceRange) Ticks() int {
+	return int((r.Max-r.Min)/r.Tick) + 1
+}
+
+// Check returns an error if the price is not one of the range.
+func (r PriceRange) Check(price uint64) error {
+	if price < r.Min || price > r.Max {
+		return fmt.Errorf("price %d, range [%d, %d]: %w", price, r.Min, r.Max, PriceOutOfRangeErr)
+	}
+
+	if (price-r.Min)%r.Tick != 0 {
+		return fmt.Errorf("price %d, tick %d: %w", price, r.Tick, PriceOffTickErr)
+	}
+
+	return nil
+}
+
+// TickIndex is a PriceIndex for markets with a bounded price range and a fixed
+// tick size. Levels are stored in an array indexed by tick, and a bitmap of the
+// existing levels is scanned to find the next best price.
+//
+// Reads and inserts are O(1). Deleting the head is O(d/4096), for a distance
+// of d ticks to the next level, which is small for dense books.
+type TickIndex struct {
+	prices PriceRange
+
+	// Whether the best price is the maximum, for buy-side books.
+	maxFirst bool
+
+	// The levels by tick. Levels are allocated on first use and kept when they
+	// become empty, so that later inserts at the same price do not allocate.
+	levels []*pricelevel.PriceLevel
+
+	// A bit per tick, set when its level is in the index.
+	bitmap []uint64
+
+	// A bit per word of the bitmap, set when the word is not zero, so that
+	// scans skip 4096 empty ticks at a time.
+	summary []uint64
+
+	// The tick of the best price, -1 if the index is empty.
+	head int
+
+	// The number of levels in the index.
+	len int
+}
+
+// NewTickIndex returns an empty index for the side.
+func NewTickIndex(side order.OrderSide, prices PriceRange) (*TickIndex, error) {
+	if err := prices.Valid(); err != nil {
+		return nil, err
+	}
+
+	ticks := prices.Ticks()
+	return &TickIndex{
+		prices:   prices,
+		maxFirst: side == order.OrderBuy,
+		levels:   make([]*pricelevel.PriceLevel, ticks),
+		bitmap:   make([]uint64, (ticks+63)/64),
+		summary:  make([]uint64, (ticks+64*64-1)/(64*64)),
+		head:     -1,
+	}, nil
+}
+
+func (t *TickIndex) price(tick int) uint64 {
+	return t.prices.Min + uint64(tick)*t.prices.Tick
+}
+
+// tick returns the tick of a price, or false if it is not in the range.
+func (t *TickIndex) tick(price uint64) (int, bool) {
+	if price < t.prices.Min || price > t.prices.Max || (price-t.prices.Min)%t.prices.Tick != 0 {
+		return 0, false
+	}
+
+	return int((price - t.prices.Min) / t.prices.Tick), true
+}
+
+func (t *TickIndex) isSet(tick int) bool {
+	return t.bitmap[tick/64]&(1<<(tick%64)) != 0
+}
+
+func (t *TickIndex) set(tick int) {
+	w := tick / 64
+	t.bitmap[w] |= 1 << (tick % 64)
+	t.summary[w/64] |= 1 << (w % 64)
+}
+
+func (t *TickIndex) clear(tick int) {
+	w := tick / 64
+	t.bitmap[w] &^= 1 << (tick % 64)
+	if t.bitmap[w] == 0 {
+		t.summary[w/64] &^= 1 << (w % 64)
+	}
+}
+
+// next returns the first set tick at or after the tick, or -1.
+func (t *TickIndex) next(tick int) int {
+	if tick >= len(t.levels) {
+		return -1
+	}
+
+	if word := t.bitmap[tick/64] &^ (1<<(tick%64) - 1); word != 0 {
+		return tick/64*64 + bits.TrailingZeros64(word)
+	}
+
+	w := nextSet(t.summary, tick/64+1)
+	if w == -1 {
+		return -1
+	}
+
+	return w*64 + bits.TrailingZeros64(t.bitmap[w])
+}
+
+// prev returns the last set tick at or before the tick, or -1.
+func (t *TickIndex) prev(tick int) int {
+	if tick < 0 {
+		return -1
+	}
+
+	word := t.bitmap[tick/64]
+	if shift := tick%64 + 1; shift < 64 {
+		word &= 1<<shift - 1
+	}
+	if word != 0 {
+		return tick/64*64 + bits.Len64(word) - 1
+	}
+
+	w := prevSet(t.summary, tick/64-1)
+	if w == -1 {
+		return -1
+	}
+
+	return w*64 + bits.Len64(t.bitmap[w]) - 1
+}
+
+// nextSet returns the first set bit of the words at or after the bit i, or -1.
+func nextSet(words []uint64, i int) int {
+	if i >= len(words)*64 {
+		return -1
+	}
+
+	w := i / 64
+	word := words[w] &^ (1<<(i%64) - 1)
+	for {
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word)
+		}
+
+		w++
+		if w == len(words) {
+			return -1
+		}
+		word = words[w]
+	}
+}
+
+// prevSet returns the last set bit of the words at or before the bit i, or -1.
+func prevSet(words []uint64, i int) int {
+	if i < 0 {
+		return -1
+	}
+
+	w := i / 64
+	word := words[w]
+	if shift := i%64 + 1; shift < 64 {
+		word &= 1<<shift - 1
+	}
+	for {
+		if word != 0 {
+			return w*64 + bits.Len64(word) - 1
+		}
+
+		w--
+		if w < 0 {
+			return -1
+		}
+		word = words[w]
+	}
+}
+
+// O(1)
+func (t *TickIndex) Get(price uint64) (*pricelevel.PriceLevel, bool) {
+	tick, ok := t.tick(price)
+	if !ok || !t.isSet(tick) {
+		return nil, false
+	}
+
+	return t.levels[tick], true
+}
+
+// O(1)
+func (t *TickIndex) Insert(price uint64) (*pricelevel.PriceLevel, error) {
+	tick, ok := t.tick(price)
+	if !ok {
+		return nil, t.prices.Check(price)
+	}
+
+	if t.isSet(tick) {
+		return t.levels[tick], nil
+	}
+
+	if t.levels[tick] == nil {
+		t.levels[tick] = pricelevel.New()
+	}
+
+	t.set(tick)
+	t.len++
+
+	if t.head == -1 || t.maxFirst && tick > t.head || !t.maxFirst && tick < t.head {
+		t.head = tick
+	}
+
+	return t.levels[tick], nil
+}
+
+// O(1), O(d/4096) to find the next head.
+func (t *TickIndex) Delete(price uint64) {
+	tick, ok := t.tick(price)
+	if !ok || !t.isSet(tick) {
+		return
+	}
+
+	t.clear(tick)
+	t.levels[tick].Reset()
+	t.len--
+
+	if t.len == 0 {
+		t.head = -1
+	} else if tick == t.head {
+		if t.maxFirst {
+			t.head = t.prev(tick - 1)
+		} else {
+			t.head = t.next(tick + 1)
+		}
+	}
+}
+
+// O(1)
+func (t *TickIndex) Head() (uint64, *pricelevel.PriceLevel, bool) {
+	if t.head == -1 {
+		return 0, nil, false
+	}
+
+	return t.price(t.head), t.levels[t.head], true
+}
+
+// O(n + d/4096) for a distance of d ticks between the first and last levels.
+func (t *TickIndex) FromHead() iter.Seq2[uint64, *pricelevel.PriceLevel] {
+	return func(yield func(uint64, *pricelevel.PriceLevel) bool) {
+		for tick := t.head; tick != -1; {
+			if !yield(t.price(tick), t.levels[tick]) {
+				return
+			}
+
+			if t.maxFirst {
+				tick = t.prev(tick - 1)
+			} else {
+				tick = t.next(tick + 1)
+			}
+		}
+	}
+}
+
+// O(1)
+func (t *TickIndex) Len() int {
+	return t.len
+}
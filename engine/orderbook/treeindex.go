@@ -0,0 +1,94 @@
+package orderbook
+
+import (
+	"iter"
+	"sync"
+
+	"exchange/engine/order"
+	"exchange/engine/orderbook/pricelevel"
+	"exchange/engine/orderbook/rbtree"
+)
+
+// TreeIndex is a PriceIndex for unbounded prices, backed by a red-black tree
+// and a map to its nodes.
+type TreeIndex struct {
+	// The binary search tree to optimize price discovery
+	// Searches in O(log n). Inserts in O(log n)
+	priceTree *rbtree.Tree[uint64, *pricelevel.PriceLevel]
+
+	// A map of price to tree node to know if a price exists
+	// Reads in O(1). Writes in O(1)
+	priceMap map[uint64]*PriceNode
+}
+
+// NewTreeIndex returns an empty index for the side. The pool must create
+// nodes with NewPriceNode, and may be shared by the books of a market.
+func NewTreeIndex(side order.OrderSide, nodePool *sync.Pool) *TreeIndex {
+	treeOrientation := rbtree.MinFirst
+	if side == order.OrderBuy {
+		treeOrientation = rbtree.MaxFirst
+	}
+
+	return &TreeIndex{
+		priceTree: rbtree.NewTree[uint64, *pricelevel.PriceLevel](treeOrientation, nodePool),
+		priceMap:  make(map[uint64]*PriceNode),
+	}
+}
+
+// O(1)
+func (t *TreeIndex) Get(price uint64) (*pricelevel.PriceLevel, bool) {
+	node, exists := t.priceMap[price]
+	if !exists {
+		return nil, false
+	}
+
+	return node.Value, true
+}
+
+// O(1) if the price exists, O(log n) otherwise.
+func (t *TreeIndex) Insert(price uint64) (*pricelevel.PriceLevel, error) {
+	node, exists := t.priceMap[price]
+	if !exists {
+		node = t.priceTree.Insert(price) // O(log n)
+		t.priceMap[price] = node
+	}
+
+	return node.Value, nil
+}
+
+// O(log n)
+func (t *TreeIndex) Delete(price uint64) {
+	node, exists := t.priceMap[price]
+	if !exists {
+		return
+	}
+
+	delete(t.priceMap, price)
+	t.priceTree.DeleteNode(node) // O(log n)
+}
+
+// O(1)
+func (t *TreeIndex) Head() (uint64, *pricelevel.PriceLevel, bool) {
+	head := t.priceTree.Head()
+	if head == nil {
+		return 0, nil, false
+	}
+
+	return head.Key, head.Value, true
+}
+
+// O(n), O(k) to stop after k levels.
+func (t *TreeIndex) FromHead() iter.Seq2[uint64, *pricelevel.PriceLevel] {
+	return func(yield func(uint64, *pricelevel.PriceLevel) bool) {
+		for node := range t.priceTree.FromHead() {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// O(1)
+func (t *TreeIndex) Len() int {
+	return len(t.priceMap)
+}
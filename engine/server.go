@@ -26,29 +26,36 @@ func killSignal() chan os.Signal {
 func main() {
 	fmt.Println("Welcome to the engine.")
 
-	markets := []engineserver.MarketSymbol{
-		{Base: "DOLS", Trade: "MEEM"},
-	}
-	engine, err := engineserver.NewEngine(markets)
+	markets := engineserver.Instruments()
+	engine, err := engineserver.NewEngine(markets, "snapshots")
 	if err != nil {
 		log.Fatalf("Error creating engine: %v\n", err)
 	}
 
 	defer engine.CloseKafka()
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
 	fmt.Println("Engine starting to stream messages...")
-	engine.Stream(ctx)
+	// The events are still produced while shutting down, so that the snapshots
+	// saved by Listen follow the events of every order request applied.
+	engine.Stream(context.Background())
 
 	errChan := make(chan error, 1)
 	go func() {
 		fmt.Println("Engine starting to listen for Kafka messages...")
-		if err := engine.Listen(ctx); err != nil {
-			errChan <- err
-		}
+		errChan <- engine.Listen(ctx)
 	}()
 
 	sigc := killSignal()
-	<-sigc
-	fmt.Println("Shutting down gracefully...")
+	select {
+	case <-sigc:
+		fmt.Println("Shutting down gracefully...")
+
+		// Listen saves the market snapshots before returning.
+		cancel()
+		<-errChan
+	case err := <-errChan:
+		cancel()
+		log.Printf("Error listening: %v\n", err)
+	}
 }
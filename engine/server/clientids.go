@@ -0,0 +1,95 @@
+package engineserver
+
+import (
+	"slices"
+	"time"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+// ClientOrderIDWindow is how long a client order ID identifies the first
+// order of its account created with it. Requests creating an order again with
+// the same client order ID within the window are dropped.
+const ClientOrderIDWindow = 24 * time.Hour
+
+// createTypes are the order request types creating orders.
+var createTypes = map[enginepb.OrderRequest_Type]bool{
+	enginepb.OrderRequest_LIMIT:         true,
+	enginepb.OrderRequest_MARKET:        true,
+	enginepb.OrderRequest_STOP:          true,
+	enginepb.OrderRequest_TRAILING_STOP: true,
+	enginepb.OrderRequest_PEGGED:        true,
+	enginepb.OrderRequest_OCO:           true,
+	enginepb.OrderRequest_BRACKET:       true,
+}
+
+// clientOrderID is a client order ID seen by a market, keyed by account.
+// Stored in the snapshots of the market.
+type clientOrderID struct {
+	Key string
+	At  time.Time
+}
+
+// clientOrderIDs are the client order IDs of the orders created in a market
+// within the window. Times are those of the order request records, so that
+// replaying the order topics drops the same requests.
+type clientOrderIDs struct {
+	window time.Duration
+
+	seen map[string]bool
+
+	// The client order IDs in seen, oldest first.
+	queue []clientOrderID
+}
+
+func newClientOrderIDs(window time.Duration) *clientOrderIDs {
+	return &clientOrderIDs{
+		window: window,
+		seen:   map[string]bool{},
+	}
+}
+
+// add records the client order IDs of the orders created by a request at t.
+// It returns false, recording none of them, if any was already seen within the
+// window or is repeated by the orders of the request.
+func (c *clientOrderIDs) add(msg *enginepb.OrderRequest, t time.Time) bool {
+	for len(c.queue) > 0 && t.Sub(c.queue[0].At) >= c.window {
+		delete(c.seen, c.queue[0].Key)
+		c.queue = c.queue[1:]
+	}
+
+	if !createTypes[msg.Type] {
+		return true
+	}
+
+	keys := []string{}
+	for _, o := range append([]*exchangepb.Order{msg.Order}, msg.Linked...) {
+		if o.GetClientOrderId() == "" {
+			continue
+		}
+
+		key := o.AccountId + "\x00" + o.ClientOrderId
+		if c.seen[key] || slices.Contains(keys, key) {
+			return false
+		}
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		c.seen[key] = true
+		c.queue = append(c.queue, clientOrderID{Key: key, At: t})
+	}
+
+	return true
+}
+
+// restore replaces the client order IDs with those of a snapshot, oldest
+// first.
+func (c *clientOrderIDs) restore(queue []clientOrderID) {
+	c.queue = queue
+	c.seen = map[string]bool{}
+	for _, id := range queue {
+		c.seen[id.Key] = true
+	}
+}
@@ -0,0 +1,153 @@
+package engineserver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+func Test_ClientOrderIDs_Add(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	o := func(accountID string, clientOrderID string) *exchangepb.Order {
+		return &exchangepb.Order{AccountId: accountID, ClientOrderId: clientOrderID}
+	}
+
+	limit := func(o *exchangepb.Order) *enginepb.OrderRequest {
+		return &enginepb.OrderRequest{Type: enginepb.OrderRequest_LIMIT, Order: o}
+	}
+
+	type add struct {
+		req   *enginepb.OrderRequest
+		after time.Duration
+		want  bool
+	}
+
+	testCases := []struct {
+		name string
+		adds []add
+	}{
+		{
+			name: "duplicate",
+			adds: []add{
+				{req: limit(o("a", "1")), want: true},
+				{req: limit(o("a", "1")), after: time.Hour, want: false},
+			},
+		},
+		{
+			name: "other_account",
+			adds: []add{
+				{req: limit(o("a", "1")), want: true},
+				{req: limit(o("b", "1")), want: true},
+			},
+		},
+		{
+			name: "without_client_order_id",
+			adds: []add{
+				{req: limit(o("a", "")), want: true},
+				{req: limit(o("a", "")), want: true},
+			},
+		},
+		{
+			name: "window_expiry_by_record_time",
+			adds: []add{
+				{req: limit(o("a", "1")), want: true},
+				{req: limit(o("a", "1")), after: ClientOrderIDWindow - time.Nanosecond, want: false},
+				{req: limit(o("a", "1")), after: ClientOrderIDWindow, want: true},
+				// Seen again from the last time it was recorded.
+				{req: limit(o("a", "1")), after: 2*ClientOrderIDWindow - time.Nanosecond, want: false},
+			},
+		},
+		{
+			name: "not_created",
+			adds: []add{
+				{req: &enginepb.OrderRequest{Type: enginepb.OrderRequest_CANCEL, Order: o("a", "1")}, want: true},
+				{req: limit(o("a", "1")), want: true},
+			},
+		},
+		{
+			name: "oco_linked",
+			adds: []add{
+				{req: &enginepb.OrderRequest{Type: enginepb.OrderRequest_OCO, Order: o("a", "1"), Linked: []*exchangepb.Order{o("a", "2")}}, want: true},
+				{req: limit(o("a", "2")), want: false},
+			},
+		},
+		{
+			name: "bracket_linked",
+			adds: []add{
+				{req: limit(o("a", "3")), want: true},
+				{req: &enginepb.OrderRequest{Type: enginepb.OrderRequest_BRACKET, Order: o("a", "1"), Linked: []*exchangepb.Order{o("a", "2"), o("a", "3")}}, want: false},
+			},
+		},
+		{
+			name: "repeated_in_request",
+			adds: []add{
+				{req: &enginepb.OrderRequest{Type: enginepb.OrderRequest_OCO, Order: o("a", "1"), Linked: []*exchangepb.Order{o("a", "1")}}, want: false},
+				{req: limit(o("a", "1")), want: true},
+			},
+		},
+		{
+			name: "all_or_nothing",
+			adds: []add{
+				{req: limit(o("a", "3")), want: true},
+				{req: &enginepb.OrderRequest{Type: enginepb.OrderRequest_BRACKET, Order: o("a", "1"), Linked: []*exchangepb.Order{o("a", "2"), o("a", "3")}}, want: false},
+				// None of the bracket was recorded.
+				{req: limit(o("a", "1")), want: true},
+				{req: limit(o("a", "2")), want: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newClientOrderIDs(ClientOrderIDWindow)
+
+			for i, add := range tc.adds {
+				if got := c.add(add.req, start.Add(add.after)); got != add.want {
+					t.Errorf("add() %d got %v, want %v", i, got, add.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_ProcessOrderRequest_BatchDuplicates(t *testing.T) {
+	ms := MarketSymbol{Base: "A", Trade: "B"}
+	e := newTestEngine(t, t.TempDir(), ms)
+
+	o := func(id string, price uint64) *exchangepb.Order {
+		return &exchangepb.Order{Id: id, Pair: ms.Name(), AccountId: "a", ClientOrderId: "c", Side: exchangepb.Side_BUY, Price: price, Volume: 1}
+	}
+
+	batch := &enginepb.OrderRequest{
+		Type: enginepb.OrderRequest_BATCH,
+		Batch: []*enginepb.OrderRequest{
+			{Type: enginepb.OrderRequest_LIMIT, Order: o("1", 10)},
+			{Type: enginepb.OrderRequest_LIMIT, Order: o("2", 11)},
+		},
+	}
+
+	err := apply(t, e, ms.Topic(), 0, batch)
+	if err == nil || !strings.Contains(err.Error(), "batch request 1: duplicate client order ID") {
+		t.Errorf("processOrderRequest() got error %v, want a duplicate in batch request 1", err)
+	}
+
+	m, _ := e.pairs.Load(ms.Topic())
+	for _, tc := range []struct {
+		order   *order.Order
+		resting bool
+	}{
+		{order: &order.Order{ID: "1", Pair: ms.Name(), Side: order.OrderBuy, Price: 10}, resting: true},
+		{order: &order.Order{ID: "2", Pair: ms.Name(), Side: order.OrderBuy, Price: 11}, resting: false},
+	} {
+		if _, err := m.(*market.Market).RestingOrder(tc.order); (err == nil) != tc.resting {
+			t.Errorf("RestingOrder(%q) got error %v, want resting: %v", tc.order.ID, err, tc.resting)
+		}
+	}
+}
@@ -3,13 +3,38 @@ package engineserver
 import (
 	"context"
 	"exchange/engine/market"
+	"fmt"
 	"log"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
+const (
+	// The Kafka broker the clients of the engine connect to.
+	kafkaBroker = "localhost:9092"
+
+	// How often a depth snapshot of every market is published.
+	depthInterval = time.Second
+
+	// The number of levels per side in depth snapshots.
+	depthLevels = 20
+
+	// The number of levels per side in the views of each market.
+	viewLevels = 100
+
+	// How often the markets are snapshotted.
+	snapshotInterval = time.Minute
+
+	// How long the events are given to be produced before a snapshot.
+	flushTimeout = 10 * time.Second
+
+	// How long the last events produced are given to be read on start.
+	producedTimeout = 10 * time.Second
+)
+
 // Engine is a struct that coordinates events between the order books of different
 // trading pairs, and the Kafka client.
 type Engine struct {
@@ -28,21 +53,89 @@ type Engine struct {
 	// A map from symbol topics to match event channels
 	matchEventsChans map[string]chan *market.MatchEvent
 
+	// A map from symbol topics to the client order IDs seen by the market,
+	// only used by the goroutine processing the order requests.
+	clientOrderIDs map[string]*clientOrderIDs
+
+	// How often a depth snapshot of every market is published.
+	depthInterval time.Duration
+
+	// The number of levels per side in depth snapshots.
+	depthLevels int
+
+	// The directory of the market snapshots, one file per market topic.
+	snapshotDir string
+
+	// How often the markets are snapshotted.
+	snapshotInterval time.Duration
+
+	// From symbol topics to the offset of the next order request to apply,
+	// and to that offset when the market was last snapshotted. Only used by
+	// the goroutine processing the order requests.
+	offsets      map[string]int64
+	savedOffsets map[string]int64
+
+	// From event topics to the sequence of their last event when the market
+	// was snapshotted, and to the sequence of their last event produced when
+	// the engine started. The events up to the latter are applied again, but
+	// not produced.
+	sequences map[string]uint64
+	produced  map[string]uint64
+
+	// From event topics to the channels answering the nil events sent to the
+	// goroutines streaming the events, see streamed.
+	barriers map[string]chan uint64
+
 	// The Kafka client
 	kafka *kgo.Client
 }
 
 // NewEngine creates an engine with initialized channels and a kafka client.
-func NewEngine(markets []MarketSymbol) (*Engine, error) {
-	topics := []string{}
+// The markets are restored from their snapshots in snapshotDir, and consume
+// their order requests from the first one after the snapshot, or from the
+// start of their topic without one. The events of the order requests applied
+// again are only produced if they were not before.
+func NewEngine(markets []MarketSymbol, snapshotDir string) (*Engine, error) {
+	e := &Engine{
+		marketSymbols:     markets,
+		orderEventsChans:  map[string]chan *market.OrderEvent{},
+		volumeEventsChans: map[string]chan *market.VolumeEvent{},
+		matchEventsChans:  map[string]chan *market.MatchEvent{},
+		clientOrderIDs:    map[string]*clientOrderIDs{},
+		depthInterval:     depthInterval,
+		depthLevels:       depthLevels,
+		snapshotDir:       snapshotDir,
+		snapshotInterval:  snapshotInterval,
+		offsets:           map[string]int64{},
+		savedOffsets:      map[string]int64{},
+		sequences:         map[string]uint64{},
+		barriers:          map[string]chan uint64{},
+	}
+
+	if err := os.MkdirAll(snapshotDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	offsets := map[string]map[int32]kgo.Offset{}
 	for _, market := range markets {
-		topics = append(topics, market.Topic())
+		if err := e.addMarket(market); err != nil {
+			return nil, err
+		}
+
+		offset, err := e.loadSnapshot(market.Topic())
+		if err != nil {
+			return nil, err
+		}
+
+		// The topics of the engine have a single partition.
+		offsets[market.Topic()] = map[int32]kgo.Offset{0: kgo.NewOffset().At(offset)}
 	}
 
+	// Without a consumer group, the offsets are those of the snapshots, so
+	// that no order request is skipped or applied twice.
 	cl, err := kgo.NewClient(
-		kgo.SeedBrokers("localhost:9092"),
-		kgo.ConsumeTopics(topics...),
-		kgo.ConsumerGroup("engine"),
+		kgo.SeedBrokers(kafkaBroker),
+		kgo.ConsumePartitions(offsets),
 	)
 	if err != nil {
 		return nil, err
@@ -59,22 +152,22 @@ func NewEngine(markets []MarketSymbol) (*Engine, error) {
 		log.Print("Ping successful, kafka client is up!")
 	}
 
-	e := &Engine{
-		marketSymbols:     markets,
-		orderEventsChans:  map[string]chan *market.OrderEvent{},
-		volumeEventsChans: map[string]chan *market.VolumeEvent{},
-		matchEventsChans:  map[string]chan *market.MatchEvent{},
-		kafka:             cl,
+	// Without a snapshot, every order request is applied again, and its events
+	// produced again only if they are after the last ones produced.
+	ctxTime, cancel = context.WithTimeout(ctx, producedTimeout)
+	e.produced, err = producedSequences(ctxTime, cl, markets)
+	cancel()
+	if err != nil {
+		cl.Close()
+		return nil, fmt.Errorf("sequences of the events produced: %w", err)
 	}
 
-	for _, market := range markets {
-		e.addMarket(market)
-	}
+	e.kafka = cl
 
 	return e, nil
 }
 
-func (e *Engine) addMarket(ms MarketSymbol) {
+func (e *Engine) addMarket(ms MarketSymbol) error {
 	orderEventsChan := make(chan *market.OrderEvent, 20)
 	volumeEventsChan := make(chan *market.VolumeEvent, 20)
 	matchEventsChan := make(chan *market.MatchEvent, 20)
@@ -82,10 +175,50 @@ func (e *Engine) addMarket(ms MarketSymbol) {
 	e.orderEventsChans[ms.Topic()] = orderEventsChan
 	e.volumeEventsChans[ms.Topic()] = volumeEventsChan
 	e.matchEventsChans[ms.Topic()] = matchEventsChan
+	e.clientOrderIDs[ms.Topic()] = newClientOrderIDs(ClientOrderIDWindow)
+	for _, topic := range eventTopics(ms.Topic()) {
+		e.barriers[topic] = make(chan uint64)
+	}
+
+	var m *market.Market
+	if ms.PriceRange == nil {
+		m = market.New(ms.Name(), orderEventsChan, volumeEventsChan, matchEventsChan)
+	} else {
+		var err error
+		m, err = market.NewWithPriceRange(ms.Name(), *ms.PriceRange, orderEventsChan, volumeEventsChan, matchEventsChan)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ms.Allocator != nil {
+		m.SetAllocator(ms.Allocator)
+	}
 
-	m := market.New(ms.Name(), orderEventsChan, volumeEventsChan, matchEventsChan)
+	m.SetLotSize(ms.LotSize)
 
 	e.pairs.Store(ms.Topic(), m)
+	return nil
+}
+
+// publishViews publishes the view of every market that changed. It must be
+// called from the goroutine processing the order requests.
+func (e *Engine) publishViews() {
+	e.pairs.Range(func(_, m any) bool {
+		m.(*market.Market).PublishView(viewLevels)
+		return true
+	})
+}
+
+// View returns the last published view of the market, as of the end of a batch
+// of order requests. It is safe to call from any goroutine.
+func (e *Engine) View(ms MarketSymbol) (*market.View, bool) {
+	m, ok := e.pairs.Load(ms.Topic())
+	if !ok {
+		return nil, false
+	}
+
+	return m.(*market.Market).View(), true
 }
 
 func (e *Engine) CloseKafka() {
@@ -0,0 +1,10 @@
+package engineserver
+
+// Instruments returns the markets listed in the exchange. The engine and the
+// services are started with the same markets, so that the services only
+// accept the orders the engine can take.
+func Instruments() []MarketSymbol {
+	return []MarketSymbol{
+		{Base: "DOLS", Trade: "MEEM"},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/twmb/franz-go/pkg/kgo"
 	"google.golang.org/protobuf/proto"
@@ -28,20 +29,68 @@ func (e *Engine) processOrderRequest(record *kgo.Record) error {
 		return err
 	}
 
-	orderSide := order.OrderBuy
-	if msg.Order.Side == exchangepb.Side_SELL {
-		orderSide = order.OrderSell
+	// Orders are expired by the time of the records instead of the clock of
+	// the engine, so that replaying the topic expires the same orders, before
+	// the same requests.
+	market := m.(*market.Market)
+	market.Expire(record.Timestamp)
+
+	// Retried requests creating an order already created are dropped, so
+	// that the order is not matched twice.
+	clientOrderIDs := e.clientOrderIDs[record.Topic]
+	if !clientOrderIDs.add(msg, record.Timestamp) {
+		return fmt.Errorf("duplicate client order ID in order request %v %q", msg.Type, msg.Order.GetId())
 	}
 
-	o := &order.Order{
-		ID:     msg.Order.Id,
-		Pair:   msg.Order.Pair,
-		Side:   orderSide,
-		Price:  msg.Order.Price,
-		Volume: msg.Order.Volume,
+	if msg.Type != enginepb.OrderRequest_BATCH {
+		return applyOrderRequest(market, msg)
 	}
 
-	market := m.(*market.Market)
+	// The requests of a batch are applied in the same call, so no other request
+	// of the market comes in between. Each one fails on its own.
+	var errs []error
+	for i, req := range msg.Batch {
+		if req.Type == enginepb.OrderRequest_TICK || req.Type == enginepb.OrderRequest_BATCH {
+			errs = append(errs, fmt.Errorf("batch request %d: unsupported type %v", i, req.Type))
+			continue
+		}
+
+		if !clientOrderIDs.add(req, record.Timestamp) {
+			errs = append(errs, fmt.Errorf("batch request %d: duplicate client order ID in order request %v %q", i, req.Type, req.Order.GetId()))
+			continue
+		}
+
+		if err := applyOrderRequest(market, req); err != nil {
+			errs = append(errs, fmt.Errorf("batch request %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyOrderRequest applies one order request to its market.
+func applyOrderRequest(market *market.Market, msg *enginepb.OrderRequest) error {
+	if msg.Type == enginepb.OrderRequest_TICK {
+		// Ticks carry no order, only the time to expire orders up to.
+		market.Expire(msg.Time.AsTime())
+		return nil
+	}
+
+	if msg.Type == enginepb.OrderRequest_CANCEL_ALL {
+		if msg.CancelAll == nil {
+			return errors.New("cancel all request without filters")
+		}
+
+		market.CancelAll(msg.CancelAll.Id, cancelFilterFromPB(msg.CancelAll))
+		return nil
+	}
+
+	if msg.Order == nil {
+		return fmt.Errorf("order request %v without an order", msg.Type)
+	}
+
+	o := orderFromPB(msg.Order)
+
 	switch msg.Type {
 	case enginepb.OrderRequest_LIMIT:
 		if err := market.InsertMakerOrder(o); err != nil {
@@ -55,6 +104,42 @@ func (e *Engine) processOrderRequest(record *kgo.Record) error {
 		if err := market.Cancel(o); err != nil {
 			return err
 		}
+	case enginepb.OrderRequest_AMEND:
+		if msg.Amend == nil {
+			return fmt.Errorf("amend order request %q without changes", o.ID)
+		}
+
+		if err := market.Amend(o, msg.Amend.Price, msg.Amend.Volume); err != nil {
+			return err
+		}
+	case enginepb.OrderRequest_STOP:
+		if err := market.InsertStopOrder(o); err != nil {
+			return err
+		}
+	case enginepb.OrderRequest_TRAILING_STOP:
+		if err := market.InsertTrailingStopOrder(o); err != nil {
+			return err
+		}
+	case enginepb.OrderRequest_PEGGED:
+		if err := market.InsertPeggedOrder(o); err != nil {
+			return err
+		}
+	case enginepb.OrderRequest_OCO:
+		if len(msg.Linked) != 1 {
+			return fmt.Errorf("OCO order request %q with %d linked orders, want 1", o.ID, len(msg.Linked))
+		}
+
+		if err := market.InsertOCO(o, orderFromPB(msg.Linked[0])); err != nil {
+			return err
+		}
+	case enginepb.OrderRequest_BRACKET:
+		if len(msg.Linked) != 2 {
+			return fmt.Errorf("bracket order request %q with %d linked orders, want 2", o.ID, len(msg.Linked))
+		}
+
+		if err := market.InsertBracket(o, orderFromPB(msg.Linked[0]), orderFromPB(msg.Linked[1])); err != nil {
+			return err
+		}
 	default:
 		return errors.New(fmt.Sprintf("unhandled order request type %v, from %+v", msg.Type, msg))
 	}
@@ -62,14 +147,121 @@ func (e *Engine) processOrderRequest(record *kgo.Record) error {
 	return nil
 }
 
+// pegTypes are the market peg types of the request peg types.
+var pegTypes = map[exchangepb.Peg_Type]order.PegType{
+	exchangepb.Peg_PRIMARY:  order.PegPrimary,
+	exchangepb.Peg_MARKET:   order.PegMarket,
+	exchangepb.Peg_MIDPOINT: order.PegMidpoint,
+}
+
+// orderFromPB converts an order of a request to a market order.
+func orderFromPB(pb *exchangepb.Order) *order.Order {
+	orderSide := order.OrderBuy
+	if pb.Side == exchangepb.Side_SELL {
+		orderSide = order.OrderSell
+	}
+
+	o := &order.Order{
+		ID:        pb.Id,
+		Pair:      pb.Pair,
+		Account:   pb.AccountId,
+		Session:   pb.SessionId,
+		Side:      orderSide,
+		Price:     pb.Price,
+		Volume:    pb.Volume,
+		StopPrice: pb.StopPrice,
+	}
+
+	if pb.ExpiresAt != nil {
+		o.ExpiresAt = pb.ExpiresAt.AsTime()
+	}
+
+	if pb.Trail != nil {
+		o.Trail = &order.Trail{Amount: pb.Trail.Amount, BasisPoints: pb.Trail.BasisPoints}
+	}
+
+	// Orders without a known peg type are rejected by the market.
+	if pegType, ok := pegTypes[pb.Peg.GetType()]; ok {
+		o.Peg = &order.Peg{Type: pegType, Offset: pb.Peg.GetOffset()}
+	}
+
+	return o
+}
+
+// cancelFilterFromPB converts the filters of a mass cancel request to a market
+// filter. The pair is not a filter, requests are sent to its market.
+func cancelFilterFromPB(pb *exchangepb.CancelAllOrdersRequest) market.CancelFilter {
+	filter := market.CancelFilter{
+		Account:  pb.AccountId,
+		Session:  pb.SessionId,
+		MinPrice: pb.MinPrice,
+		MaxPrice: pb.MaxPrice,
+	}
+
+	if pb.Side != exchangepb.Side_SIDE_UNDEFINED {
+		side := order.OrderBuy
+		if pb.Side == exchangepb.Side_SELL {
+			side = order.OrderSell
+		}
+		filter.Side = &side
+	}
+
+	return filter
+}
+
+// pollErrors returns the errors of the fetches, ignoring the expiration of the
+// polling deadline.
+func pollErrors(fetches kgo.Fetches) []kgo.FetchError {
+	errs := []kgo.FetchError{}
+	for _, err := range fetches.Errors() {
+		if !errors.Is(err.Err, context.DeadlineExceeded) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Listen processes the order requests of every market, and publishes their
+// depth snapshots periodically. It saves the market snapshots periodically
+// too, and once more when the context is done.
+//
+// Markets are not safe for concurrent use, so snapshots are taken in this same
+// goroutine, between order requests. Other goroutines read the views published
+// after each batch of order requests, see View.
 func (e *Engine) Listen(ctx context.Context) error {
+	nextDepth := time.Now().Add(e.depthInterval)
+	nextSave := time.Now().Add(e.snapshotInterval)
 	for {
 		select {
 		case <-ctx.Done():
+			if err := e.saveSnapshots(); err != nil {
+				log.Printf("Error saving market snapshots: %v", err)
+			}
 			return ctx.Err()
 		default:
-			fetches := e.kafka.PollFetches(ctx)
-			if errs := fetches.Errors(); len(errs) > 0 {
+			if now := time.Now(); !now.Before(nextDepth) {
+				e.publishDepth(ctx)
+				nextDepth = now.Add(e.depthInterval)
+			}
+
+			if now := time.Now(); !now.Before(nextSave) {
+				if err := e.saveSnapshots(); err != nil {
+					log.Printf("Error saving market snapshots: %v", err)
+				}
+				nextSave = now.Add(e.snapshotInterval)
+			}
+
+			// Stop polling when the next snapshot is due.
+			deadline := nextDepth
+			if nextSave.Before(deadline) {
+				deadline = nextSave
+			}
+			pollCtx, cancel := context.WithDeadline(ctx, deadline)
+			fetches := e.kafka.PollFetches(pollCtx)
+			cancel()
+
+			if errs := pollErrors(fetches); len(errs) > 0 {
 				// Log errors but continue processing
 				for _, err := range errs {
 					log.Printf("Error polling Kafka: %v", err)
@@ -81,7 +273,12 @@ func (e *Engine) Listen(ctx context.Context) error {
 				if err := e.processOrderRequest(record); err != nil {
 					log.Printf("Error processing record: %v", err)
 				}
+				e.offsets[record.Topic] = record.Offset + 1
 			})
+
+			// Views are published once per batch, so that readers never slow
+			// down the processing of each order.
+			e.publishViews()
 		}
 	}
 }
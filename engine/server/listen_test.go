@@ -0,0 +1,62 @@
+package engineserver
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"exchange/engine/market"
+	"exchange/engine/order"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+func Test_ProcessOrderRequest_ExpiresByRecordTime(t *testing.T) {
+	ms := MarketSymbol{Base: "A", Trade: "B"}
+	e := newTestEngine(t, t.TempDir(), ms)
+	m, _ := e.pairs.Load(ms.Topic())
+
+	limit := func(id string, price uint64, expiresAt time.Time) *enginepb.OrderRequest {
+		return &enginepb.OrderRequest{
+			Type:  enginepb.OrderRequest_LIMIT,
+			Order: &exchangepb.Order{Id: id, Pair: ms.Name(), AccountId: "a", Side: exchangepb.Side_BUY, Price: price, Volume: 1, ExpiresAt: timestamppb.New(expiresAt)},
+		}
+	}
+
+	resting := func(id string, price uint64) bool {
+		_, err := m.(*market.Market).RestingOrder(&order.Order{ID: id, Pair: ms.Name(), Side: order.OrderBuy, Price: price})
+		return err == nil
+	}
+
+	// The records are a second apart from recordsStart.
+	if err := apply(t, e, ms.Topic(), 0, limit("1", 10, recordsStart.Add(1500*time.Millisecond)), limit("2", 11, recordsStart.Add(time.Hour))); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+
+	if !resting("1", 10) {
+		t.Errorf("order 1 want resting before its expiry")
+	}
+
+	if err := apply(t, e, ms.Topic(), 2, limit("3", 12, recordsStart.Add(time.Hour))); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+
+	if resting("1", 10) {
+		t.Errorf("order 1 want expired by the time of the next record")
+	}
+
+	if err := apply(t, e, ms.Topic(), 3, limit("4", 13, recordsStart.Add(2*time.Second))); err == nil {
+		t.Errorf("processOrderRequest() of an order expired by the record time want error, got nil")
+	}
+
+	tick := &enginepb.OrderRequest{Type: enginepb.OrderRequest_TICK, Time: timestamppb.New(recordsStart.Add(2 * time.Hour))}
+	if err := apply(t, e, ms.Topic(), 4, tick); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+
+	if resting("2", 11) || resting("3", 12) {
+		t.Errorf("orders 2 and 3 want expired by the tick")
+	}
+}
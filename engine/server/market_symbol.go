@@ -1,8 +1,39 @@
 package engineserver
 
+import (
+	"exchange/engine/orderbook"
+	"exchange/engine/orderbook/pricelevel"
+)
+
+// MarketStatus is whether a market accepts new orders.
+type MarketStatus int
+
+const (
+	// Accepts new orders.
+	MarketTrading MarketStatus = iota
+
+	// Only accepts cancellations of the open orders.
+	MarketHalted
+)
+
 type MarketSymbol struct {
 	Base  string
 	Trade string
+
+	// Checked by the orders service, the engine applies every request it
+	// receives.
+	Status MarketStatus
+
+	// Bounds the prices of the market, so that its levels are indexed in arrays
+	// by tick instead of trees. Nil to accept any price.
+	PriceRange *orderbook.PriceRange
+
+	// Volumes must be multiples of it. Zero to accept any volume.
+	LotSize uint64
+
+	// Splits the volume matched at a price between the maker orders. Nil for
+	// FIFO.
+	Allocator pricelevel.Allocator
 }
 
 func (m *MarketSymbol) Name() string {
@@ -12,3 +43,13 @@ func (m *MarketSymbol) Name() string {
 func (m *MarketSymbol) Topic() string {
 	return "engine." + m.Base + "." + m.Trade
 }
+
+// TickSize returns the size prices must be multiples of, from the minimum
+// price of the range. 1 if the market accepts any price.
+func (m *MarketSymbol) TickSize() uint64 {
+	if m.PriceRange == nil {
+		return 1
+	}
+
+	return m.PriceRange.Tick
+}
@@ -0,0 +1,114 @@
+package engineserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"google.golang.org/protobuf/proto"
+
+	enginepb "exchange/engine/api/v1"
+)
+
+// sequencedEvent is an event numbered in its topic.
+type sequencedEvent interface {
+	proto.Message
+	GetSequence() uint64
+}
+
+// producedSequences returns the sequence of the last event of every event
+// topic of the markets, read from the end of the topics. Empty and missing
+// topics are left out.
+func producedSequences(ctx context.Context, cl *kgo.Client, markets []MarketSymbol) (map[string]uint64, error) {
+	events := map[string]func() sequencedEvent{}
+	for _, ms := range markets {
+		topics := eventTopics(ms.Topic())
+		events[topics[0]] = func() sequencedEvent { return &enginepb.OrderEvent{} }
+		events[topics[1]] = func() sequencedEvent { return &enginepb.VolumeEvent{} }
+		events[topics[2]] = func() sequencedEvent { return &enginepb.MatchEvent{} }
+	}
+
+	req := kmsg.NewPtrListOffsetsRequest()
+	for topic := range events {
+		rt := kmsg.NewListOffsetsRequestTopic()
+		rt.Topic = topic
+
+		// The topics of the engine have a single partition, and a timestamp of
+		// -1 asks for the offset after their last record.
+		rp := kmsg.NewListOffsetsRequestTopicPartition()
+		rp.Partition = 0
+		rp.Timestamp = -1
+		rt.Partitions = append(rt.Partitions, rp)
+
+		req.Topics = append(req.Topics, rt)
+	}
+
+	res, err := req.RequestWith(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	last := map[string]map[int32]kgo.Offset{}
+	for _, rt := range res.Topics {
+		for _, rp := range rt.Partitions {
+			err := kerr.ErrorForCode(rp.ErrorCode)
+			if errors.Is(err, kerr.UnknownTopicOrPartition) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("end offset of %q: %w", rt.Topic, err)
+			}
+
+			if rp.Offset > 0 {
+				last[rt.Topic] = map[int32]kgo.Offset{rp.Partition: kgo.NewOffset().At(rp.Offset - 1)}
+			}
+		}
+	}
+
+	produced := map[string]uint64{}
+	if len(last) == 0 {
+		return produced, nil
+	}
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(kafkaBroker),
+		kgo.ConsumePartitions(last),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer consumer.Close()
+
+	read := map[string]bool{}
+	for len(read) < len(last) {
+		fetches := consumer.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return nil, fmt.Errorf("last event of %q: %w", errs[0].Topic, errs[0].Err)
+		}
+
+		var errs []error
+		fetches.EachRecord(func(record *kgo.Record) {
+			ev := events[record.Topic]()
+			if err := proto.Unmarshal(record.Value, ev); err != nil {
+				errs = append(errs, fmt.Errorf("last event of %q: %w", record.Topic, err))
+				return
+			}
+
+			produced[record.Topic] = ev.GetSequence()
+			read[record.Topic] = true
+		})
+
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
+
+	return produced, nil
+}
@@ -0,0 +1,142 @@
+package engineserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"exchange/engine/market"
+)
+
+// marketSnapshot is the state of a market stored by the engine, with the
+// offset of the order requests to resume from.
+type marketSnapshot struct {
+	// The offset of the next order request of the market topic.
+	Offset int64
+
+	Market *market.Snapshot
+
+	ClientOrderIDs []clientOrderID
+
+	// The sequence of the last event of each event topic of the market, by
+	// topic.
+	Sequences map[string]uint64
+}
+
+func (e *Engine) snapshotPath(topic string) string {
+	return filepath.Join(e.snapshotDir, topic+".json")
+}
+
+// loadSnapshot restores the market of a topic from its snapshot, and returns
+// the offset of the next order request to apply, 0 without a snapshot.
+func (e *Engine) loadSnapshot(topic string) (int64, error) {
+	b, err := os.ReadFile(e.snapshotPath(topic))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s := &marketSnapshot{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return 0, fmt.Errorf("snapshot of %q: %w", topic, err)
+	}
+
+	m, ok := e.pairs.Load(topic)
+	if !ok {
+		return 0, fmt.Errorf("snapshot of %q: market not found", topic)
+	}
+
+	if err := m.(*market.Market).Restore(s.Market); err != nil {
+		return 0, fmt.Errorf("snapshot of %q: %w", topic, err)
+	}
+	e.clientOrderIDs[topic].restore(s.ClientOrderIDs)
+	e.offsets[topic] = s.Offset
+	e.savedOffsets[topic] = s.Offset
+	for eventTopic, sequence := range s.Sequences {
+		e.sequences[eventTopic] = sequence
+	}
+
+	return s.Offset, nil
+}
+
+// saveSnapshots writes the snapshot of every market that applied order
+// requests since its last one, once the events of those requests are
+// produced, so that restarting from a snapshot never loses events. Each file
+// is replaced at once, so a crash leaves the previous snapshot. It must be
+// called from the goroutine processing the order requests.
+func (e *Engine) saveSnapshots() error {
+	snapshots := map[string]*marketSnapshot{}
+	e.pairs.Range(func(topic, m any) bool {
+		offset := e.offsets[topic.(string)]
+		if saved, ok := e.savedOffsets[topic.(string)]; ok && saved == offset {
+			return true
+		}
+
+		snapshots[topic.(string)] = &marketSnapshot{
+			Offset:         offset,
+			Market:         m.(*market.Market).Snapshot(),
+			ClientOrderIDs: e.clientOrderIDs[topic.(string)].queue,
+			Sequences:      e.streamed(topic.(string)),
+		}
+
+		return true
+	})
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	if e.kafka != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		err := e.kafka.Flush(ctx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("producing the events before the snapshots: %w", err)
+		}
+	}
+
+	var errs []error
+	for topic, s := range snapshots {
+		if err := e.writeSnapshot(topic, s); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		e.savedOffsets[topic] = s.Offset
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Engine) writeSnapshot(topic string, s *marketSnapshot) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("snapshot of %q: %w", topic, err)
+	}
+
+	f, err := os.CreateTemp(e.snapshotDir, topic+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), e.snapshotPath(topic))
+}
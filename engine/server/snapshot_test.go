@@ -0,0 +1,262 @@
+package engineserver
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"exchange/engine/market"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+// newTestEngine returns an engine of a single market without a Kafka client,
+// restored from its snapshot in dir, and discarding the events of the market.
+func newTestEngine(t *testing.T, dir string, ms MarketSymbol) *Engine {
+	t.Helper()
+
+	return newStreamingTestEngine(t, dir, ms, nil, func(string, sequencedEvent) {})
+}
+
+// newStreamingTestEngine returns an engine like newTestEngine, which publishes
+// the events after the produced sequences of their topics, like Stream.
+func newStreamingTestEngine(t *testing.T, dir string, ms MarketSymbol, produced map[string]uint64, publish func(topic string, ev sequencedEvent)) *Engine {
+	t.Helper()
+
+	e := &Engine{
+		marketSymbols:     []MarketSymbol{ms},
+		orderEventsChans:  map[string]chan *market.OrderEvent{},
+		volumeEventsChans: map[string]chan *market.VolumeEvent{},
+		matchEventsChans:  map[string]chan *market.MatchEvent{},
+		clientOrderIDs:    map[string]*clientOrderIDs{},
+		snapshotDir:       dir,
+		offsets:           map[string]int64{},
+		savedOffsets:      map[string]int64{},
+		sequences:         map[string]uint64{},
+		produced:          produced,
+		barriers:          map[string]chan uint64{},
+	}
+
+	if err := e.addMarket(ms); err != nil {
+		t.Fatalf("addMarket() unexpected error: %v", err)
+	}
+
+	if _, err := e.loadSnapshot(ms.Topic()); err != nil {
+		t.Fatalf("loadSnapshot() unexpected error: %v", err)
+	}
+
+	topic := ms.Topic()
+	topics := eventTopics(topic)
+	t.Cleanup(func() {
+		close(e.orderEventsChans[topic])
+		close(e.volumeEventsChans[topic])
+		close(e.matchEventsChans[topic])
+	})
+
+	go streamEvents(e.orderEventsChans[topic], e.barriers[topics[0]], e.sequences[topics[0]], produced[topics[0]], func(ev *market.OrderEvent, sequence uint64) {
+		publish(topics[0], orderEventPB(ev, sequence))
+	})
+
+	go streamEvents(e.volumeEventsChans[topic], e.barriers[topics[1]], e.sequences[topics[1]], produced[topics[1]], func(ev *market.VolumeEvent, sequence uint64) {
+		publish(topics[1], volumeEventPB(ev, sequence))
+	})
+
+	go streamEvents(e.matchEventsChans[topic], e.barriers[topics[2]], e.sequences[topics[2]], produced[topics[2]], func(ev *market.MatchEvent, sequence uint64) {
+		publish(topics[2], matchEventPB(ev, sequence))
+	})
+
+	return e
+}
+
+// The time of the first record applied by the tests.
+var recordsStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// apply processes order requests as consecutive records of the market topic,
+// from offset, each a second after the previous one.
+func apply(t *testing.T, e *Engine, topic string, offset int64, reqs ...*enginepb.OrderRequest) error {
+	t.Helper()
+
+	var err error
+	for i, req := range reqs {
+		b, marshalErr := proto.Marshal(req)
+		if marshalErr != nil {
+			t.Fatalf("proto.Marshal() unexpected error: %v", marshalErr)
+		}
+
+		record := &kgo.Record{Topic: topic, Value: b, Offset: offset + int64(i), Timestamp: recordsStart.Add(time.Duration(offset+int64(i)) * time.Second)}
+		err = e.processOrderRequest(record)
+		e.offsets[topic] = record.Offset + 1
+	}
+
+	return err
+}
+
+func Test_Snapshots(t *testing.T) {
+	ms := MarketSymbol{Base: "A", Trade: "B"}
+	topic := ms.Topic()
+	dir := t.TempDir()
+
+	o := func(id string, side exchangepb.Side, price uint64, stopPrice uint64, volume uint64) *exchangepb.Order {
+		return &exchangepb.Order{Id: id, Pair: ms.Name(), AccountId: "a", Side: side, Price: price, StopPrice: stopPrice, Volume: volume}
+	}
+
+	limit := &enginepb.OrderRequest{Type: enginepb.OrderRequest_LIMIT, Order: o("1", exchangepb.Side_BUY, 9, 0, 5)}
+	limit.Order.ClientOrderId = "c"
+
+	bracket := &enginepb.OrderRequest{
+		Type:   enginepb.OrderRequest_BRACKET,
+		Order:  o("2", exchangepb.Side_BUY, 10, 0, 4),
+		Linked: []*exchangepb.Order{o("3", exchangepb.Side_SELL, 20, 0, 4), o("4", exchangepb.Side_SELL, 0, 5, 4)},
+	}
+
+	// Partially fills the bracket entry.
+	fill := &enginepb.OrderRequest{Type: enginepb.OrderRequest_MARKET, Order: o("5", exchangepb.Side_SELL, 0, 0, 1)}
+
+	e := newTestEngine(t, dir, ms)
+	if err := apply(t, e, topic, 0, limit, bracket, fill); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+
+	if err := e.saveSnapshots(); err != nil {
+		t.Fatalf("saveSnapshots() unexpected error: %v", err)
+	}
+
+	restarted := newTestEngine(t, dir, ms)
+	offset := restarted.offsets[topic]
+	if offset != 3 {
+		t.Errorf("loadSnapshot() want offset 3, got %d", offset)
+	}
+
+	snapshot := func(e *Engine) *market.Snapshot {
+		m, _ := e.pairs.Load(topic)
+		return m.(*market.Market).Snapshot()
+	}
+
+	if diff := cmp.Diff(snapshot(e), snapshot(restarted)); diff != "" {
+		t.Errorf("restored market diff (-want, +got):\n%s", diff)
+	}
+
+	// The client order IDs seen before the snapshot are still seen.
+	duplicate := proto.Clone(limit).(*enginepb.OrderRequest)
+	duplicate.Order.Id = "6"
+	if err := apply(t, restarted, topic, offset, duplicate); err == nil {
+		t.Errorf("processOrderRequest() of a duplicate client order ID want error, got nil")
+	}
+
+	// Fills the rest of the entry, which places the legs of the bracket.
+	fill = &enginepb.OrderRequest{Type: enginepb.OrderRequest_MARKET, Order: o("7", exchangepb.Side_SELL, 0, 0, 3)}
+	for _, e := range []*Engine{e, restarted} {
+		if err := apply(t, e, topic, 4, fill); err != nil {
+			t.Fatalf("processOrderRequest() unexpected error: %v", err)
+		}
+	}
+
+	got := snapshot(restarted)
+	if diff := cmp.Diff(snapshot(e), got); diff != "" {
+		t.Errorf("restored market diff (-want, +got):\n%s", diff)
+	}
+
+	if len(got.Groups) != 1 || got.Groups[0].Entry != "" || len(got.SellStops) != 1 {
+		t.Errorf("want the legs of the bracket placed, got %+v", got)
+	}
+}
+
+func Test_LoadSnapshot_Missing(t *testing.T) {
+	ms := MarketSymbol{Base: "A", Trade: "B"}
+	e := newTestEngine(t, t.TempDir(), ms)
+
+	offset, err := e.loadSnapshot(ms.Topic())
+	if err != nil || offset != 0 {
+		t.Errorf("loadSnapshot() got %d, %v, want 0, nil", offset, err)
+	}
+}
+
+func Test_Restart_PublishesEventsOnce(t *testing.T) {
+	ms := MarketSymbol{Base: "A", Trade: "B"}
+	topic := ms.Topic()
+
+	o := func(id string, side exchangepb.Side, price uint64, volume uint64) *exchangepb.Order {
+		return &exchangepb.Order{Id: id, Pair: ms.Name(), AccountId: "a", Side: side, Price: price, Volume: volume}
+	}
+
+	reqs := []*enginepb.OrderRequest{
+		{Type: enginepb.OrderRequest_LIMIT, Order: o("1", exchangepb.Side_SELL, 10, 5)},
+		{Type: enginepb.OrderRequest_LIMIT, Order: o("2", exchangepb.Side_SELL, 11, 5)},
+		// Matches both orders.
+		{Type: enginepb.OrderRequest_MARKET, Order: o("3", exchangepb.Side_BUY, 0, 7)},
+		{Type: enginepb.OrderRequest_LIMIT, Order: o("4", exchangepb.Side_BUY, 9, 1)},
+		{Type: enginepb.OrderRequest_CANCEL, Order: o("4", exchangepb.Side_BUY, 9, 1)},
+		{Type: enginepb.OrderRequest_MARKET, Order: o("5", exchangepb.Side_BUY, 0, 3)},
+	}
+
+	var mu sync.Mutex
+	collect := func(events map[string][]sequencedEvent) func(string, sequencedEvent) {
+		return func(topic string, ev sequencedEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			events[topic] = append(events[topic], ev)
+		}
+	}
+
+	want := map[string][]sequencedEvent{}
+	e := newStreamingTestEngine(t, t.TempDir(), ms, nil, collect(want))
+	if err := apply(t, e, topic, 0, reqs...); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+	e.streamed(topic)
+
+	// Snapshotted after the first two requests, and stopped after the fourth,
+	// before producing the last match of the third.
+	dir := t.TempDir()
+	got := map[string][]sequencedEvent{}
+	e = newStreamingTestEngine(t, dir, ms, nil, collect(got))
+	if err := apply(t, e, topic, 0, reqs[:2]...); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+
+	if err := e.saveSnapshots(); err != nil {
+		t.Fatalf("saveSnapshots() unexpected error: %v", err)
+	}
+
+	if err := apply(t, e, topic, 2, reqs[2:4]...); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+	e.streamed(topic)
+
+	mu.Lock()
+	matches := topic + ".matches"
+	got[matches] = got[matches][:len(got[matches])-1]
+
+	produced := map[string]uint64{}
+	for topic, events := range got {
+		produced[topic] = events[len(events)-1].GetSequence()
+	}
+	mu.Unlock()
+
+	restarted := newStreamingTestEngine(t, dir, ms, produced, collect(got))
+	offset := restarted.offsets[topic]
+	if err := apply(t, restarted, topic, offset, reqs[offset:]...); err != nil {
+		t.Fatalf("processOrderRequest() unexpected error: %v", err)
+	}
+	restarted.streamed(topic)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&enginepb.OrderEvent{}, "time"),
+		protocmp.IgnoreFields(&enginepb.VolumeEvent{}, "time"),
+		protocmp.IgnoreFields(&enginepb.MatchEvent{}, "time"),
+	}
+	if diff := cmp.Diff(want, got, opts); diff != "" {
+		t.Errorf("published events diff (-want, +got):\n%s", diff)
+	}
+}
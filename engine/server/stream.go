@@ -10,12 +10,19 @@ import (
 
 	"exchange/engine/market"
 	"exchange/engine/order"
+	"exchange/engine/orderbook"
 
 	exchangepb "exchange/api/v1"
 	enginepb "exchange/engine/api/v1"
 )
 
-func (e *Engine) produce(ctx context.Context, topic string, msg []byte) {
+func (e *Engine) produce(ctx context.Context, topic string, event proto.Message) {
+	msg, err := proto.Marshal(event)
+	if err != nil {
+		fmt.Printf("Error marshalling proto: %v", err)
+		return
+	}
+
 	r := &kgo.Record{Topic: topic, Value: msg}
 
 	e.kafka.Produce(ctx, r, func(_ *kgo.Record, err error) {
@@ -25,111 +32,182 @@ func (e *Engine) produce(ctx context.Context, topic string, msg []byte) {
 	})
 }
 
+// eventTopics returns the topics of the events of the market of a topic.
+func eventTopics(topic string) []string {
+	return []string{topic + ".orders", topic + ".volumes", topic + ".matches"}
+}
+
+// streamEvents numbers the events of a market from the sequence of its
+// snapshot, and publishes those after the sequence last produced to their
+// topic, the others were produced before the engine restarted. A nil event is
+// answered with the sequence of the last event on barrier.
+func streamEvents[T any](events <-chan *T, barrier chan<- uint64, sequence uint64, produced uint64, publish func(ev *T, sequence uint64)) {
+	for ev := range events {
+		if ev == nil {
+			barrier <- sequence
+			continue
+		}
+
+		sequence++
+		if sequence <= produced {
+			continue
+		}
+
+		publish(ev, sequence)
+	}
+}
+
+// Stream produces the events of every market. It must be called before Listen.
 func (e *Engine) Stream(ctx context.Context) {
 	for _, ms := range e.marketSymbols {
 		fmt.Printf("Streaming for market: %v\n", ms)
 
-		go func(topic string, orderEventsChan chan *market.OrderEvent) {
-			for {
-				ev, ok := <-orderEventsChan
-				if !ok {
-					return
-				}
-
-				eventType := enginepb.OrderEvent_UNDEFINED
-				switch ev.Type {
-				case market.OrderCancelled:
-					eventType = enginepb.OrderEvent_ORDER_CANCELLED
-				case market.MakerOrderInserted:
-					eventType = enginepb.OrderEvent_MAKER_ORDER_INSERTED
-				case market.TakerOrderUnfulfilled:
-					eventType = enginepb.OrderEvent_TAKER_ORDER_UNFULFILLED
-				case market.OrderRejected:
-					eventType = enginepb.OrderEvent_ORDER_REJECTED
-				}
-
-				eventPB := &enginepb.OrderEvent{
-					Type:    eventType,
-					OrderId: ev.OrderID,
-					Time:    timestamppb.New(ev.Timestamp),
-				}
-
-				msg, err := proto.Marshal(eventPB)
-				if err != nil {
-					fmt.Printf("Error marshalling proto: %v", err)
-					continue
-				}
-
-				e.produce(ctx, topic, msg)
-			}
-		}(ms.Topic()+".orders", e.orderEventsChans[ms.Topic()])
-
-		go func(topic string, volumeEventsChan chan *market.VolumeEvent) {
-			for {
-				ev, ok := <-volumeEventsChan
-				if !ok {
-					return
-				}
-
-				eventSide := exchangepb.Side_BUY
-				if ev.Side == order.OrderSell {
-					eventSide = exchangepb.Side_SELL
-				}
-
-				eventPB := &enginepb.VolumeEvent{
-					Pair:   ev.Pair,
-					Side:   eventSide,
-					Price:  ev.Price,
-					Volume: ev.Volume,
-					Time:   timestamppb.New(ev.Timestamp),
-				}
-
-				msg, err := proto.Marshal(eventPB)
-				if err != nil {
-					fmt.Printf("Error marshalling proto: %v", err)
-					continue
-				}
-
-				e.produce(ctx, topic, msg)
-			}
-		}(ms.Topic()+".volumes", e.volumeEventsChans[ms.Topic()])
-
-		go func(topic string, matchEventsChan chan *market.MatchEvent) {
-			for {
-				ev, ok := <-matchEventsChan
-				if !ok {
-					return
-				}
-
-				takerMatchType := enginepb.MatchType_ORDER_FULFILLED
-				if ev.TakerMatchType == order.OrderPartiallyFulfilled {
-					takerMatchType = enginepb.MatchType_ORDER_PARTIALLY_FULFILLED
-				}
-
-				makerMatchType := enginepb.MatchType_ORDER_FULFILLED
-				if ev.MakerMatchType == order.OrderPartiallyFulfilled {
-					makerMatchType = enginepb.MatchType_ORDER_PARTIALLY_FULFILLED
-				}
-
-				eventPB := &enginepb.MatchEvent{
-					Pair:            ev.Pair,
-					TakerOrderId:    ev.TakerOrderID,
-					TakerMatchType:  takerMatchType,
-					MakerOrderId:    ev.MakerOrderID,
-					MakerMatchType:  makerMatchType,
-					MatchedVolume:   ev.MatchedVolume,
-					SettlementPrice: ev.SettlementPrice,
-					Time:            timestamppb.New(ev.Timestamp),
-				}
-
-				msg, err := proto.Marshal(eventPB)
-				if err != nil {
-					fmt.Printf("Error marshalling proto: %v", err)
-					continue
-				}
-
-				e.produce(ctx, topic, msg)
-			}
-		}(ms.Topic()+".matches", e.matchEventsChans[ms.Topic()])
+		topics := eventTopics(ms.Topic())
+
+		go streamEvents(e.orderEventsChans[ms.Topic()], e.barriers[topics[0]], e.sequences[topics[0]], e.produced[topics[0]], func(ev *market.OrderEvent, sequence uint64) {
+			e.produce(ctx, topics[0], orderEventPB(ev, sequence))
+		})
+
+		go streamEvents(e.volumeEventsChans[ms.Topic()], e.barriers[topics[1]], e.sequences[topics[1]], e.produced[topics[1]], func(ev *market.VolumeEvent, sequence uint64) {
+			e.produce(ctx, topics[1], volumeEventPB(ev, sequence))
+		})
+
+		go streamEvents(e.matchEventsChans[ms.Topic()], e.barriers[topics[2]], e.sequences[topics[2]], e.produced[topics[2]], func(ev *market.MatchEvent, sequence uint64) {
+			e.produce(ctx, topics[2], matchEventPB(ev, sequence))
+		})
+	}
+}
+
+// streamed waits for the events of the market of a topic to be streamed, and
+// returns the sequence of the last event of each of its event topics. It must
+// be called from the goroutine processing the order requests.
+func (e *Engine) streamed(topic string) map[string]uint64 {
+	e.orderEventsChans[topic] <- nil
+	e.volumeEventsChans[topic] <- nil
+	e.matchEventsChans[topic] <- nil
+
+	sequences := map[string]uint64{}
+	for _, eventTopic := range eventTopics(topic) {
+		sequences[eventTopic] = <-e.barriers[eventTopic]
+	}
+
+	return sequences
+}
+
+func orderEventPB(ev *market.OrderEvent, sequence uint64) *enginepb.OrderEvent {
+	eventType := enginepb.OrderEvent_UNDEFINED
+	switch ev.Type {
+	case market.OrderCancelled:
+		eventType = enginepb.OrderEvent_ORDER_CANCELLED
+	case market.MakerOrderInserted:
+		eventType = enginepb.OrderEvent_MAKER_ORDER_INSERTED
+	case market.TakerOrderUnfulfilled:
+		eventType = enginepb.OrderEvent_TAKER_ORDER_UNFULFILLED
+	case market.OrderRejected:
+		eventType = enginepb.OrderEvent_ORDER_REJECTED
+	case market.OrderExpired:
+		eventType = enginepb.OrderEvent_ORDER_EXPIRED
+	case market.OrderPending:
+		eventType = enginepb.OrderEvent_ORDER_PENDING
+	case market.OrderTriggered:
+		eventType = enginepb.OrderEvent_ORDER_TRIGGERED
+	case market.OrderAmended:
+		eventType = enginepb.OrderEvent_ORDER_AMENDED
+	case market.MassCancelled:
+		eventType = enginepb.OrderEvent_MASS_CANCELLED
+	case market.AmendRejected:
+		eventType = enginepb.OrderEvent_AMEND_REJECTED
+	}
+
+	return &enginepb.OrderEvent{
+		Type:      eventType,
+		OrderId:   ev.OrderID,
+		Time:      timestamppb.New(ev.Timestamp),
+		Reason:    ev.Reason,
+		Price:     ev.Price,
+		Cancelled: uint32(ev.Cancelled),
+		Volume:    ev.Volume,
+		Sequence:  sequence,
+	}
+}
+
+func volumeEventPB(ev *market.VolumeEvent, sequence uint64) *enginepb.VolumeEvent {
+	eventSide := exchangepb.Side_BUY
+	if ev.Side == order.OrderSell {
+		eventSide = exchangepb.Side_SELL
+	}
+
+	return &enginepb.VolumeEvent{
+		Pair:     ev.Pair,
+		Side:     eventSide,
+		Price:    ev.Price,
+		Volume:   ev.Volume,
+		Time:     timestamppb.New(ev.Timestamp),
+		Sequence: sequence,
+	}
+}
+
+func matchEventPB(ev *market.MatchEvent, sequence uint64) *enginepb.MatchEvent {
+	takerMatchType := enginepb.MatchType_ORDER_FULFILLED
+	if ev.TakerMatchType == order.OrderPartiallyFulfilled {
+		takerMatchType = enginepb.MatchType_ORDER_PARTIALLY_FULFILLED
+	}
+
+	makerMatchType := enginepb.MatchType_ORDER_FULFILLED
+	if ev.MakerMatchType == order.OrderPartiallyFulfilled {
+		makerMatchType = enginepb.MatchType_ORDER_PARTIALLY_FULFILLED
+	}
+
+	takerSide := exchangepb.Side_BUY
+	if ev.TakerSide == order.OrderSell {
+		takerSide = exchangepb.Side_SELL
+	}
+
+	return &enginepb.MatchEvent{
+		Pair:            ev.Pair,
+		TakerOrderId:    ev.TakerOrderID,
+		TakerMatchType:  takerMatchType,
+		TakerSide:       takerSide,
+		MakerOrderId:    ev.MakerOrderID,
+		MakerMatchType:  makerMatchType,
+		MatchedVolume:   ev.MatchedVolume,
+		SettlementPrice: ev.SettlementPrice,
+		Time:            timestamppb.New(ev.Timestamp),
+		Sequence:        sequence,
+	}
+}
+
+func depthLevelsPB(levels []orderbook.Level) []*enginepb.DepthLevel {
+	levelsPB := make([]*enginepb.DepthLevel, 0, len(levels))
+	for _, l := range levels {
+		levelsPB = append(levelsPB, &enginepb.DepthLevel{
+			Price:  l.Price,
+			Volume: l.Volume,
+			Orders: uint64(l.Orders),
+		})
+	}
+
+	return levelsPB
+}
+
+// publishDepth produces a depth snapshot of every market. It must be called
+// from the goroutine processing the order requests.
+func (e *Engine) publishDepth(ctx context.Context) {
+	for _, ms := range e.marketSymbols {
+		m, ok := e.pairs.Load(ms.Topic())
+		if !ok {
+			continue
+		}
+
+		ev := m.(*market.Market).Depth(e.depthLevels)
+
+		eventPB := &enginepb.DepthEvent{
+			Pair: ev.Pair,
+			Bids: depthLevelsPB(ev.Bids),
+			Asks: depthLevelsPB(ev.Asks),
+			Time: timestamppb.New(ev.Timestamp),
+		}
+
+		e.produce(ctx, ms.Topic()+".depth", eventPB)
 	}
 }
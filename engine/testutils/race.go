@@ -0,0 +1,7 @@
+//go:build race
+
+package testutils
+
+// RaceEnabled is true when built with -race. The race detector randomly drops
+// the items put in a sync.Pool, so tests counting allocations skip.
+const RaceEnabled = true
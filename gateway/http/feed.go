@@ -0,0 +1,287 @@
+package httpgateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	exchangepb "exchange/api/v1"
+	authservice "exchange/services/auth"
+	"exchange/services/ratelimit"
+)
+
+// feedKey identifies a subscription of a feed connection.
+type feedKey struct {
+	channel   exchangepb.FeedChannel
+	pair      string
+	accountID string
+	interval  exchangepb.CandleInterval
+}
+
+// feedSubscription is an open subscription of a feed connection.
+type feedSubscription struct {
+	cancel context.CancelFunc
+}
+
+// feedConn is a WebSocket connection to the feed, with the subscriptions it
+// multiplexes.
+type feedConn struct {
+	gateway *Gateway
+
+	ws *websocket.Conn
+
+	// Serializes the messages of the subscriptions.
+	sendMu sync.Mutex
+
+	mu sync.Mutex
+
+	subscriptions map[feedKey]*feedSubscription
+}
+
+// feed returns the handler of the WebSocket feed. It accepts connections from
+// any origin, like the REST endpoints.
+func (g *Gateway) feed() http.Handler {
+	return websocket.Server{Handler: g.serveFeed}
+}
+
+// serveFeed answers the requests of a connection until it is closed, and then
+// ends its subscriptions.
+func (g *Gateway) serveFeed(ws *websocket.Conn) {
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+
+	if ip := clientIP(ws.Request()); ip != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ratelimit.ForwardedForHeader, ip)
+	}
+
+	c := &feedConn{
+		gateway:       g,
+		ws:            ws,
+		subscriptions: map[feedKey]*feedSubscription{},
+	}
+
+	for {
+		var text string
+		if err := websocket.Message.Receive(ws, &text); err != nil {
+			return
+		}
+
+		req := &exchangepb.FeedRequest{}
+		if err := protojson.Unmarshal([]byte(text), req); err != nil {
+			c.send(&exchangepb.FeedMessage{Message: &exchangepb.FeedMessage_Error{Error: fmt.Sprintf("invalid request: %v", err)}})
+			continue
+		}
+
+		if err := c.handle(ctx, req); err != nil {
+			res := feedMessage(req)
+			res.Message = &exchangepb.FeedMessage_Error{Error: err.Error()}
+			c.send(res)
+		}
+	}
+}
+
+// handle starts or stops a subscription, and acknowledges it.
+func (c *feedConn) handle(ctx context.Context, req *exchangepb.FeedRequest) error {
+	key := feedKey{channel: req.Channel, pair: req.Pair, accountID: req.AccountId, interval: req.Interval}
+
+	switch req.Action {
+	case exchangepb.FeedRequest_SUBSCRIBE:
+		if err := c.subscribe(ctx, key, req); err != nil {
+			return err
+		}
+	case exchangepb.FeedRequest_UNSUBSCRIBE:
+		if !c.unsubscribe(key) {
+			return fmt.Errorf("not subscribed to %v", req.Channel)
+		}
+	default:
+		return fmt.Errorf("action not supported: %v", req.Action)
+	}
+
+	res := feedMessage(req)
+	res.Message = &exchangepb.FeedMessage_Ack{Ack: req.Action}
+	c.send(res)
+
+	return nil
+}
+
+// subscribe opens the stream of a subscription, and forwards its messages
+// until it ends or is unsubscribed.
+func (c *feedConn) subscribe(ctx context.Context, key feedKey, req *exchangepb.FeedRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.subscriptions[key]; ok {
+		return fmt.Errorf("already subscribed to %v", req.Channel)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	recv, err := c.gateway.openFeed(ctx, req)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("error subscribing to %v: %v", req.Channel, status.Convert(err).Message())
+	}
+	sub := &feedSubscription{cancel: cancel}
+	c.subscriptions[key] = sub
+
+	go func() {
+		defer cancel()
+
+		for {
+			msg, err := recv()
+			if err != nil {
+				// Unsubscribed, or the connection is closed.
+				if ctx.Err() != nil {
+					return
+				}
+
+				c.forget(key, sub)
+
+				res := feedMessage(req)
+				res.Message = &exchangepb.FeedMessage_Error{Error: status.Convert(err).Message()}
+				c.send(res)
+				return
+			}
+
+			c.send(msg)
+		}
+	}()
+
+	return nil
+}
+
+// unsubscribe ends a subscription, returning false if there is none.
+func (c *feedConn) unsubscribe(key feedKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub, ok := c.subscriptions[key]
+	if !ok {
+		return false
+	}
+
+	sub.cancel()
+	delete(c.subscriptions, key)
+
+	return true
+}
+
+// forget removes a subscription whose stream ended, unless it was replaced.
+func (c *feedConn) forget(key feedKey, sub *feedSubscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subscriptions[key] == sub {
+		delete(c.subscriptions, key)
+	}
+}
+
+// send writes a message to the connection. Errors are ignored, the reads of
+// the connection fail as well and end it.
+func (c *feedConn) send(msg *exchangepb.FeedMessage) {
+	text, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	websocket.Message.Send(c.ws, string(text))
+}
+
+// feedMessage returns a message of the subscription of a request.
+func feedMessage(req *exchangepb.FeedRequest) *exchangepb.FeedMessage {
+	return &exchangepb.FeedMessage{
+		Channel:   req.Channel,
+		Pair:      req.Pair,
+		AccountId: req.AccountId,
+		Interval:  req.Interval,
+	}
+}
+
+// openFeed opens the stream of the channel of a request, returning the
+// function receiving its messages.
+func (g *Gateway) openFeed(ctx context.Context, req *exchangepb.FeedRequest) (func() (*exchangepb.FeedMessage, error), error) {
+	switch req.Channel {
+	case exchangepb.FeedChannel_BOOK:
+		stream, err := g.marketData.StreamOrderBook(ctx, &exchangepb.StreamOrderBookRequest{Pair: req.Pair})
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (*exchangepb.FeedMessage, error) {
+			update, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			msg := feedMessage(req)
+			msg.Message = &exchangepb.FeedMessage_Book{Book: update}
+			return msg, nil
+		}, nil
+	case exchangepb.FeedChannel_TRADES:
+		stream, err := g.marketData.StreamTrades(ctx, &exchangepb.StreamTradesRequest{Pair: req.Pair})
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (*exchangepb.FeedMessage, error) {
+			trade, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			msg := feedMessage(req)
+			msg.Message = &exchangepb.FeedMessage_Trade{Trade: trade}
+			return msg, nil
+		}, nil
+	case exchangepb.FeedChannel_ORDERS:
+		ctx = metadata.AppendToOutgoingContext(ctx,
+			authservice.KeyHeader, req.ApiKey,
+			authservice.TimestampHeader, strconv.FormatInt(req.Timestamp, 10),
+			authservice.NonceHeader, req.Nonce,
+			authservice.SignatureHeader, req.Signature,
+		)
+
+		stream, err := g.orders.StreamOrderUpdates(ctx, &exchangepb.StreamOrderUpdatesRequest{AccountId: req.AccountId, FromSequence: req.FromSequence})
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (*exchangepb.FeedMessage, error) {
+			update, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			msg := feedMessage(req)
+			msg.Message = &exchangepb.FeedMessage_Order{Order: update}
+			return msg, nil
+		}, nil
+	case exchangepb.FeedChannel_CANDLES:
+		stream, err := g.candles.StreamCandles(ctx, &exchangepb.StreamCandlesRequest{Pair: req.Pair, Interval: req.Interval})
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (*exchangepb.FeedMessage, error) {
+			candle, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+
+			msg := feedMessage(req)
+			msg.Message = &exchangepb.FeedMessage_Candle{Candle: candle}
+			return msg, nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("channel not supported: %v", req.Channel)
+}
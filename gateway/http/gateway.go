@@ -0,0 +1,54 @@
+package httpgateway
+
+import (
+	"net/http"
+
+	exchangepb "exchange/api/v1"
+)
+
+// Gateway serves the gRPC services over HTTP, as REST endpoints with JSON
+// bodies and a WebSocket feed. Requests and responses are the messages of the
+// services, encoded as protobuf JSON.
+type Gateway struct {
+	orders exchangepb.OrdersServiceClient
+
+	marketData exchangepb.MarketDataServiceClient
+
+	candles exchangepb.CandlesServiceClient
+}
+
+func New(orders exchangepb.OrdersServiceClient, marketData exchangepb.MarketDataServiceClient, candles exchangepb.CandlesServiceClient) *Gateway {
+	return &Gateway{
+		orders:     orders,
+		marketData: marketData,
+		candles:    candles,
+	}
+}
+
+// Handler returns the handler of every endpoint of the gateway. The fields of
+// the request messages are read from the JSON body, then from the path
+// wildcards and the query parameters with the same name.
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("POST /v1/orders", unary(g.orders.CreateOrder))
+	mux.Handle("GET /v1/orders", unary(g.orders.ListOrders))
+	mux.Handle("GET /v1/orders/{order_id}", unary(g.orders.GetOrder))
+	mux.Handle("DELETE /v1/orders/{order_id}", unary(g.orders.DeleteOrder))
+	mux.Handle("PATCH /v1/orders/{order_id}", unary(g.orders.AmendOrder))
+	mux.Handle("POST /v1/order-groups", unary(g.orders.CreateOrderGroup))
+	mux.Handle("POST /v1/batch", unary(g.orders.BatchOrders))
+	mux.Handle("POST /v1/cancel-all", unary(g.orders.CancelAllOrders))
+	mux.Handle("POST /v1/sessions", unary(g.orders.OpenSession))
+	mux.Handle("POST /v1/sessions/{session_id}/heartbeat", unary(g.orders.Heartbeat))
+	mux.Handle("GET /v1/instruments", unary(g.orders.ListInstruments))
+
+	// Pairs have a slash, so they are the rest of the path.
+	mux.Handle("GET /v1/books/{pair...}", unary(g.marketData.GetOrderBook))
+	mux.Handle("GET /v1/tickers/{pair...}", unary(g.marketData.GetTicker))
+	mux.Handle("GET /v1/candles/{pair...}", unary(g.candles.GetCandles))
+
+	mux.Handle("GET /v1/feed", g.feed())
+
+	return mux
+}
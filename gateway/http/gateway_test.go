@@ -0,0 +1,413 @@
+package httpgateway
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	exchangepb "exchange/api/v1"
+	authservice "exchange/services/auth"
+	"exchange/services/ratelimit"
+)
+
+// fakeOrders answers with the fields of the requests, to check how they are
+// decoded.
+type fakeOrders struct {
+	exchangepb.UnimplementedOrdersServiceServer
+}
+
+func (f *fakeOrders) CreateOrder(ctx context.Context, req *exchangepb.CreateOrderRequest) (*exchangepb.Order, error) {
+	return req.Order, nil
+}
+
+func (f *fakeOrders) GetOrder(ctx context.Context, req *exchangepb.GetOrderRequest) (*exchangepb.Order, error) {
+	if req.OrderId == "missing" {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	if req.OrderId == "limited" {
+		grpc.SetHeader(ctx, metadata.Pairs(ratelimit.LimitHeader, "2", ratelimit.RemainingHeader, "0", ratelimit.RetryAfterHeader, "1500"))
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	// The API key and the client of the request, to check that they are
+	// forwarded.
+	md, _ := metadata.FromIncomingContext(ctx)
+	o := &exchangepb.Order{Id: req.OrderId}
+	if keys := md.Get(authservice.KeyHeader); len(keys) > 0 {
+		o.AccountId = keys[0]
+	}
+	if req.OrderId == "forwarded" {
+		o.ClientOrderId = strings.Join(md.Get(ratelimit.ForwardedForHeader), ",")
+	}
+
+	return o, nil
+}
+
+func (f *fakeOrders) AmendOrder(ctx context.Context, req *exchangepb.AmendOrderRequest) (*emptypb.Empty, error) {
+	if req.OrderId != "1" || req.Price != 10 {
+		return nil, status.Errorf(codes.InvalidArgument, "unexpected amendment %v", req)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeOrders) ListOrders(ctx context.Context, req *exchangepb.ListOrdersRequest) (*exchangepb.ListOrdersResponse, error) {
+	return &exchangepb.ListOrdersResponse{
+		Orders:        []*exchangepb.Order{{AccountId: req.AccountId, Status: req.Status}},
+		NextPageToken: req.PageToken,
+	}, nil
+}
+
+func (f *fakeOrders) StreamOrderUpdates(req *exchangepb.StreamOrderUpdatesRequest, stream exchangepb.OrdersService_StreamOrderUpdatesServer) error {
+	if err := stream.Send(&exchangepb.OrderUpdate{Sequence: req.FromSequence + 1}); err != nil {
+		return err
+	}
+
+	<-stream.Context().Done()
+	return nil
+}
+
+type fakeMarketData struct {
+	exchangepb.UnimplementedMarketDataServiceServer
+}
+
+func (f *fakeMarketData) GetOrderBook(ctx context.Context, req *exchangepb.GetOrderBookRequest) (*exchangepb.OrderBook, error) {
+	return &exchangepb.OrderBook{Pair: req.Pair, Sequence: uint64(req.Depth)}, nil
+}
+
+func (f *fakeMarketData) StreamTrades(req *exchangepb.StreamTradesRequest, stream exchangepb.MarketDataService_StreamTradesServer) error {
+	if req.Pair != "A/B" {
+		return status.Error(codes.NotFound, "pair not found")
+	}
+
+	if err := stream.Send(&exchangepb.Trade{Pair: req.Pair, Sequence: 1}); err != nil {
+		return err
+	}
+
+	<-stream.Context().Done()
+	return nil
+}
+
+type fakeCandles struct {
+	exchangepb.UnimplementedCandlesServiceServer
+}
+
+func (f *fakeCandles) GetCandles(ctx context.Context, req *exchangepb.GetCandlesRequest) (*exchangepb.GetCandlesResponse, error) {
+	return &exchangepb.GetCandlesResponse{
+		Candles: []*exchangepb.Candle{{Pair: req.Pair, Interval: req.Interval, Trades: uint64(req.Limit)}},
+	}, nil
+}
+
+func (f *fakeCandles) StreamCandles(req *exchangepb.StreamCandlesRequest, stream exchangepb.CandlesService_StreamCandlesServer) error {
+	if err := stream.Send(&exchangepb.Candle{Pair: req.Pair, Interval: req.Interval}); err != nil {
+		return err
+	}
+
+	<-stream.Context().Done()
+	return nil
+}
+
+// newTestGateway returns a gateway server in front of the fake services.
+func newTestGateway(t *testing.T) *httptest.Server {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	exchangepb.RegisterOrdersServiceServer(s, &fakeOrders{})
+	exchangepb.RegisterMarketDataServiceServer(s, &fakeMarketData{})
+	exchangepb.RegisterCandlesServiceServer(s, &fakeCandles{})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	g := New(exchangepb.NewOrdersServiceClient(conn), exchangepb.NewMarketDataServiceClient(conn), exchangepb.NewCandlesServiceClient(conn))
+	server := httptest.NewServer(g.Handler())
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_REST(t *testing.T) {
+	server := newTestGateway(t)
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		header     http.Header
+		body       string
+		wantCode   int
+		want       proto.Message
+		wantHeader http.Header
+	}{
+		{
+			name:     "create_order",
+			method:   http.MethodPost,
+			path:     "/v1/orders",
+			body:     `{"order": {"pair": "A/B", "side": "BUY", "price": "10", "volume": 5}}`,
+			wantCode: http.StatusOK,
+			want:     &exchangepb.Order{Pair: "A/B", Side: exchangepb.Side_BUY, Price: 10, Volume: 5},
+		},
+		{
+			name:     "get_order",
+			method:   http.MethodGet,
+			path:     "/v1/orders/1",
+			wantCode: http.StatusOK,
+			want:     &exchangepb.Order{Id: "1"},
+		},
+		{
+			name:     "forwarded_api_key",
+			method:   http.MethodGet,
+			path:     "/v1/orders/1",
+			header:   http.Header{"X-Api-Key": {"k1"}, "Authorization": {"other"}},
+			wantCode: http.StatusOK,
+			want:     &exchangepb.Order{Id: "1", AccountId: "k1"},
+		},
+		{
+			name:     "forwarded_client_ip",
+			method:   http.MethodGet,
+			path:     "/v1/orders/forwarded",
+			wantCode: http.StatusOK,
+			want:     &exchangepb.Order{Id: "forwarded", ClientOrderId: "127.0.0.1"},
+		},
+		{
+			name:     "rate_limited",
+			method:   http.MethodGet,
+			path:     "/v1/orders/limited",
+			wantCode: http.StatusTooManyRequests,
+			wantHeader: http.Header{
+				"X-Ratelimit-Limit":     {"2"},
+				"X-Ratelimit-Remaining": {"0"},
+				"Retry-After-Ms":        {"1500"},
+				"Retry-After":           {"2"},
+			},
+		},
+		{
+			name:     "get_missing_order",
+			method:   http.MethodGet,
+			path:     "/v1/orders/missing",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "amend_order",
+			method:   http.MethodPatch,
+			path:     "/v1/orders/1",
+			body:     `{"price": 10}`,
+			wantCode: http.StatusOK,
+			want:     &emptypb.Empty{},
+		},
+		{
+			name:     "list_orders",
+			method:   http.MethodGet,
+			path:     "/v1/orders?account_id=alice&status=FILLED&pageToken=7",
+			wantCode: http.StatusOK,
+			want: &exchangepb.ListOrdersResponse{
+				Orders:        []*exchangepb.Order{{AccountId: "alice", Status: exchangepb.Order_FILLED}},
+				NextPageToken: "7",
+			},
+		},
+		{
+			name:     "unknown_query_parameter",
+			method:   http.MethodGet,
+			path:     "/v1/orders?owner=alice",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid_query_parameter",
+			method:   http.MethodGet,
+			path:     "/v1/orders?page_size=-1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid_body",
+			method:   http.MethodPost,
+			path:     "/v1/orders",
+			body:     `{"order": 1}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "book",
+			method:   http.MethodGet,
+			path:     "/v1/books/A/B?depth=3",
+			wantCode: http.StatusOK,
+			want:     &exchangepb.OrderBook{Pair: "A/B", Sequence: 3},
+		},
+		{
+			name:     "candles",
+			method:   http.MethodGet,
+			path:     "/v1/candles/A/B?interval=FIVE_MINUTES&limit=2",
+			wantCode: http.StatusOK,
+			want: &exchangepb.GetCandlesResponse{
+				Candles: []*exchangepb.Candle{{Pair: "A/B", Interval: exchangepb.CandleInterval_FIVE_MINUTES, Trades: 2}},
+			},
+		},
+		{
+			name:     "unimplemented",
+			method:   http.MethodGet,
+			path:     "/v1/instruments",
+			wantCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("NewRequest() unexpected error: %v", err)
+			}
+			for name, values := range tc.header {
+				req.Header[name] = values
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do() unexpected error: %v", err)
+			}
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() unexpected error: %v", err)
+			}
+
+			if res.StatusCode != tc.wantCode {
+				t.Fatalf("%s %s got status %d, want %d, body: %s", tc.method, tc.path, res.StatusCode, tc.wantCode, body)
+			}
+
+			for name, want := range tc.wantHeader {
+				if got := res.Header[name]; !cmp.Equal(want, got) {
+					t.Errorf("%s %s got header %s %v, want %v", tc.method, tc.path, name, got, want)
+				}
+			}
+
+			if tc.want == nil {
+				return
+			}
+
+			got := tc.want.ProtoReflect().New().Interface()
+			if err := protojson.Unmarshal(body, got); err != nil {
+				t.Fatalf("Unmarshal() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("%s %s unexpected response (-want +got):\n%s", tc.method, tc.path, diff)
+			}
+		})
+	}
+}
+
+func Test_Feed(t *testing.T) {
+	server := newTestGateway(t)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/v1/feed", "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial() unexpected error: %v", err)
+	}
+	defer ws.Close()
+
+	send := func(req *exchangepb.FeedRequest) {
+		text, err := protojson.Marshal(req)
+		if err != nil {
+			t.Fatalf("Marshal() unexpected error: %v", err)
+		}
+
+		if err := websocket.Message.Send(ws, string(text)); err != nil {
+			t.Fatalf("Send() unexpected error: %v", err)
+		}
+	}
+
+	receive := func() *exchangepb.FeedMessage {
+		var text string
+		if err := websocket.Message.Receive(ws, &text); err != nil {
+			t.Fatalf("Receive() unexpected error: %v", err)
+		}
+
+		msg := &exchangepb.FeedMessage{}
+		if err := protojson.Unmarshal([]byte(text), msg); err != nil {
+			t.Fatalf("Unmarshal() unexpected error: %v", err)
+		}
+		return msg
+	}
+
+	trades := &exchangepb.FeedRequest{Action: exchangepb.FeedRequest_SUBSCRIBE, Channel: exchangepb.FeedChannel_TRADES, Pair: "A/B"}
+	send(trades)
+
+	want := []*exchangepb.FeedMessage{
+		{Channel: exchangepb.FeedChannel_TRADES, Pair: "A/B", Message: &exchangepb.FeedMessage_Ack{Ack: exchangepb.FeedRequest_SUBSCRIBE}},
+		{Channel: exchangepb.FeedChannel_TRADES, Pair: "A/B", Message: &exchangepb.FeedMessage_Trade{Trade: &exchangepb.Trade{Pair: "A/B", Sequence: 1}}},
+	}
+	got := []*exchangepb.FeedMessage{receive(), receive()}
+
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("feed subscribe unexpected messages (-want +got):\n%s", diff)
+	}
+
+	// The subscriptions of a connection are multiplexed.
+	send(&exchangepb.FeedRequest{Action: exchangepb.FeedRequest_SUBSCRIBE, Channel: exchangepb.FeedChannel_ORDERS, AccountId: "alice", FromSequence: 4})
+
+	want = []*exchangepb.FeedMessage{
+		{Channel: exchangepb.FeedChannel_ORDERS, AccountId: "alice", Message: &exchangepb.FeedMessage_Ack{Ack: exchangepb.FeedRequest_SUBSCRIBE}},
+		{Channel: exchangepb.FeedChannel_ORDERS, AccountId: "alice", Message: &exchangepb.FeedMessage_Order{Order: &exchangepb.OrderUpdate{Sequence: 5}}},
+	}
+	got = []*exchangepb.FeedMessage{receive(), receive()}
+
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("feed second subscribe unexpected messages (-want +got):\n%s", diff)
+	}
+
+	send(trades)
+	if msg := receive(); msg.GetError() == "" {
+		t.Errorf("feed subscribe twice got %v, want an error", msg)
+	}
+
+	send(&exchangepb.FeedRequest{Action: exchangepb.FeedRequest_UNSUBSCRIBE, Channel: exchangepb.FeedChannel_TRADES, Pair: "A/B"})
+	if msg := receive(); msg.GetAck() != exchangepb.FeedRequest_UNSUBSCRIBE {
+		t.Errorf("feed unsubscribe got %v, want an ack", msg)
+	}
+
+	send(&exchangepb.FeedRequest{Action: exchangepb.FeedRequest_SUBSCRIBE, Channel: exchangepb.FeedChannel_CANDLES, Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_HOUR})
+
+	want = []*exchangepb.FeedMessage{
+		{Channel: exchangepb.FeedChannel_CANDLES, Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_HOUR, Message: &exchangepb.FeedMessage_Ack{Ack: exchangepb.FeedRequest_SUBSCRIBE}},
+		{Channel: exchangepb.FeedChannel_CANDLES, Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_HOUR, Message: &exchangepb.FeedMessage_Candle{Candle: &exchangepb.Candle{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_HOUR}}},
+	}
+	got = []*exchangepb.FeedMessage{receive(), receive()}
+
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("feed candles subscribe unexpected messages (-want +got):\n%s", diff)
+	}
+
+	// Streams failing end their subscription with an error.
+	send(&exchangepb.FeedRequest{Action: exchangepb.FeedRequest_SUBSCRIBE, Channel: exchangepb.FeedChannel_TRADES, Pair: "C/D"})
+	if msg := receive(); msg.GetAck() != exchangepb.FeedRequest_SUBSCRIBE {
+		t.Errorf("feed subscribe got %v, want an ack", msg)
+	}
+
+	if msg := receive(); msg.GetError() == "" || msg.Pair != "C/D" {
+		t.Errorf("feed subscribe to unknown pair got %v, want an error", msg)
+	}
+}
@@ -0,0 +1,253 @@
+package httpgateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	authservice "exchange/services/auth"
+	"exchange/services/ratelimit"
+)
+
+// The largest request body accepted.
+const maxBodySize = 1 << 20
+
+// Responses use the field names of the protos, like path wildcards and query
+// parameters.
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// unary returns the handler of a unary RPC, which calls it with the request
+// message of the HTTP request, and writes its response as JSON.
+func unary[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Res proto.Message](call func(context.Context, PReq, ...grpc.CallOption) (Res, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := PReq(new(Req))
+		if err := decodeRequest(r, req); err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		var header metadata.MD
+		res, err := call(outgoingContext(r), req, grpc.Header(&header))
+		writeHeaders(w, header)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeMessage(w, http.StatusOK, res)
+	})
+}
+
+// authHeaders are the headers of the requests forwarded to the services, to
+// authenticate them.
+var authHeaders = []string{
+	authservice.KeyHeader,
+	authservice.TimestampHeader,
+	authservice.NonceHeader,
+	authservice.SignatureHeader,
+}
+
+// outgoingContext returns the context of the call of a request, with its
+// authentication headers and the IP of its client as metadata.
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, header := range authHeaders {
+		if value := r.Header.Get(header); value != "" {
+			md.Set(header, value)
+		}
+	}
+
+	if ip := clientIP(r); ip != "" {
+		md.Set(ratelimit.ForwardedForHeader, ip)
+	}
+
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// clientIP returns the IP of the client of a request, which limits the rate of
+// its public requests.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return ""
+	}
+
+	return host
+}
+
+// rateLimitHeaders are the headers of the responses of the services written to
+// the HTTP responses, to report the usage of the rate limits.
+var rateLimitHeaders = []string{
+	ratelimit.LimitHeader,
+	ratelimit.RemainingHeader,
+	ratelimit.ResetHeader,
+	ratelimit.RetryAfterHeader,
+}
+
+// writeHeaders writes the rate limit headers of a response, and its
+// retry-after-ms as a Retry-After in seconds.
+func writeHeaders(w http.ResponseWriter, header metadata.MD) {
+	for _, name := range rateLimitHeaders {
+		if values := header.Get(name); len(values) > 0 {
+			w.Header().Set(name, values[0])
+		}
+	}
+
+	if values := header.Get(ratelimit.RetryAfterHeader); len(values) > 0 {
+		if ms, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+			w.Header().Set("Retry-After", strconv.FormatInt((ms+999)/1000, 10))
+		}
+	}
+}
+
+// decodeRequest sets the fields of req from the JSON body of the request,
+// then from its path wildcards and query parameters.
+func decodeRequest(r *http.Request, req proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	if err != nil {
+		return fmt.Errorf("error reading body: %w", err)
+	}
+
+	if len(body) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			return fmt.Errorf("invalid body: %w", err)
+		}
+	}
+
+	m := req.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if value := r.PathValue(string(fd.Name())); value != "" {
+			if err := setField(m, fd, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, values := range r.URL.Query() {
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = fields.ByJSONName(name)
+		}
+
+		if fd == nil {
+			return fmt.Errorf("unknown query parameter %q", name)
+		}
+
+		if err := setField(m, fd, values[len(values)-1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setField sets a scalar field from its text. Enums are given by name or
+// number.
+func setField(m protoreflect.Message, fd protoreflect.FieldDescriptor, text string) error {
+	if fd.Cardinality() == protoreflect.Repeated {
+		return fmt.Errorf("field %q cannot be set from the URL", fd.Name())
+	}
+
+	var value protoreflect.Value
+	var err error
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		value = protoreflect.ValueOfString(text)
+	case protoreflect.BoolKind:
+		var b bool
+		b, err = strconv.ParseBool(text)
+		value = protoreflect.ValueOfBool(b)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var n uint64
+		n, err = strconv.ParseUint(text, 10, 32)
+		value = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var n uint64
+		n, err = strconv.ParseUint(text, 10, 64)
+		value = protoreflect.ValueOfUint64(n)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var n int64
+		n, err = strconv.ParseInt(text, 10, 32)
+		value = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var n int64
+		n, err = strconv.ParseInt(text, 10, 64)
+		value = protoreflect.ValueOfInt64(n)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(text)); ev != nil {
+			value = protoreflect.ValueOfEnum(ev.Number())
+		} else {
+			var n int64
+			n, err = strconv.ParseInt(text, 10, 32)
+			value = protoreflect.ValueOfEnum(protoreflect.EnumNumber(n))
+		}
+	default:
+		return fmt.Errorf("field %q cannot be set from the URL", fd.Name())
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %q: %q", fd.Name(), text)
+	}
+
+	m.Set(fd, value)
+	return nil
+}
+
+// httpStatuses are the HTTP statuses of the gRPC codes, 500 if missing.
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+}
+
+// writeError writes the status of a gRPC error as JSON, with the HTTP status
+// of its code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	code, ok := httpStatuses[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+
+	writeMessage(w, code, st.Proto())
+}
+
+func writeMessage(w http.ResponseWriter, code int, m proto.Message) {
+	body, err := marshalOptions.Marshal(m)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error serializing response: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
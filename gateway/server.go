@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	exchangepb "exchange/api/v1"
+	httpgateway "exchange/gateway/http"
+)
+
+func main() {
+	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to the services: %v", err)
+	}
+	defer conn.Close()
+
+	gateway := httpgateway.New(
+		exchangepb.NewOrdersServiceClient(conn),
+		exchangepb.NewMarketDataServiceClient(conn),
+		exchangepb.NewCandlesServiceClient(conn),
+	)
+
+	log.Println("HTTP gateway is listening on port 8080...")
+	if err := http.ListenAndServe(":8080", gateway.Handler()); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
@@ -44,6 +44,14 @@ func (p *Printer) processMessage(record *kgo.Record) error {
 		}
 
 		log.Printf("match event: %v\n", matchEvent)
+	case "depth":
+		depthEvent := &enginepb.DepthEvent{}
+		err := proto.Unmarshal(record.Value, depthEvent)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("depth event: %v\n", depthEvent)
 	default:
 		return fmt.Errorf("Unsupported topic suffix %q", parts[3])
 	}
@@ -35,6 +35,7 @@ func NewPrinter(markets []engineserver.MarketSymbol) (*Printer, error) {
 			market.Topic()+".orders",
 			market.Topic()+".volumes",
 			market.Topic()+".matches",
+			market.Topic()+".depth",
 		)
 	}
 
@@ -0,0 +1,132 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	"exchange/services/orders/storage"
+)
+
+type AdminService struct {
+	exchangepb.UnimplementedAdminServiceServer
+
+	keys Keys
+}
+
+func NewAdminService(keys Keys) *AdminService {
+	return &AdminService{keys: keys}
+}
+
+// requireAdmin checks that the caller of a request has an ADMIN key.
+func requireAdmin(ctx context.Context) error {
+	if caller, ok := CallerFromContext(ctx); !ok || !caller.Admin {
+		return status.Error(codes.PermissionDenied, "an admin API key is required")
+	}
+
+	return nil
+}
+
+// newAPIKey stores a new API key for the account, with a random ID and
+// secret.
+func newAPIKey(keys Keys, accountID string, role exchangepb.APIKey_Role) (*exchangepb.APIKey, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("error generating API key id: %w", err)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("error generating API key secret: %w", err)
+	}
+
+	k := &exchangepb.APIKey{
+		Id:        id,
+		AccountId: accountID,
+		Role:      role,
+		Secret:    secret,
+		CreatedAt: timestamppb.New(time.Now()),
+	}
+
+	if err := keys.CreateAPIKey(k); err != nil {
+		return nil, fmt.Errorf("error storing API key: %w", err)
+	}
+
+	return k, nil
+}
+
+// EnsureAdminKey creates an ADMIN key for the account if there is no ADMIN
+// key yet, so that the first keys can be created. It returns the created key
+// with its secret, or nil if there was one.
+func EnsureAdminKey(keys Keys, accountID string) (*exchangepb.APIKey, error) {
+	all, err := keys.ListAPIKeys("")
+	if err != nil {
+		return nil, fmt.Errorf("error listing API keys: %w", err)
+	}
+
+	for _, k := range all {
+		if k.Role == exchangepb.APIKey_ADMIN {
+			return nil, nil
+		}
+	}
+
+	return newAPIKey(keys, accountID, exchangepb.APIKey_ADMIN)
+}
+
+// CreateAPIKey returns a new API key for the account, the only response with
+// its secret.
+func (s *AdminService) CreateAPIKey(ctx context.Context, req *exchangepb.CreateAPIKeyRequest) (*exchangepb.APIKey, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account id is required")
+	}
+
+	role := req.Role
+	if role == exchangepb.APIKey_ROLE_UNSPECIFIED {
+		role = exchangepb.APIKey_TRADER
+	}
+
+	return newAPIKey(s.keys, req.AccountId, role)
+}
+
+func (s *AdminService) ListAPIKeys(ctx context.Context, req *exchangepb.ListAPIKeysRequest) (*exchangepb.ListAPIKeysResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.keys.ListAPIKeys(req.AccountId)
+	if err != nil {
+		return nil, fmt.Errorf("error listing API keys: %w", err)
+	}
+
+	for _, k := range keys {
+		k.Secret = ""
+	}
+
+	return &exchangepb.ListAPIKeysResponse{Keys: keys}, nil
+}
+
+func (s *AdminService) DeleteAPIKey(ctx context.Context, req *exchangepb.DeleteAPIKeyRequest) (*emptypb.Empty, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	err := s.keys.DeleteAPIKey(req.Id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "API key %q not found", req.Id)
+	} else if err != nil {
+		return nil, fmt.Errorf("error deleting API key: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
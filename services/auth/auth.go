@@ -0,0 +1,261 @@
+// Package authservice authenticates the requests of the services with API
+// keys, and manages the keys through the AdminService.
+package authservice
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	exchangepb "exchange/api/v1"
+	"exchange/services/orders/storage"
+)
+
+// Keys is where the API keys are stored.
+type Keys interface {
+	CreateAPIKey(k *exchangepb.APIKey) error
+
+	GetAPIKey(keyID string) (*exchangepb.APIKey, error)
+
+	ListAPIKeys(accountID string) ([]*exchangepb.APIKey, error)
+
+	DeleteAPIKey(keyID string) error
+}
+
+// publicMethods are the prefixes of the methods callable without an API key:
+// market data, candles and listings are public.
+var publicMethods = []string{
+	"/" + exchangepb.MarketDataService_ServiceDesc.ServiceName + "/",
+	"/" + exchangepb.CandlesService_ServiceDesc.ServiceName + "/",
+	exchangepb.OrdersService_ListInstruments_FullMethodName,
+	"/grpc.reflection.",
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Caller is the authenticated client of a request.
+type Caller struct {
+	KeyID string
+
+	AccountID string
+
+	// Whether the caller acts for every account.
+	Admin bool
+}
+
+type callerKey struct{}
+
+// NewContext returns a context with the caller of a request.
+func NewContext(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller of a request authenticated by the
+// interceptors of an Authenticator.
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*Caller)
+	return caller, ok
+}
+
+// Authenticator verifies the signatures of the requests, see Sign, and adds
+// their caller to their context.
+type Authenticator struct {
+	keys Keys
+
+	// How far the timestamp of a request may be from the clock of the service.
+	window time.Duration
+
+	nonces *nonces
+
+	now func() time.Time
+}
+
+// NewAuthenticator returns an authenticator accepting requests signed within
+// window of its clock.
+func NewAuthenticator(keys Keys, window time.Duration) *Authenticator {
+	return &Authenticator{
+		keys:   keys,
+		window: window,
+		// A nonce can be replayed as long as its timestamp is accepted.
+		nonces: newNonces(2 * window),
+		now:    time.Now,
+	}
+}
+
+// authenticate returns the caller of a signed request, or an Unauthenticated
+// error.
+func (a *Authenticator) authenticate(ctx context.Context, method string, req proto.Message) (*Caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	keyID, nonce, signature := get(KeyHeader), get(NonceHeader), get(SignatureHeader)
+	if keyID == "" || nonce == "" || signature == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "%s, %s, %s and %s are required", KeyHeader, TimestampHeader, NonceHeader, SignatureHeader)
+	}
+
+	timestamp, err := strconv.ParseInt(get(TimestampHeader), 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid %s", TimestampHeader)
+	}
+
+	now := a.now()
+	if diff := now.Sub(time.UnixMilli(timestamp)); diff > a.window || diff < -a.window {
+		return nil, status.Errorf(codes.Unauthenticated, "%s outside of the %v window", TimestampHeader, a.window)
+	}
+
+	k, err := a.keys.GetAPIKey(keyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "unknown API key")
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting API key: %v", err)
+	}
+
+	want, err := Sign(k.Secret, method, timestamp, nonce, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error signing request: %v", err)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		return nil, status.Error(codes.Unauthenticated, "invalid signature")
+	}
+
+	// Checked last, so that only signed requests use up nonces.
+	if !a.nonces.add(keyID+"\x00"+nonce, now) {
+		return nil, status.Errorf(codes.Unauthenticated, "%s already used", NonceHeader)
+	}
+
+	return &Caller{
+		KeyID:     k.Id,
+		AccountID: k.AccountId,
+		Admin:     k.Role == exchangepb.APIKey_ADMIN,
+	}, nil
+}
+
+// UnaryInterceptor authenticates the unary requests.
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isPublic(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	caller, err := a.authenticate(ctx, info.FullMethod, req.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(NewContext(ctx, caller), req)
+}
+
+// StreamInterceptor authenticates the server streaming requests when their
+// request is received. Client streams are not supported.
+func (a *Authenticator) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublic(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	if info.IsClientStream {
+		return status.Errorf(codes.Unimplemented, "client streaming method %q cannot be authenticated", info.FullMethod)
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: ss, authenticator: a, method: info.FullMethod, ctx: ss.Context()})
+}
+
+// authenticatedStream authenticates the request of a server stream, and adds
+// its caller to the context of the stream.
+type authenticatedStream struct {
+	grpc.ServerStream
+
+	authenticator *Authenticator
+
+	method string
+
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authenticatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	caller, err := s.authenticator.authenticate(s.ctx, s.method, m.(proto.Message))
+	if err != nil {
+		return err
+	}
+	s.ctx = NewContext(s.ctx, caller)
+
+	return nil
+}
+
+// nonce is a nonce used by a key, at the time it was used.
+type nonce struct {
+	key string
+	at  time.Time
+}
+
+// nonces are the nonces used by the keys within a window, to reject the
+// requests replaying them. They only live in memory, the requests signed
+// before a restart are rejected by their timestamp once the window passes.
+type nonces struct {
+	mu sync.Mutex
+
+	window time.Duration
+
+	seen map[string]bool
+
+	// The nonces in seen, oldest first.
+	queue []nonce
+}
+
+func newNonces(window time.Duration) *nonces {
+	return &nonces{
+		window: window,
+		seen:   map[string]bool{},
+	}
+}
+
+// add records a nonce used at now, returning false if it was already used
+// within the window.
+func (n *nonces) add(key string, now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for len(n.queue) > 0 && now.Sub(n.queue[0].at) > n.window {
+		delete(n.seen, n.queue[0].key)
+		n.queue = n.queue[1:]
+	}
+
+	if n.seen[key] {
+		return false
+	}
+
+	n.seen[key] = true
+	n.queue = append(n.queue, nonce{key: key, at: now})
+
+	return true
+}
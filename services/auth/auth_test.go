@@ -0,0 +1,313 @@
+package authservice
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	exchangepb "exchange/api/v1"
+	"exchange/services/orders/storage"
+)
+
+func Test_UnaryInterceptor(t *testing.T) {
+	now := time.Now()
+	keys := storage.NewMemory()
+	if err := keys.CreateAPIKey(&exchangepb.APIKey{Id: "k1", AccountId: "alice", Role: exchangepb.APIKey_TRADER, Secret: "secret"}); err != nil {
+		t.Fatalf("CreateAPIKey() unexpected error: %v", err)
+	}
+
+	a := NewAuthenticator(keys, 30*time.Second)
+	a.now = func() time.Time { return now }
+
+	method := exchangepb.OrdersService_GetOrder_FullMethodName
+	req := &exchangepb.GetOrderRequest{OrderId: "1"}
+
+	// signed returns the metadata of the request signed by k1.
+	signed := func(method string, timestamp time.Time, nonce string, secret string, req proto.Message) metadata.MD {
+		signature, err := Sign(secret, method, timestamp.UnixMilli(), nonce, req)
+		if err != nil {
+			t.Fatalf("Sign() unexpected error: %v", err)
+		}
+
+		return metadata.Pairs(
+			KeyHeader, "k1",
+			TimestampHeader, strconv.FormatInt(timestamp.UnixMilli(), 10),
+			NonceHeader, nonce,
+			SignatureHeader, signature,
+		)
+	}
+
+	testCases := []struct {
+		name       string
+		method     string
+		md         metadata.MD
+		wantCode   codes.Code
+		wantCaller *Caller
+	}{
+		{
+			name:       "signed",
+			method:     method,
+			md:         signed(method, now, "n1", "secret", req),
+			wantCode:   codes.OK,
+			wantCaller: &Caller{KeyID: "k1", AccountID: "alice"},
+		},
+		{
+			name:     "replayed_nonce",
+			method:   method,
+			md:       signed(method, now, "n1", "secret", req),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:       "in_window",
+			method:     method,
+			md:         signed(method, now.Add(-20*time.Second), "n2", "secret", req),
+			wantCode:   codes.OK,
+			wantCaller: &Caller{KeyID: "k1", AccountID: "alice"},
+		},
+		{
+			name:     "expired",
+			method:   method,
+			md:       signed(method, now.Add(-time.Minute), "n3", "secret", req),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "future",
+			method:   method,
+			md:       signed(method, now.Add(time.Minute), "n4", "secret", req),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "wrong_secret",
+			method:   method,
+			md:       signed(method, now, "n5", "other", req),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "other_request",
+			method:   method,
+			md:       signed(method, now, "n6", "secret", &exchangepb.GetOrderRequest{OrderId: "2"}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "other_method",
+			method:   method,
+			md:       signed(exchangepb.OrdersService_DeleteOrder_FullMethodName, now, "n7", "secret", req),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "unsigned",
+			method:   method,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "public",
+			method:   exchangepb.MarketDataService_GetOrderBook_FullMethodName,
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+
+			var gotCaller *Caller
+			handler := func(ctx context.Context, req any) (any, error) {
+				gotCaller, _ = CallerFromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := a.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("UnaryInterceptor() got code %v, want %v, error: %v", got, tc.wantCode, err)
+			}
+
+			if tc.wantCaller != nil && (gotCaller == nil || *gotCaller != *tc.wantCaller) {
+				t.Errorf("UnaryInterceptor() got caller %+v, want %+v", gotCaller, tc.wantCaller)
+			}
+		})
+	}
+}
+
+// Test_Sign checks a signature computed without protobuf, as by a client of the
+// JSON gateway: the request is encoded by hand.
+func Test_Sign(t *testing.T) {
+	req := &exchangepb.DeleteOrderRequest{}
+	if err := protojson.Unmarshal([]byte(`{"order_id": "1"}`), req); err != nil {
+		t.Fatalf("protojson.Unmarshal() unexpected error: %v", err)
+	}
+
+	got, err := Sign("secret", exchangepb.OrdersService_DeleteOrder_FullMethodName, 1700000000000, "n", req)
+	if err != nil {
+		t.Fatalf("Sign() unexpected error: %v", err)
+	}
+
+	// The encoding of the request is 0a 01 31: order_id, field 1, a string of
+	// length 1.
+	if want := "bcdcf3a4e82e30e48feffe134976c2a777f3ae2ef9a66358326692282a27ce2a"; got != want {
+		t.Errorf("Sign() got %s, want %s", got, want)
+	}
+}
+
+func Test_Nonces(t *testing.T) {
+	start := time.Now()
+	n := newNonces(time.Minute)
+
+	if !n.add("a", start) {
+		t.Errorf("add() new nonce want true, got false")
+	}
+
+	if n.add("a", start.Add(time.Minute)) {
+		t.Errorf("add() nonce within the window want false, got true")
+	}
+
+	if !n.add("a", start.Add(time.Minute+time.Second)) {
+		t.Errorf("add() nonce after the window want true, got false")
+	}
+}
+
+func Test_AdminService(t *testing.T) {
+	keys := storage.NewMemory()
+	s := NewAdminService(keys)
+
+	admin, err := EnsureAdminKey(keys, "root")
+	if err != nil || admin == nil || admin.Role != exchangepb.APIKey_ADMIN || admin.Secret == "" {
+		t.Fatalf("EnsureAdminKey() got %v, error %v, want a new admin key", admin, err)
+	}
+
+	if again, err := EnsureAdminKey(keys, "root"); err != nil || again != nil {
+		t.Errorf("EnsureAdminKey() with an admin key got %v, error %v, want none", again, err)
+	}
+
+	adminCtx := NewContext(context.Background(), &Caller{KeyID: admin.Id, AccountID: "root", Admin: true})
+	traderCtx := NewContext(context.Background(), &Caller{KeyID: "k", AccountID: "alice"})
+
+	if _, err := s.CreateAPIKey(traderCtx, &exchangepb.CreateAPIKeyRequest{AccountId: "alice"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreateAPIKey() by a trader got %v, want PermissionDenied", err)
+	}
+
+	k, err := s.CreateAPIKey(adminCtx, &exchangepb.CreateAPIKeyRequest{AccountId: "alice"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() unexpected error: %v", err)
+	}
+
+	if k.Role != exchangepb.APIKey_TRADER || k.Secret == "" || k.Id == "" {
+		t.Errorf("CreateAPIKey() got %v, want a trader key with a secret", k)
+	}
+
+	listed, err := s.ListAPIKeys(adminCtx, &exchangepb.ListAPIKeysRequest{AccountId: "alice"})
+	if err != nil {
+		t.Fatalf("ListAPIKeys() unexpected error: %v", err)
+	}
+
+	if len(listed.Keys) != 1 || listed.Keys[0].Id != k.Id || listed.Keys[0].Secret != "" {
+		t.Errorf("ListAPIKeys() got %v, want key %q without its secret", listed.Keys, k.Id)
+	}
+
+	if _, err := s.DeleteAPIKey(adminCtx, &exchangepb.DeleteAPIKeyRequest{Id: k.Id}); err != nil {
+		t.Fatalf("DeleteAPIKey() unexpected error: %v", err)
+	}
+
+	if _, err := s.DeleteAPIKey(adminCtx, &exchangepb.DeleteAPIKeyRequest{Id: k.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteAPIKey() twice got %v, want NotFound", err)
+	}
+}
+
+// callerOrders answers with the account of the caller of the requests.
+type callerOrders struct {
+	exchangepb.UnimplementedOrdersServiceServer
+}
+
+func (s *callerOrders) GetOrder(ctx context.Context, req *exchangepb.GetOrderRequest) (*exchangepb.Order, error) {
+	caller, _ := CallerFromContext(ctx)
+	return &exchangepb.Order{Id: req.OrderId, AccountId: caller.AccountID}, nil
+}
+
+func (s *callerOrders) StreamOrderUpdates(req *exchangepb.StreamOrderUpdatesRequest, stream exchangepb.OrdersService_StreamOrderUpdatesServer) error {
+	caller, _ := CallerFromContext(stream.Context())
+	return stream.Send(&exchangepb.OrderUpdate{Order: &exchangepb.Order{AccountId: caller.AccountID}})
+}
+
+func Test_ClientInterceptors(t *testing.T) {
+	keys := storage.NewMemory()
+	if err := keys.CreateAPIKey(&exchangepb.APIKey{Id: "k1", AccountId: "alice", Role: exchangepb.APIKey_TRADER, Secret: "secret"}); err != nil {
+		t.Fatalf("CreateAPIKey() unexpected error: %v", err)
+	}
+	a := NewAuthenticator(keys, 30*time.Second)
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(a.UnaryInterceptor), grpc.StreamInterceptor(a.StreamInterceptor))
+	exchangepb.RegisterOrdersServiceServer(server, &callerOrders{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	dial := func(opts ...grpc.DialOption) exchangepb.OrdersServiceClient {
+		opts = append(opts,
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+
+		conn, err := grpc.NewClient("passthrough:///bufconn", opts...)
+		if err != nil {
+			t.Fatalf("NewClient() unexpected error: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		return exchangepb.NewOrdersServiceClient(conn)
+	}
+
+	client := dial(
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor("k1", "secret")),
+		grpc.WithStreamInterceptor(StreamClientInterceptor("k1", "secret")),
+	)
+	ctx := context.Background()
+
+	o, err := client.GetOrder(ctx, &exchangepb.GetOrderRequest{OrderId: "1"})
+	if err != nil {
+		t.Fatalf("GetOrder() unexpected error: %v", err)
+	}
+
+	if o.AccountId != "alice" {
+		t.Errorf("GetOrder() got caller account %q, want %q", o.AccountId, "alice")
+	}
+
+	stream, err := client.StreamOrderUpdates(ctx, &exchangepb.StreamOrderUpdatesRequest{})
+	if err != nil {
+		t.Fatalf("StreamOrderUpdates() unexpected error: %v", err)
+	}
+
+	update, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() unexpected error: %v", err)
+	}
+
+	if update.Order.AccountId != "alice" {
+		t.Errorf("StreamOrderUpdates() got caller account %q, want %q", update.Order.AccountId, "alice")
+	}
+
+	// Without the interceptors, requests are rejected.
+	unsigned := dial()
+
+	if _, err := unsigned.GetOrder(ctx, &exchangepb.GetOrderRequest{OrderId: "1"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("GetOrder() unsigned got %v, want Unauthenticated", err)
+	}
+
+	stream, err = unsigned.StreamOrderUpdates(ctx, &exchangepb.StreamOrderUpdatesRequest{})
+	if err != nil {
+		t.Fatalf("StreamOrderUpdates() unexpected error: %v", err)
+	}
+
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("StreamOrderUpdates() unsigned got %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,89 @@
+package candlesservice
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+)
+
+// intervals are the durations of the candle intervals, every trade is
+// aggregated into a candle of each.
+var intervals = map[exchangepb.CandleInterval]time.Duration{
+	exchangepb.CandleInterval_ONE_MINUTE:   time.Minute,
+	exchangepb.CandleInterval_FIVE_MINUTES: 5 * time.Minute,
+	exchangepb.CandleInterval_ONE_HOUR:     time.Hour,
+	exchangepb.CandleInterval_ONE_DAY:      24 * time.Hour,
+}
+
+// trade is a match of the matches topic of a pair.
+type trade struct {
+	pair string
+
+	// The offset of the match in the topic plus one.
+	sequence uint64
+
+	price uint64
+
+	volume uint64
+
+	time time.Time
+}
+
+// intervalStart returns the start of the interval of a time, in UTC.
+func intervalStart(t time.Time, d time.Duration) time.Time {
+	// Truncate rounds since the zero time, which starts a UTC day.
+	return t.UTC().Truncate(d)
+}
+
+// addTrade aggregates a trade into the candle of its interval. The trades are
+// added in the order of their sequences, so a late trade, matched after the
+// trades of later intervals, is the close of the candle of its time.
+func addTrade(c *exchangepb.Candle, t trade) {
+	if c.Trades == 0 {
+		c.Open, c.High, c.Low, c.FirstSequence = t.price, t.price, t.price, t.sequence
+	}
+	c.Close, c.LastSequence = t.price, t.sequence
+
+	c.High = max(c.High, t.price)
+	c.Low = min(c.Low, t.price)
+
+	c.Volume += t.volume
+	c.Trades++
+}
+
+// emptyCandle returns the candle of an interval without trades after the
+// candle prev.
+func emptyCandle(prev *exchangepb.Candle, start time.Time) *exchangepb.Candle {
+	return &exchangepb.Candle{
+		Pair:     prev.Pair,
+		Interval: prev.Interval,
+		Start:    timestamppb.New(start),
+		Open:     prev.Close,
+		High:     prev.Close,
+		Low:      prev.Close,
+		Close:    prev.Close,
+	}
+}
+
+// fillGaps returns the candles of every interval from start until before end,
+// with empty candles for the intervals missing from candles, which are sorted
+// by start. The intervals before the first candle are skipped, unless there is
+// a candle prev before them.
+func fillGaps(prev *exchangepb.Candle, candles []*exchangepb.Candle, d time.Duration, start time.Time, end time.Time) []*exchangepb.Candle {
+	var res []*exchangepb.Candle
+	for t := start; t.Before(end); t = t.Add(d) {
+		if len(candles) > 0 && !candles[0].Start.AsTime().After(t) {
+			prev, candles = candles[0], candles[1:]
+			res = append(res, prev)
+			continue
+		}
+
+		if prev != nil {
+			res = append(res, emptyCandle(prev, t))
+		}
+	}
+
+	return res
+}
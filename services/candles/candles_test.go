@@ -0,0 +1,112 @@
+package candlesservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+)
+
+func Test_AddTrade(t *testing.T) {
+	testCases := []struct {
+		name   string
+		trades []trade
+		want   *exchangepb.Candle
+	}{
+		{
+			name:   "first",
+			trades: []trade{{sequence: 3, price: 10, volume: 2}},
+			want:   &exchangepb.Candle{Open: 10, High: 10, Low: 10, Close: 10, Volume: 2, Trades: 1, FirstSequence: 3, LastSequence: 3},
+		},
+		{
+			name: "in_order",
+			trades: []trade{
+				{sequence: 3, price: 10, volume: 2},
+				{sequence: 4, price: 12, volume: 1},
+				{sequence: 5, price: 8, volume: 1},
+				{sequence: 6, price: 11, volume: 3},
+			},
+			want: &exchangepb.Candle{Open: 10, High: 12, Low: 8, Close: 11, Volume: 7, Trades: 4, FirstSequence: 3, LastSequence: 6},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := &exchangepb.Candle{}
+			for _, trade := range tc.trades {
+				addTrade(got, trade)
+			}
+
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("addTrade() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_FillGaps(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(time.Duration(minutes) * time.Minute))
+	}
+
+	candle := func(minutes int, open uint64, close uint64) *exchangepb.Candle {
+		return &exchangepb.Candle{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Start: at(minutes), Open: open, High: max(open, close), Low: min(open, close), Close: close, Volume: 1, Trades: 2}
+	}
+
+	empty := func(minutes int, price uint64) *exchangepb.Candle {
+		return &exchangepb.Candle{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Start: at(minutes), Open: price, High: price, Low: price, Close: price}
+	}
+
+	testCases := []struct {
+		name    string
+		prev    *exchangepb.Candle
+		candles []*exchangepb.Candle
+		end     int
+		want    []*exchangepb.Candle
+	}{
+		{
+			name:    "no_gaps",
+			candles: []*exchangepb.Candle{candle(0, 1, 2), candle(1, 2, 3)},
+			end:     2,
+			want:    []*exchangepb.Candle{candle(0, 1, 2), candle(1, 2, 3)},
+		},
+		{
+			name:    "gaps",
+			candles: []*exchangepb.Candle{candle(0, 1, 2), candle(3, 2, 3)},
+			end:     5,
+			want:    []*exchangepb.Candle{candle(0, 1, 2), empty(1, 2), empty(2, 2), candle(3, 2, 3), empty(4, 3)},
+		},
+		{
+			name:    "before_first",
+			candles: []*exchangepb.Candle{candle(2, 1, 2)},
+			end:     3,
+			want:    []*exchangepb.Candle{candle(2, 1, 2)},
+		},
+		{
+			name:    "prev",
+			prev:    candle(-10, 4, 5),
+			candles: []*exchangepb.Candle{candle(2, 1, 2)},
+			end:     3,
+			want:    []*exchangepb.Candle{empty(0, 5), empty(1, 5), candle(2, 1, 2)},
+		},
+		{
+			name: "no_candles",
+			end:  3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := fillGaps(tc.prev, tc.candles, time.Minute, start, at(tc.end).AsTime())
+
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("fillGaps() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
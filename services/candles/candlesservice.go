@@ -0,0 +1,229 @@
+// Package candlesservice aggregates the trades of the matches topics into OHLCV
+// candles, stored locally, and serves them through the CandlesService.
+package candlesservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+	"exchange/services/feed"
+)
+
+const (
+	// The number of candles of GetCandles requests without a limit.
+	defaultLimit = 500
+
+	// The most candles of a GetCandles response.
+	maxLimit = 1000
+)
+
+type Service struct {
+	exchangepb.UnimplementedCandlesServiceServer
+
+	store *Store
+
+	// The feeds of the candles changed by the trades, by pair. Read-only after
+	// creation.
+	feeds map[string]*feed.Feed[*exchangepb.Candle]
+
+	kafka *kgo.Client
+
+	now func() time.Time
+}
+
+// series returns the duration of the interval of a pair, and an error if the
+// pair or the interval is unknown.
+func (s *Service) series(pair string, interval exchangepb.CandleInterval) (time.Duration, error) {
+	if _, ok := s.feeds[pair]; !ok {
+		return 0, status.Errorf(codes.NotFound, "pair %q not found", pair)
+	}
+
+	d, ok := intervals[interval]
+	if !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid interval %v", interval)
+	}
+
+	return d, nil
+}
+
+func (s *Service) GetCandles(ctx context.Context, req *exchangepb.GetCandlesRequest) (*exchangepb.GetCandlesResponse, error) {
+	d, err := s.series(req.Pair, req.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+
+	end := s.now()
+	if req.End != nil && req.End.AsTime().Before(end) {
+		end = req.End.AsTime()
+	}
+
+	var start time.Time
+	if req.Start != nil {
+		// The first interval starting from start.
+		start = intervalStart(req.Start.AsTime(), d)
+		if start.Before(req.Start.AsTime()) {
+			start = start.Add(d)
+		}
+
+		// Candles are keyed by their start since the Unix epoch.
+		if epoch := time.Unix(0, 0); start.Before(epoch) {
+			start = epoch
+		}
+
+		if last := start.Add(time.Duration(limit) * d); last.Before(end) {
+			end = last
+		}
+	} else {
+		// The latest intervals starting before end.
+		start = intervalStart(end.Add(-1), d).Add(-time.Duration(limit-1) * d)
+	}
+
+	if !start.Before(end) {
+		return &exchangepb.GetCandlesResponse{}, nil
+	}
+
+	prev, candles, err := s.store.Candles(req.Pair, req.Interval, start, end)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting candles: %v", err)
+	}
+
+	return &exchangepb.GetCandlesResponse{Candles: fillGaps(prev, candles, d, start, end)}, nil
+}
+
+func (s *Service) StreamCandles(req *exchangepb.StreamCandlesRequest, stream exchangepb.CandlesService_StreamCandlesServer) error {
+	d, err := s.series(req.Pair, req.Interval)
+	if err != nil {
+		return err
+	}
+
+	f := s.feeds[req.Pair]
+	ch := f.Subscribe()
+	defer f.Unsubscribe(ch)
+
+	// The latest candle with trades, and the latest candle sent, which is empty
+	// when its interval has no trades yet.
+	traded, err := s.store.Latest(req.Pair, req.Interval)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error getting candles: %v", err)
+	}
+	current := traded
+
+	if current != nil {
+		if start := intervalStart(s.now(), d); current.Start.AsTime().Before(start) {
+			current = emptyCandle(traded, start)
+		}
+
+		if err := stream.Send(current); err != nil {
+			return err
+		}
+	}
+
+	// Fires when the next interval starts.
+	timer := time.NewTimer(time.Until(intervalStart(s.now(), d).Add(d)))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case c, ok := <-ch:
+			if !ok {
+				return status.Error(codes.Aborted, "stream fell behind")
+			}
+
+			if c.Interval != req.Interval {
+				continue
+			}
+
+			if err := stream.Send(c); err != nil {
+				return err
+			}
+
+			if traded == nil || !c.Start.AsTime().Before(traded.Start.AsTime()) {
+				traded = c
+			}
+
+			if current == nil || !c.Start.AsTime().Before(current.Start.AsTime()) {
+				current = c
+				continue
+			}
+
+			// A late trade changed the close before the empty candle.
+			if current.Trades == 0 && c == traded && c.Close != current.Close {
+				current = emptyCandle(c, current.Start.AsTime())
+				if err := stream.Send(current); err != nil {
+					return err
+				}
+			}
+		case <-timer.C:
+			start := intervalStart(s.now(), d)
+			timer.Reset(time.Until(start.Add(d)))
+
+			if current == nil || !current.Start.AsTime().Before(start) {
+				continue
+			}
+
+			current = emptyCandle(traded, start)
+			if err := stream.Send(current); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// New returns a candles service for the markets. The candles of the pairs are
+// aggregated from the match after the last one stored, or from the offset from
+// of their matches topic for the pairs without candles, to backfill them.
+func New(markets []engineserver.MarketSymbol, store *Store, from int64) (*Service, error) {
+	offsets := map[string]map[int32]kgo.Offset{}
+	for _, market := range markets {
+		offset := kgo.NewOffset().At(from)
+
+		sequence, ok, err := store.Sequence(market.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		// The sequence of a trade is the offset of its match plus one.
+		if ok {
+			offset = kgo.NewOffset().At(int64(sequence))
+		}
+
+		// The topics of the engine have a single partition.
+		offsets[market.Topic()+".matches"] = map[int32]kgo.Offset{0: offset}
+	}
+
+	// Without a consumer group, the offsets are the sequences in the store.
+	cl, err := kgo.NewClient(
+		kgo.SeedBrokers("localhost:9092"),
+		kgo.ConsumePartitions(offsets),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		store: store,
+		feeds: map[string]*feed.Feed[*exchangepb.Candle]{},
+		kafka: cl,
+		now:   time.Now,
+	}
+
+	for _, ms := range markets {
+		s.feeds[ms.Name()] = feed.New[*exchangepb.Candle]()
+	}
+
+	return s, nil
+}
@@ -0,0 +1,302 @@
+package candlesservice
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	"exchange/services/feed"
+)
+
+var start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestService returns a service of the pair A/B storing its candles in a
+// temporary directory, without a Kafka client.
+func newTestService(t *testing.T, now time.Time) *Service {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "candles.db"))
+	if err != nil {
+		t.Fatalf("OpenStore() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return &Service{
+		store: store,
+		feeds: map[string]*feed.Feed[*exchangepb.Candle]{"A/B": feed.New[*exchangepb.Candle]()},
+		now:   func() time.Time { return now },
+	}
+}
+
+func tradeAt(sequence uint64, offset time.Duration, price uint64, volume uint64) trade {
+	return trade{pair: "A/B", sequence: sequence, price: price, volume: volume, time: start.Add(offset)}
+}
+
+func Test_GetCandles(t *testing.T) {
+	s := newTestService(t, start.Add(5*time.Minute+30*time.Second))
+
+	batches := [][]trade{
+		{
+			tradeAt(1, 10*time.Second, 10, 1),
+			tradeAt(2, 40*time.Second, 12, 2),
+		},
+		{
+			tradeAt(3, 2*time.Minute+5*time.Second, 11, 1),
+			tradeAt(4, 2*time.Minute+30*time.Second, 13, 1),
+			// Late for the first minute.
+			tradeAt(5, time.Minute+50*time.Second, 9, 1),
+		},
+		// Consumed again, already aggregated.
+		{
+			tradeAt(2, 40*time.Second, 12, 2),
+			tradeAt(3, 2*time.Minute+5*time.Second, 11, 1),
+		},
+	}
+	for _, trades := range batches {
+		if err := s.addTrades(trades); err != nil {
+			t.Fatalf("addTrades() unexpected error: %v", err)
+		}
+	}
+
+	minute := func(minutes int, open, high, low, close, volume, trades, first, last uint64) *exchangepb.Candle {
+		return &exchangepb.Candle{
+			Pair:          "A/B",
+			Interval:      exchangepb.CandleInterval_ONE_MINUTE,
+			Start:         timestamppb.New(start.Add(time.Duration(minutes) * time.Minute)),
+			Open:          open,
+			High:          high,
+			Low:           low,
+			Close:         close,
+			Volume:        volume,
+			Trades:        trades,
+			FirstSequence: first,
+			LastSequence:  last,
+		}
+	}
+
+	m0 := minute(0, 10, 12, 10, 12, 3, 2, 1, 2)
+	m1 := minute(1, 9, 9, 9, 9, 1, 1, 5, 5)
+	m2 := minute(2, 11, 13, 11, 13, 2, 2, 3, 4)
+	empty := func(minutes int) *exchangepb.Candle {
+		return minute(minutes, 13, 13, 13, 13, 0, 0, 0, 0)
+	}
+
+	at := func(d time.Duration) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(d))
+	}
+
+	testCases := []struct {
+		name     string
+		req      *exchangepb.GetCandlesRequest
+		wantCode codes.Code
+		want     []*exchangepb.Candle
+	}{
+		{
+			name: "latest",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE},
+			want: []*exchangepb.Candle{m0, m1, m2, empty(3), empty(4), empty(5)},
+		},
+		{
+			name: "latest_limit",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Limit: 2},
+			want: []*exchangepb.Candle{empty(4), empty(5)},
+		},
+		{
+			name: "end",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, End: at(2 * time.Minute), Limit: 1},
+			want: []*exchangepb.Candle{m1},
+		},
+		{
+			name: "start_limit",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Start: at(time.Minute), Limit: 2},
+			want: []*exchangepb.Candle{m1, m2},
+		},
+		{
+			name: "unaligned_start",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Start: at(30 * time.Second), End: at(2 * time.Minute)},
+			want: []*exchangepb.Candle{m1},
+		},
+		{
+			name: "before_trades",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, End: at(0)},
+		},
+		{
+			name: "five_minutes",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_FIVE_MINUTES},
+			want: []*exchangepb.Candle{
+				{Pair: "A/B", Interval: exchangepb.CandleInterval_FIVE_MINUTES, Start: at(0), Open: 10, High: 13, Low: 9, Close: 9, Volume: 6, Trades: 5, FirstSequence: 1, LastSequence: 5},
+				{Pair: "A/B", Interval: exchangepb.CandleInterval_FIVE_MINUTES, Start: at(5 * time.Minute), Open: 9, High: 9, Low: 9, Close: 9},
+			},
+		},
+		{
+			name: "one_day",
+			req:  &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_DAY},
+			want: []*exchangepb.Candle{
+				{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_DAY, Start: at(0), Open: 10, High: 13, Low: 9, Close: 9, Volume: 6, Trades: 5, FirstSequence: 1, LastSequence: 5},
+			},
+		},
+		{
+			name:     "unknown_pair",
+			req:      &exchangepb.GetCandlesRequest{Pair: "C/D", Interval: exchangepb.CandleInterval_ONE_MINUTE},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "no_interval",
+			req:      &exchangepb.GetCandlesRequest{Pair: "A/B"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := s.GetCandles(context.Background(), tc.req)
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("GetCandles() got error %v, want %v", err, tc.wantCode)
+			}
+
+			if diff := cmp.Diff(tc.want, res.GetCandles(), protocmp.Transform()); diff != "" {
+				t.Errorf("GetCandles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	sequence, ok, err := s.store.Sequence("A/B")
+	if err != nil || !ok || sequence != 5 {
+		t.Errorf("Sequence() got %d, %v, %v, want 5, true, nil", sequence, ok, err)
+	}
+}
+
+func Test_GetCandles_LateTrade(t *testing.T) {
+	s := newTestService(t, start.Add(2*time.Minute))
+
+	if err := s.addTrades([]trade{
+		tradeAt(1, 10*time.Second, 10, 1),
+		tradeAt(2, time.Minute+10*time.Second, 12, 1),
+		// Late for the first minute, after a trade of the second one.
+		tradeAt(3, 50*time.Second, 9, 1),
+	}); err != nil {
+		t.Fatalf("addTrades() unexpected error: %v", err)
+	}
+
+	at := func(d time.Duration) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(d))
+	}
+
+	testCases := []struct {
+		interval exchangepb.CandleInterval
+		want     []*exchangepb.Candle
+	}{
+		{
+			interval: exchangepb.CandleInterval_ONE_MINUTE,
+			want: []*exchangepb.Candle{
+				// The late trade is the close of its candle.
+				{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Start: at(0), Open: 10, High: 10, Low: 9, Close: 9, Volume: 2, Trades: 2, FirstSequence: 1, LastSequence: 3},
+				{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE, Start: at(time.Minute), Open: 12, High: 12, Low: 12, Close: 12, Volume: 1, Trades: 1, FirstSequence: 2, LastSequence: 2},
+			},
+		},
+		{
+			interval: exchangepb.CandleInterval_FIVE_MINUTES,
+			want: []*exchangepb.Candle{
+				{Pair: "A/B", Interval: exchangepb.CandleInterval_FIVE_MINUTES, Start: at(0), Open: 10, High: 12, Low: 9, Close: 9, Volume: 3, Trades: 3, FirstSequence: 1, LastSequence: 3},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.interval.String(), func(t *testing.T) {
+			res, err := s.GetCandles(context.Background(), &exchangepb.GetCandlesRequest{Pair: "A/B", Interval: tc.interval, End: at(2 * time.Minute)})
+			if err != nil {
+				t.Fatalf("GetCandles() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, res.GetCandles(), protocmp.Transform()); diff != "" {
+				t.Errorf("GetCandles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// candleStream is a StreamCandles stream sending the candles to a channel.
+type candleStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+
+	candles chan *exchangepb.Candle
+}
+
+func (s *candleStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *candleStream) Send(c *exchangepb.Candle) error {
+	s.candles <- c
+	return nil
+}
+
+func Test_StreamCandles(t *testing.T) {
+	s := newTestService(t, start.Add(2*time.Minute+30*time.Second))
+
+	if err := s.addTrades([]trade{tradeAt(1, 10*time.Second, 10, 1)}); err != nil {
+		t.Fatalf("addTrades() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &candleStream{ctx: ctx, candles: make(chan *exchangepb.Candle)}
+	done := make(chan error)
+	go func() {
+		done <- s.StreamCandles(&exchangepb.StreamCandlesRequest{Pair: "A/B", Interval: exchangepb.CandleInterval_ONE_MINUTE}, stream)
+	}()
+
+	minute := func(minutes int, price uint64, trades uint64, sequence uint64) *exchangepb.Candle {
+		c := &exchangepb.Candle{
+			Pair:     "A/B",
+			Interval: exchangepb.CandleInterval_ONE_MINUTE,
+			Start:    timestamppb.New(start.Add(time.Duration(minutes) * time.Minute)),
+			Open:     price,
+			High:     price,
+			Low:      price,
+			Close:    price,
+			Trades:   trades,
+		}
+		if trades > 0 {
+			c.Volume, c.FirstSequence, c.LastSequence = 1, sequence, sequence
+		}
+		return c
+	}
+
+	// The current interval has no trades yet.
+	want := []*exchangepb.Candle{minute(2, 10, 0, 0)}
+	got := []*exchangepb.Candle{<-stream.candles}
+
+	// A late trade changes the close before the current interval.
+	if err := s.addTrades([]trade{tradeAt(2, time.Minute+10*time.Second, 12, 1)}); err != nil {
+		t.Fatalf("addTrades() unexpected error: %v", err)
+	}
+	want = append(want, minute(1, 12, 1, 2), minute(2, 12, 0, 0))
+	got = append(got, <-stream.candles, <-stream.candles)
+
+	if err := s.addTrades([]trade{tradeAt(3, 2*time.Minute+40*time.Second, 11, 1)}); err != nil {
+		t.Fatalf("addTrades() unexpected error: %v", err)
+	}
+	want = append(want, minute(2, 11, 1, 3))
+	got = append(got, <-stream.candles)
+
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("StreamCandles() mismatch (-want +got):\n%s", diff)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("StreamCandles() unexpected error: %v", err)
+	}
+}
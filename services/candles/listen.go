@@ -0,0 +1,82 @@
+package candlesservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+
+	enginepb "exchange/engine/api/v1"
+)
+
+func (s *Service) decodeTrade(record *kgo.Record) (trade, error) {
+	matchEvent := &enginepb.MatchEvent{}
+	if err := proto.Unmarshal(record.Value, matchEvent); err != nil {
+		return trade{}, err
+	}
+
+	if _, ok := s.feeds[matchEvent.Pair]; !ok {
+		return trade{}, fmt.Errorf("unknown pair %q", matchEvent.Pair)
+	}
+
+	return trade{
+		pair:     matchEvent.Pair,
+		sequence: uint64(record.Offset) + 1,
+		price:    matchEvent.SettlementPrice,
+		volume:   matchEvent.MatchedVolume,
+		time:     matchEvent.Time.AsTime(),
+	}, nil
+}
+
+// addTrades stores the trades in their candles, and publishes the candles that
+// changed.
+func (s *Service) addTrades(trades []trade) error {
+	candles, err := s.store.addTrades(trades)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range candles {
+		s.feeds[c.Pair].Publish(c)
+	}
+
+	return nil
+}
+
+// Listen consumes the matches of every market and aggregates them into the
+// candles, each poll in a single transaction. It returns the errors storing
+// the candles, so that the matches are consumed again from the last one stored
+// once restarted.
+func (s *Service) Listen(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			fetches := s.kafka.PollFetches(ctx)
+			if errs := fetches.Errors(); len(errs) > 0 {
+				for _, err := range errs {
+					log.Printf("Error polling Kafka: %v", err)
+				}
+				continue
+			}
+
+			var trades []trade
+			fetches.EachRecord(func(record *kgo.Record) {
+				t, err := s.decodeTrade(record)
+				if err != nil {
+					log.Printf("Error processing record: %v", err)
+					return
+				}
+
+				trades = append(trades, t)
+			})
+
+			if err := s.addTrades(trades); err != nil {
+				return fmt.Errorf("error storing candles: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package candlesservice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+)
+
+var (
+	candlesBucket   = []byte("candles")
+	sequencesBucket = []byte("sequences")
+)
+
+// Store keeps the candles in a local bbolt database file, with the sequence of
+// the last trade aggregated for each pair.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens or creates the database at path.
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{candlesBucket, sequencesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating buckets of %q: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// seriesKey is the prefix of the keys of the candles of a pair and interval.
+func seriesKey(pair string, interval exchangepb.CandleInterval) []byte {
+	return fmt.Appendf(nil, "%s\x00%d\x00", pair, interval)
+}
+
+// candleKey is the series key followed by the start in Unix seconds, big endian
+// so that candles sort by start.
+func candleKey(pair string, interval exchangepb.CandleInterval, start time.Time) []byte {
+	return binary.BigEndian.AppendUint64(seriesKey(pair, interval), uint64(start.Unix()))
+}
+
+func decodeCandle(v []byte) (*exchangepb.Candle, error) {
+	c := &exchangepb.Candle{}
+	if err := proto.Unmarshal(v, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Sequence returns the sequence of the last trade of a pair, and false if none
+// was aggregated.
+func (s *Store) Sequence(pair string) (uint64, bool, error) {
+	var sequence uint64
+	var ok bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(sequencesBucket).Get([]byte(pair)); v != nil {
+			sequence, ok = binary.BigEndian.Uint64(v), true
+		}
+		return nil
+	})
+
+	return sequence, ok, err
+}
+
+// addTrades aggregates the trades into the candles of every interval, skipping
+// the trades of a pair up to its last sequence, which were already added. It
+// returns the candles that changed.
+func (s *Store) addTrades(trades []trade) ([]*exchangepb.Candle, error) {
+	// The candles changed by the trades, by key, in the order they changed.
+	changed := map[string]*exchangepb.Candle{}
+	var order []string
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		candles, sequences := tx.Bucket(candlesBucket), tx.Bucket(sequencesBucket)
+
+		for _, t := range trades {
+			if v := sequences.Get([]byte(t.pair)); v != nil && t.sequence <= binary.BigEndian.Uint64(v) {
+				continue
+			}
+
+			for interval := exchangepb.CandleInterval_ONE_MINUTE; interval <= exchangepb.CandleInterval_ONE_DAY; interval++ {
+				start := intervalStart(t.time, intervals[interval])
+				key := candleKey(t.pair, interval, start)
+
+				c, ok := changed[string(key)]
+				if !ok {
+					if v := candles.Get(key); v != nil {
+						var err error
+						if c, err = decodeCandle(v); err != nil {
+							return fmt.Errorf("decoding candle %q: %w", key, err)
+						}
+					} else {
+						c = &exchangepb.Candle{Pair: t.pair, Interval: interval, Start: timestamppb.New(start)}
+					}
+
+					changed[string(key)] = c
+					order = append(order, string(key))
+				}
+
+				addTrade(c, t)
+			}
+
+			if err := sequences.Put([]byte(t.pair), binary.BigEndian.AppendUint64(nil, t.sequence)); err != nil {
+				return err
+			}
+		}
+
+		for _, key := range order {
+			v, err := proto.Marshal(changed[key])
+			if err != nil {
+				return err
+			}
+
+			if err := candles.Put([]byte(key), v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*exchangepb.Candle, 0, len(order))
+	for _, key := range order {
+		res = append(res, changed[key])
+	}
+
+	return res, nil
+}
+
+// lastBefore returns the last key and value of the cursor before key.
+func lastBefore(c *bolt.Cursor, key []byte) ([]byte, []byte) {
+	if k, _ := c.Seek(key); k == nil {
+		return c.Last()
+	}
+
+	return c.Prev()
+}
+
+// Candles returns the candles of a pair and interval starting from start until
+// before end, and the last candle before start, nil if there is none.
+func (s *Store) Candles(pair string, interval exchangepb.CandleInterval, start time.Time, end time.Time) (*exchangepb.Candle, []*exchangepb.Candle, error) {
+	var prev *exchangepb.Candle
+	var res []*exchangepb.Candle
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := seriesKey(pair, interval)
+		from, to := candleKey(pair, interval, start), candleKey(pair, interval, end)
+
+		c := tx.Bucket(candlesBucket).Cursor()
+		if k, v := lastBefore(c, from); k != nil && bytes.HasPrefix(k, prefix) {
+			var err error
+			if prev, err = decodeCandle(v); err != nil {
+				return fmt.Errorf("decoding candle %q: %w", k, err)
+			}
+		}
+
+		for k, v := c.Seek(from); k != nil && bytes.HasPrefix(k, prefix) && bytes.Compare(k, to) < 0; k, v = c.Next() {
+			candle, err := decodeCandle(v)
+			if err != nil {
+				return fmt.Errorf("decoding candle %q: %w", k, err)
+			}
+			res = append(res, candle)
+		}
+
+		return nil
+	})
+
+	return prev, res, err
+}
+
+// Latest returns the latest candle of a pair and interval, nil if there is
+// none.
+func (s *Store) Latest(pair string, interval exchangepb.CandleInterval) (*exchangepb.Candle, error) {
+	var latest *exchangepb.Candle
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := seriesKey(pair, interval)
+
+		c := tx.Bucket(candlesBucket).Cursor()
+		k, v := lastBefore(c, binary.BigEndian.AppendUint64(prefix, math.MaxUint64))
+		if k == nil || !bytes.HasPrefix(k, prefix) {
+			return nil
+		}
+
+		var err error
+		if latest, err = decodeCandle(v); err != nil {
+			return fmt.Errorf("decoding candle %q: %w", k, err)
+		}
+		return nil
+	})
+
+	return latest, err
+}
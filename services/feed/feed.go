@@ -0,0 +1,54 @@
+// Package feed fans out the messages of the streams of the services to their
+// subscribers.
+package feed
+
+import "sync"
+
+// How many messages a stream may fall behind before it is disconnected.
+const SubscriberBuffer = 1000
+
+// Feed fans out messages to every subscriber without blocking the publisher.
+type Feed[T any] struct {
+	mu sync.Mutex
+
+	subscribers map[chan T]struct{}
+}
+
+func New[T any]() *Feed[T] {
+	return &Feed[T]{subscribers: map[chan T]struct{}{}}
+}
+
+// Subscribe returns a channel receiving every published message. The channel
+// is closed if the subscriber falls behind.
+func (f *Feed[T]) Subscribe() chan T {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan T, SubscriberBuffer)
+	f.subscribers[ch] = struct{}{}
+
+	return ch
+}
+
+func (f *Feed[T]) Unsubscribe(ch chan T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.subscribers, ch)
+}
+
+// Publish sends msg to every subscriber, disconnecting those that are too slow
+// to keep up.
+func (f *Feed[T]) Publish(msg T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for ch := range f.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			close(ch)
+			delete(f.subscribers, ch)
+		}
+	}
+}
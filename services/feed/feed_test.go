@@ -0,0 +1,29 @@
+package feed
+
+import "testing"
+
+func Test_Feed_Publish(t *testing.T) {
+	f := New[int]()
+
+	fast := f.Subscribe()
+	slow := f.Subscribe()
+
+	for i := range SubscriberBuffer {
+		f.Publish(i)
+		<-fast
+	}
+
+	f.Publish(SubscriberBuffer)
+
+	if got := <-fast; got != SubscriberBuffer {
+		t.Errorf("Publish() fast subscriber got %d, want %d", got, SubscriberBuffer)
+	}
+
+	for range SubscriberBuffer {
+		<-slow
+	}
+
+	if _, ok := <-slow; ok {
+		t.Errorf("Publish() slow subscriber got a message, want it closed")
+	}
+}
@@ -0,0 +1,77 @@
+package marketdataservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+
+	enginepb "exchange/engine/api/v1"
+)
+
+func (s *Service) processEvent(record *kgo.Record) error {
+	parts := strings.Split(record.Topic, ".")
+
+	if len(parts) != 4 {
+		return fmt.Errorf("invalid topic %q", record.Topic)
+	}
+
+	switch parts[3] {
+	case "volumes":
+		volumeEvent := &enginepb.VolumeEvent{}
+		if err := proto.Unmarshal(record.Value, volumeEvent); err != nil {
+			return err
+		}
+
+		m, ok := s.markets[volumeEvent.Pair]
+		if !ok {
+			return fmt.Errorf("unknown pair %q", volumeEvent.Pair)
+		}
+
+		m.applyVolumeEvent(volumeEvent)
+	case "matches":
+		matchEvent := &enginepb.MatchEvent{}
+		if err := proto.Unmarshal(record.Value, matchEvent); err != nil {
+			return err
+		}
+
+		m, ok := s.markets[matchEvent.Pair]
+		if !ok {
+			return fmt.Errorf("unknown pair %q", matchEvent.Pair)
+		}
+
+		m.applyMatchEvent(matchEvent)
+	default:
+		return fmt.Errorf("unsupported topic suffix %q", parts[3])
+	}
+
+	return nil
+}
+
+// Listen consumes the engine events of every market and keeps the books,
+// trades and tickers up to date.
+func (s *Service) Listen(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			fetches := s.kafka.PollFetches(ctx)
+			if errs := fetches.Errors(); len(errs) > 0 {
+				for _, err := range errs {
+					log.Printf("Error polling Kafka: %v", err)
+				}
+				continue
+			}
+
+			fetches.EachRecord(func(record *kgo.Record) {
+				if err := s.processEvent(record); err != nil {
+					log.Printf("Error processing record: %v", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package marketdataservice
+
+import (
+	"sync"
+	"time"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+	"exchange/engine/orderbook/rbtree"
+	"exchange/services/feed"
+)
+
+// market is the public state of a trading pair, rebuilt from the engine events.
+type market struct {
+	// Guards every field below, feeds excluded.
+	mu sync.Mutex
+
+	pair string
+
+	// Volume by price of each side, kept sorted from the best price so that
+	// the top of the book is read without sorting it.
+	bids *rbtree.Tree[uint64, uint64]
+	asks *rbtree.Tree[uint64, uint64]
+
+	// The sequence of the last book update.
+	bookSequence uint64
+
+	// The sequence of the last trade.
+	tradeSequence uint64
+
+	ticker ticker
+
+	bookUpdates *feed.Feed[*exchangepb.OrderBookUpdate]
+
+	trades *feed.Feed[*exchangepb.Trade]
+}
+
+// levelPool recycles the nodes of the levels of every market.
+var levelPool = &sync.Pool{
+	New: func() any {
+		return rbtree.NewNode[uint64, uint64](0)
+	},
+}
+
+func newMarket(pair string) *market {
+	return &market{
+		pair:        pair,
+		bids:        rbtree.NewTree[uint64, uint64](rbtree.MaxFirst, levelPool),
+		asks:        rbtree.NewTree[uint64, uint64](rbtree.MinFirst, levelPool),
+		bookUpdates: feed.New[*exchangepb.OrderBookUpdate](),
+		trades:      feed.New[*exchangepb.Trade](),
+	}
+}
+
+func (m *market) applyVolumeEvent(ev *enginepb.VolumeEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	levels := m.bids
+	if ev.Side == exchangepb.Side_SELL {
+		levels = m.asks
+	}
+
+	if ev.Volume == 0 {
+		levels.Delete(ev.Price)
+	} else {
+		levels.Insert(ev.Price).Value = ev.Volume
+	}
+
+	m.bookSequence++
+	m.bookUpdates.Publish(&exchangepb.OrderBookUpdate{
+		Sequence: m.bookSequence,
+		Update: &exchangepb.OrderBookUpdate_Level{
+			Level: &exchangepb.PriceLevelUpdate{
+				Side:   ev.Side,
+				Price:  ev.Price,
+				Volume: ev.Volume,
+				Time:   ev.Time,
+			},
+		},
+	})
+}
+
+func (m *market) applyMatchEvent(ev *enginepb.MatchEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ticker.addTrade(ev.SettlementPrice, ev.MatchedVolume, ev.Time.AsTime())
+
+	m.tradeSequence++
+	m.trades.Publish(&exchangepb.Trade{
+		Pair:      m.pair,
+		Sequence:  m.tradeSequence,
+		Price:     ev.SettlementPrice,
+		Volume:    ev.MatchedVolume,
+		TakerSide: ev.TakerSide,
+		Time:      ev.Time,
+	})
+}
+
+// topLevels returns up to depth levels, best price first. A depth of 0
+// returns every level.
+//
+// O(depth)
+func topLevels(levels *rbtree.Tree[uint64, uint64], depth int) []*exchangepb.PriceLevel {
+	n := levels.Len()
+	if depth > 0 && depth < n {
+		n = depth
+	}
+
+	res := make([]*exchangepb.PriceLevel, 0, n)
+	for node := range levels.FromHead() {
+		if len(res) == n {
+			break
+		}
+		res = append(res, &exchangepb.PriceLevel{Price: node.Key, Volume: node.Value})
+	}
+
+	return res
+}
+
+// orderBook returns a snapshot of the book. The caller must hold the lock.
+func (m *market) orderBook(depth int) *exchangepb.OrderBook {
+	return &exchangepb.OrderBook{
+		Pair:     m.pair,
+		Bids:     topLevels(m.bids, depth),
+		Asks:     topLevels(m.asks, depth),
+		Sequence: m.bookSequence,
+	}
+}
+
+// snapshot returns up to depth levels per side of the book.
+func (m *market) snapshot(depth int) *exchangepb.OrderBook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.orderBook(depth)
+}
+
+// subscribeBook returns a snapshot of the book and a channel receiving every
+// update after it.
+func (m *market) subscribeBook() (*exchangepb.OrderBook, chan *exchangepb.OrderBookUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.orderBook(0), m.bookUpdates.Subscribe()
+}
+
+func (m *market) tickerAt(now time.Time) *exchangepb.Ticker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := &exchangepb.Ticker{Pair: m.pair, LastPrice: m.ticker.lastPrice}
+	t.High, t.Low, t.Volume = m.ticker.stats(now)
+
+	if best := m.bids.Head(); best != nil {
+		t.BestBid = best.Key
+	}
+
+	if best := m.asks.Head(); best != nil {
+		t.BestAsk = best.Key
+	}
+
+	return t
+}
@@ -0,0 +1,136 @@
+package marketdataservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+func Test_MarketOrderBook(t *testing.T) {
+	m := newMarket("A/B")
+
+	for _, ev := range []*enginepb.VolumeEvent{
+		{Side: exchangepb.Side_BUY, Price: 8, Volume: 5},
+		{Side: exchangepb.Side_BUY, Price: 9, Volume: 5},
+		{Side: exchangepb.Side_BUY, Price: 7, Volume: 5},
+		{Side: exchangepb.Side_BUY, Price: 9, Volume: 3},
+		{Side: exchangepb.Side_SELL, Price: 12, Volume: 1},
+		{Side: exchangepb.Side_SELL, Price: 11, Volume: 2},
+		{Side: exchangepb.Side_SELL, Price: 10, Volume: 3},
+		{Side: exchangepb.Side_SELL, Price: 10, Volume: 0},
+	} {
+		m.applyVolumeEvent(ev)
+	}
+
+	want := &exchangepb.OrderBook{
+		Pair:     "A/B",
+		Bids:     []*exchangepb.PriceLevel{{Price: 9, Volume: 3}, {Price: 8, Volume: 5}},
+		Asks:     []*exchangepb.PriceLevel{{Price: 11, Volume: 2}, {Price: 12, Volume: 1}},
+		Sequence: 8,
+	}
+	if diff := cmp.Diff(want, m.snapshot(2), protocmp.Transform()); diff != "" {
+		t.Errorf("snapshot() unexpected book (-want +got):\n%s", diff)
+	}
+
+	book, ch := m.subscribeBook()
+	if len(book.Bids) != 3 || book.Sequence != 8 {
+		t.Errorf("subscribeBook() got %v, want every level at sequence 8", book)
+	}
+
+	m.applyVolumeEvent(&enginepb.VolumeEvent{Side: exchangepb.Side_SELL, Price: 11, Volume: 0})
+
+	wantUpdate := &exchangepb.OrderBookUpdate{
+		Sequence: 9,
+		Update: &exchangepb.OrderBookUpdate_Level{
+			Level: &exchangepb.PriceLevelUpdate{Side: exchangepb.Side_SELL, Price: 11, Volume: 0},
+		},
+	}
+	if diff := cmp.Diff(wantUpdate, <-ch, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected book update (-want +got):\n%s", diff)
+	}
+}
+
+func Test_MarketTicker(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	m := newMarket("A/B")
+	m.applyVolumeEvent(&enginepb.VolumeEvent{Side: exchangepb.Side_BUY, Price: 9, Volume: 5})
+	m.applyVolumeEvent(&enginepb.VolumeEvent{Side: exchangepb.Side_BUY, Price: 8, Volume: 5})
+	m.applyVolumeEvent(&enginepb.VolumeEvent{Side: exchangepb.Side_SELL, Price: 11, Volume: 5})
+	m.applyVolumeEvent(&enginepb.VolumeEvent{Side: exchangepb.Side_SELL, Price: 12, Volume: 5})
+
+	ch := m.trades.Subscribe()
+
+	for _, ev := range []*enginepb.MatchEvent{
+		// Outside of the window.
+		{SettlementPrice: 50, MatchedVolume: 100, Time: timestamppb.New(now.Add(-25 * time.Hour))},
+		{SettlementPrice: 10, MatchedVolume: 2, Time: timestamppb.New(now.Add(-2 * time.Hour))},
+		{SettlementPrice: 14, MatchedVolume: 3, Time: timestamppb.New(now.Add(-time.Hour))},
+		{SettlementPrice: 6, MatchedVolume: 1, Time: timestamppb.New(now.Add(-time.Hour))},
+		{SettlementPrice: 11, MatchedVolume: 4, TakerSide: exchangepb.Side_SELL, Time: timestamppb.New(now)},
+	} {
+		m.applyMatchEvent(ev)
+	}
+
+	want := &exchangepb.Ticker{
+		Pair:      "A/B",
+		LastPrice: 11,
+		BestBid:   9,
+		BestAsk:   11,
+		Volume:    10,
+		High:      14,
+		Low:       6,
+	}
+	if diff := cmp.Diff(want, m.tickerAt(now), protocmp.Transform()); diff != "" {
+		t.Errorf("tickerAt() unexpected ticker (-want +got):\n%s", diff)
+	}
+
+	if got := len(ch); got != 5 {
+		t.Fatalf("got %d trades, want 5", got)
+	}
+
+	for range 4 {
+		<-ch
+	}
+
+	wantTrade := &exchangepb.Trade{Pair: "A/B", Sequence: 5, Price: 11, Volume: 4, TakerSide: exchangepb.Side_SELL, Time: timestamppb.New(now)}
+	if diff := cmp.Diff(wantTrade, <-ch, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected trade (-want +got):\n%s", diff)
+	}
+}
+
+func Test_MarketTickerWindow(t *testing.T) {
+	start := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	m := newMarket("A/B")
+
+	// Two days of trades, one a minute, without the ticker being requested.
+	for i := range 2 * 24 * 60 {
+		m.applyMatchEvent(&enginepb.MatchEvent{SettlementPrice: 10, MatchedVolume: 1, Time: timestamppb.New(start.Add(time.Duration(i) * time.Minute))})
+	}
+
+	if got, want := len(m.ticker.candles), 24*60+1; got != want {
+		t.Errorf("got %d candles, want %d", got, want)
+	}
+}
+
+func Test_ServiceErrors(t *testing.T) {
+	s := &Service{markets: map[string]*market{"A/B": newMarket("A/B")}}
+
+	if _, err := s.GetOrderBook(context.Background(), &exchangepb.GetOrderBookRequest{Pair: "C/D"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetOrderBook() got error %v, want NotFound", err)
+	}
+
+	if _, err := s.GetTicker(context.Background(), &exchangepb.GetTickerRequest{Pair: "C/D"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetTicker() got error %v, want NotFound", err)
+	}
+}
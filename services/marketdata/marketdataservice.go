@@ -0,0 +1,139 @@
+package marketdataservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+)
+
+type Service struct {
+	exchangepb.UnimplementedMarketDataServiceServer
+
+	// The markets by pair name. Read-only after creation.
+	markets map[string]*market
+
+	kafka *kgo.Client
+}
+
+func (s *Service) market(pair string) (*market, error) {
+	m, ok := s.markets[pair]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "pair %q not found", pair)
+	}
+
+	return m, nil
+}
+
+func (s *Service) GetOrderBook(ctx context.Context, req *exchangepb.GetOrderBookRequest) (*exchangepb.OrderBook, error) {
+	m, err := s.market(req.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.snapshot(int(req.Depth)), nil
+}
+
+func (s *Service) StreamOrderBook(req *exchangepb.StreamOrderBookRequest, stream exchangepb.MarketDataService_StreamOrderBookServer) error {
+	m, err := s.market(req.Pair)
+	if err != nil {
+		return err
+	}
+
+	book, ch := m.subscribeBook()
+	defer m.bookUpdates.Unsubscribe(ch)
+
+	err = stream.Send(&exchangepb.OrderBookUpdate{
+		Sequence: book.Sequence,
+		Update:   &exchangepb.OrderBookUpdate_Snapshot{Snapshot: book},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case u, ok := <-ch:
+			if !ok {
+				return status.Error(codes.Aborted, "stream fell behind, request a new snapshot")
+			}
+
+			if err := stream.Send(u); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Service) StreamTrades(req *exchangepb.StreamTradesRequest, stream exchangepb.MarketDataService_StreamTradesServer) error {
+	m, err := s.market(req.Pair)
+	if err != nil {
+		return err
+	}
+
+	ch := m.trades.Subscribe()
+	defer m.trades.Unsubscribe(ch)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case t, ok := <-ch:
+			if !ok {
+				return status.Error(codes.Aborted, "stream fell behind")
+			}
+
+			if err := stream.Send(t); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Service) GetTicker(ctx context.Context, req *exchangepb.GetTickerRequest) (*exchangepb.Ticker, error) {
+	m, err := s.market(req.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.tickerAt(time.Now()), nil
+}
+
+func New(markets []engineserver.MarketSymbol) (*Service, error) {
+	topics := []string{}
+	for _, market := range markets {
+		topics = append(topics,
+			market.Topic()+".volumes",
+			market.Topic()+".matches",
+		)
+	}
+
+	// Without a consumer group, every instance reads the topics from the start
+	// to rebuild the books in memory.
+	cl, err := kgo.NewClient(
+		kgo.SeedBrokers("localhost:9092"),
+		kgo.ConsumeTopics(topics...),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		markets: map[string]*market{},
+		kafka:   cl,
+	}
+
+	for _, ms := range markets {
+		s.markets[ms.Name()] = newMarket(ms.Name())
+	}
+
+	return s, nil
+}
@@ -0,0 +1,75 @@
+package marketdataservice
+
+import "time"
+
+// The period covered by the ticker statistics.
+const tickerWindow = 24 * time.Hour
+
+// candle aggregates the trades of one minute.
+type candle struct {
+	start time.Time
+
+	high uint64
+
+	low uint64
+
+	volume uint64
+}
+
+// ticker keeps rolling statistics over the last tickerWindow, in one minute
+// buckets so that memory stays bounded regardless of the number of trades.
+type ticker struct {
+	lastPrice uint64
+
+	// Ordered by start time, oldest first.
+	candles []candle
+}
+
+func (t *ticker) addTrade(price uint64, volume uint64, tradeTime time.Time) {
+	t.lastPrice = price
+
+	start := tradeTime.Truncate(time.Minute)
+	n := len(t.candles)
+	if n > 0 && !start.After(t.candles[n-1].start) {
+		// Late trades are added to the latest candle, statistics only need to be
+		// accurate to the minute.
+		c := &t.candles[n-1]
+		c.high = max(c.high, price)
+		c.low = min(c.low, price)
+		c.volume += volume
+		return
+	}
+
+	t.candles = append(t.candles, candle{start: start, high: price, low: price, volume: volume})
+
+	// The candles are also dropped here, for the markets whose ticker is never
+	// requested.
+	t.prune(tradeTime)
+}
+
+// prune drops the candles that started before now - tickerWindow.
+func (t *ticker) prune(now time.Time) {
+	cutoff := now.Add(-tickerWindow)
+
+	i := 0
+	for i < len(t.candles) && t.candles[i].start.Before(cutoff) {
+		i++
+	}
+	t.candles = t.candles[i:]
+}
+
+// stats returns the high, low and volume of the trades since now - tickerWindow,
+// dropping the candles that are older.
+func (t *ticker) stats(now time.Time) (high uint64, low uint64, volume uint64) {
+	t.prune(now)
+
+	for _, c := range t.candles {
+		high = max(high, c.high)
+		if low == 0 || c.low < low {
+			low = c.low
+		}
+		volume += c.volume
+	}
+
+	return high, low, volume
+}
@@ -0,0 +1,42 @@
+package ordersservice
+
+import (
+	"context"
+	"fmt"
+
+	exchangepb "exchange/api/v1"
+	authservice "exchange/services/auth"
+)
+
+// callerAccount returns the account of a request, the account of its caller
+// when it has none. Admins act for every account when they give none.
+func callerAccount(ctx context.Context, accountID string) string {
+	if caller, ok := authservice.CallerFromContext(ctx); ok && accountID == "" && !caller.Admin {
+		return caller.AccountID
+	}
+
+	return accountID
+}
+
+// authorize checks that the caller of a request acts for the account.
+// Requests without a caller come from the service itself, like the
+// cancellations of the sessions that time out.
+func authorize(ctx context.Context, accountID string) error {
+	caller, ok := authservice.CallerFromContext(ctx)
+	if !ok || caller.Admin || caller.AccountID == accountID {
+		return nil
+	}
+
+	return fmt.Errorf("account %q: %w", accountID, errPermissionDenied)
+}
+
+// authorizeOrder fills in the account of a new order, and checks that the
+// caller acts for it.
+func authorizeOrder(ctx context.Context, o *exchangepb.Order) error {
+	if o == nil {
+		return nil
+	}
+	o.AccountId = callerAccount(ctx, o.AccountId)
+
+	return authorize(ctx, o.AccountId)
+}
@@ -0,0 +1,77 @@
+package ordersservice
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authservice "exchange/services/auth"
+)
+
+func Test_Authorize(t *testing.T) {
+	trader := authservice.NewContext(context.Background(), &authservice.Caller{AccountID: "alice"})
+	admin := authservice.NewContext(context.Background(), &authservice.Caller{AccountID: "root", Admin: true})
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		accountID   string
+		wantAccount string
+		wantCode    codes.Code
+	}{
+		{
+			name:        "own_account",
+			ctx:         trader,
+			accountID:   "alice",
+			wantAccount: "alice",
+			wantCode:    codes.OK,
+		},
+		{
+			name:        "caller_account",
+			ctx:         trader,
+			wantAccount: "alice",
+			wantCode:    codes.OK,
+		},
+		{
+			name:        "other_account",
+			ctx:         trader,
+			accountID:   "bob",
+			wantAccount: "bob",
+			wantCode:    codes.PermissionDenied,
+		},
+		{
+			name:        "admin",
+			ctx:         admin,
+			accountID:   "bob",
+			wantAccount: "bob",
+			wantCode:    codes.OK,
+		},
+		{
+			name:     "admin_every_account",
+			ctx:      admin,
+			wantCode: codes.OK,
+		},
+		{
+			name:        "service",
+			ctx:         context.Background(),
+			accountID:   "bob",
+			wantAccount: "bob",
+			wantCode:    codes.OK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accountID := callerAccount(tc.ctx, tc.accountID)
+			if accountID != tc.wantAccount {
+				t.Errorf("callerAccount() got %q, want %q", accountID, tc.wantAccount)
+			}
+
+			if got := status.Code(authorize(tc.ctx, accountID)); got != tc.wantCode {
+				t.Errorf("authorize() got code %v, want %v", got, tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package ordersservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+// The most operations accepted by BatchOrders.
+const maxBatchOperations = 100
+
+// BatchOrders sends the operations of the request to the engine in one BATCH
+// request per market, applied in sequence. Operations that fail before
+// reaching the engine have an error in their result, and do not stop the rest.
+// The engine results of the others come as order updates, like those of
+// single operations.
+func (s *Service) BatchOrders(ctx context.Context, req *exchangepb.BatchOrdersRequest) (*exchangepb.BatchOrdersResponse, error) {
+	fmt.Printf("BatchOrders: %d operations\n", len(req.Operations))
+
+	if len(req.Operations) > maxBatchOperations {
+		return nil, fmt.Errorf("%d operations, want at most %d: %w", len(req.Operations), maxBatchOperations, errBadRequest)
+	}
+
+	// The batch and the results of each market, in the order of their first
+	// operation.
+	type batch struct {
+		requestPB *enginepb.OrderRequest
+		results   []*exchangepb.BatchOrdersResponse_Result
+	}
+	batches := map[string]*batch{}
+	pairs := []string{}
+
+	duplicates := duplicateClientOrderIDs(ctx, req.Operations)
+
+	res := &exchangepb.BatchOrdersResponse{}
+	for i, op := range req.Operations {
+		result := &exchangepb.BatchOrdersResponse_Result{}
+		res.Results = append(res.Results, result)
+
+		if err := duplicates[i]; err != nil {
+			result.Error = err.Error()
+			continue
+		}
+
+		o, requestPB, done, err := s.operationRequest(ctx, op)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		defer done()
+		result.Order = o
+
+		if requestPB == nil {
+			continue
+		}
+
+		pair := requestPB.Order.Pair
+		b, ok := batches[pair]
+		if !ok {
+			b = &batch{requestPB: &enginepb.OrderRequest{Type: enginepb.OrderRequest_BATCH}}
+			batches[pair] = b
+			pairs = append(pairs, pair)
+		}
+
+		b.requestPB.Batch = append(b.requestPB.Batch, requestPB)
+		b.results = append(b.results, result)
+	}
+
+	records := []*kgo.Record{}
+	for _, pair := range pairs {
+		msg, err := proto.Marshal(batches[pair].requestPB)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing proto: %w", err)
+		}
+
+		records = append(records, &kgo.Record{Topic: engineTopic(pair), Value: msg})
+	}
+
+	for i, produced := range s.kafka.ProduceSync(ctx, records...) {
+		if produced.Err == nil {
+			continue
+		}
+
+		b := batches[pairs[i]]
+		for j, requestPB := range b.requestPB.Batch {
+			if requestPB.Type != enginepb.OrderRequest_CANCEL && requestPB.Type != enginepb.OrderRequest_AMEND {
+				s.untrackNew(requestPB.Order)
+			}
+			b.results[j].Error = fmt.Sprintf("error producing record: %v", produced.Err)
+		}
+	}
+
+	return res, nil
+}
+
+// duplicateClientOrderIDs returns the errors of the create operations using
+// the client order ID of an earlier one of the same account, by index. They
+// are found before any order is stored, as the later operations would get the
+// order of the first one back and produce it again.
+func duplicateClientOrderIDs(ctx context.Context, ops []*exchangepb.BatchOrdersRequest_Operation) map[int]error {
+	errs := map[int]error{}
+	seen := map[string]bool{}
+	for i, op := range ops {
+		o := op.GetCreate().GetOrder()
+		if o.GetClientOrderId() == "" {
+			continue
+		}
+
+		key := callerAccount(ctx, o.AccountId) + "\x00" + o.ClientOrderId
+		if seen[key] {
+			errs[i] = fmt.Errorf("client order id %q used twice: %w", o.ClientOrderId, errBadRequest)
+			continue
+		}
+		seen[key] = true
+	}
+
+	return errs
+}
+
+// operationRequest returns the order of an operation of a batch, the engine
+// request to produce for it, and the function to call once it is produced.
+// Created orders are stored, and retried ones have no request once the engine
+// acknowledged them.
+func (s *Service) operationRequest(ctx context.Context, op *exchangepb.BatchOrdersRequest_Operation) (*exchangepb.Order, *enginepb.OrderRequest, func(), error) {
+	switch {
+	case op.GetCreate().GetOrder() != nil:
+		o := op.GetCreate().Order
+		if err := authorizeOrder(ctx, o); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if err := s.validateOrder(o); err != nil {
+			return nil, nil, nil, err
+		}
+
+		requestPB, err := createRequest(o)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		done, err := s.sessions.startCreates([]*exchangepb.Order{o})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		orders, created, err := s.storeNewOrders([]*exchangepb.Order{o}, time.Now())
+		if err != nil {
+			done()
+			return nil, nil, nil, err
+		}
+
+		if !created && orders[0].Status != exchangepb.Order_PENDING_NEW {
+			return orders[0], nil, done, nil
+		}
+		requestPB.Order = orders[0]
+
+		return requestPB.Order, requestPB, done, nil
+	case op.GetDelete() != nil:
+		requestPB, err := s.cancelRequest(ctx, op.GetDelete().OrderId)
+		return requestPB.GetOrder(), requestPB, func() {}, err
+	case op.GetAmend() != nil:
+		requestPB, err := s.amendRequest(ctx, op.GetAmend())
+		return requestPB.GetOrder(), requestPB, func() {}, err
+	}
+
+	return nil, nil, nil, fmt.Errorf("operation without a create, delete or amend: %w", errBadRequest)
+}
@@ -0,0 +1,71 @@
+package ordersservice
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/grpc/codes"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+	"exchange/services/orders/storage"
+)
+
+func Test_BatchOrders_DuplicateClientOrderIDs(t *testing.T) {
+	// Without a broker, the records fail to be produced once the request
+	// times out.
+	cl, err := kgo.NewClient(kgo.SeedBrokers("127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("kgo.NewClient() unexpected error: %v", err)
+	}
+	defer cl.Close()
+
+	s := &Service{
+		kafka:       cl,
+		store:       storage.NewMemory(),
+		instruments: map[string]*engineserver.MarketSymbol{"A/B": {Base: "A", Trade: "B"}},
+		sessions:    newSessions(10 * time.Second),
+	}
+
+	create := func(accountID string, clientOrderID string) *exchangepb.BatchOrdersRequest_Operation {
+		return &exchangepb.BatchOrdersRequest_Operation{
+			Operation: &exchangepb.BatchOrdersRequest_Operation_Create{
+				Create: &exchangepb.CreateOrderRequest{
+					Order: &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: accountID, ClientOrderId: clientOrderID, Side: exchangepb.Side_BUY, Price: 10, Volume: 1},
+				},
+			},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	res, err := s.BatchOrders(ctx, &exchangepb.BatchOrdersRequest{
+		Operations: []*exchangepb.BatchOrdersRequest_Operation{
+			create("alice", "a"),
+			create("alice", "a"),
+			create("bob", "a"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchOrders() unexpected error: %v", err)
+	}
+
+	if got := res.Results[1]; got.Order != nil || !strings.Contains(got.Error, codes.InvalidArgument.String()) {
+		t.Errorf("BatchOrders() duplicate result got %v, want an InvalidArgument error", got)
+	}
+
+	for _, i := range []int{0, 2} {
+		if got := res.Results[i]; got.Order.GetId() == "" || strings.Contains(got.Error, codes.InvalidArgument.String()) {
+			t.Errorf("BatchOrders() result %d got %v, want a stored order", i, got)
+		}
+	}
+
+	stored, err := s.store.GetClientOrder("alice", "a")
+	if err != nil || stored.Order.Id != res.Results[0].Order.GetId() {
+		t.Errorf("GetClientOrder() got %v, %v, want the order of the first operation", stored, err)
+	}
+}
@@ -0,0 +1,92 @@
+package ordersservice
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+	"exchange/services/orders/storage"
+)
+
+// newOrderID returns a random order ID.
+func newOrderID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// storeNewOrders stores orders received from a client with new IDs, before
+// they are produced, so that no engine event is missed. Orders sent again
+// with the client order IDs of orders created within the window of the engine
+// are not stored: the orders first created are returned instead, with created
+// false. They still have to be produced if they are PENDING_NEW.
+func (s *Service) storeNewOrders(orders []*exchangepb.Order, now time.Time) ([]*exchangepb.Order, bool, error) {
+	s.creating.Lock()
+	defer s.creating.Unlock()
+
+	existing := []*exchangepb.Order{}
+	clientOrderIDs := map[string]bool{}
+	for _, o := range orders {
+		if o.ClientOrderId == "" {
+			continue
+		}
+
+		if clientOrderIDs[o.ClientOrderId] {
+			return nil, false, fmt.Errorf("client order id %q used twice: %w", o.ClientOrderId, errBadRequest)
+		}
+		clientOrderIDs[o.ClientOrderId] = true
+
+		t, err := s.store.GetClientOrder(o.AccountId, o.ClientOrderId)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, false, fmt.Errorf("error getting order: %w", err)
+		}
+
+		if now.Sub(t.Order.CreatedAt.AsTime()) < engineserver.ClientOrderIDWindow {
+			existing = append(existing, t.Order)
+		}
+	}
+
+	if len(existing) == len(orders) {
+		return existing, false, nil
+	} else if len(existing) > 0 {
+		return nil, false, fmt.Errorf("client order id %q of another order: %w", existing[0].ClientOrderId, errBadRequest)
+	}
+
+	for i, o := range orders {
+		id, err := newOrderID()
+		if err != nil {
+			return nil, false, fmt.Errorf("error generating order id: %w", err)
+		}
+		o.Id = id
+		o.CreatedAt = timestamppb.New(now)
+
+		if err := s.store.CreateOrder(newTrackedOrder(o)); err != nil {
+			for _, stored := range orders[:i] {
+				s.untrack(stored.Id)
+			}
+			return nil, false, fmt.Errorf("error storing order: %w", err)
+		}
+	}
+
+	return orders, true, nil
+}
+
+// untrackNew forgets a new order that failed to be produced, unless it has a
+// client order ID: it may have reached the engine anyway, and a retry with the
+// same client order ID produces it again.
+func (s *Service) untrackNew(o *exchangepb.Order) {
+	if o.ClientOrderId == "" {
+		s.untrack(o.Id)
+	}
+}
@@ -0,0 +1,74 @@
+package ordersservice
+
+import (
+	"testing"
+	"time"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+	"exchange/services/orders/storage"
+)
+
+func Test_StoreNewOrders(t *testing.T) {
+	start := time.Now()
+	s := &Service{store: storage.NewMemory()}
+
+	first, created, err := s.storeNewOrders([]*exchangepb.Order{{Id: "mine", AccountId: "alice", ClientOrderId: "a", Volume: 10}}, start)
+	if err != nil {
+		t.Fatalf("storeNewOrders() unexpected error: %v", err)
+	}
+
+	if !created || first[0].Id == "" || first[0].Id == "mine" || !first[0].CreatedAt.AsTime().Equal(start) {
+		t.Errorf("storeNewOrders() got %v created %v, want a new order with a service ID", first, created)
+	}
+
+	// Retries return the order first created, other accounts have their own
+	// client order IDs.
+	retried, created, err := s.storeNewOrders([]*exchangepb.Order{{AccountId: "alice", ClientOrderId: "a", Volume: 20}}, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("storeNewOrders() unexpected error: %v", err)
+	}
+
+	if created || retried[0].Id != first[0].Id || retried[0].Volume != 10 {
+		t.Errorf("storeNewOrders() retry got %v created %v, want order %q", retried, created, first[0].Id)
+	}
+
+	other, created, err := s.storeNewOrders([]*exchangepb.Order{{AccountId: "bob", ClientOrderId: "a"}}, start)
+	if err != nil {
+		t.Fatalf("storeNewOrders() unexpected error: %v", err)
+	}
+
+	if !created || other[0].Id == first[0].Id {
+		t.Errorf("storeNewOrders() of another account got %v created %v, want a new order", other, created)
+	}
+
+	// Groups are either retried or created as a whole.
+	if _, _, err := s.storeNewOrders([]*exchangepb.Order{{AccountId: "alice", ClientOrderId: "a"}, {AccountId: "alice", ClientOrderId: "b"}}, start); err == nil {
+		t.Errorf("storeNewOrders() partial retry want error, got nil")
+	}
+
+	if _, _, err := s.storeNewOrders([]*exchangepb.Order{{AccountId: "alice", ClientOrderId: "c"}, {AccountId: "alice", ClientOrderId: "c"}}, start); err == nil {
+		t.Errorf("storeNewOrders() same client order id twice want error, got nil")
+	}
+
+	if _, err := s.store.GetClientOrder("alice", "b"); err == nil {
+		t.Errorf("storeNewOrders() stored an order of a failed group")
+	}
+
+	// Client order IDs can be reused after the window.
+	reused, created, err := s.storeNewOrders([]*exchangepb.Order{{AccountId: "alice", ClientOrderId: "a"}}, start.Add(engineserver.ClientOrderIDWindow))
+	if err != nil {
+		t.Fatalf("storeNewOrders() unexpected error: %v", err)
+	}
+
+	if !created || reused[0].Id == first[0].Id {
+		t.Errorf("storeNewOrders() after the window got %v created %v, want a new order", reused, created)
+	}
+
+	// Orders without client order IDs are always new.
+	for range 2 {
+		if _, created, err := s.storeNewOrders([]*exchangepb.Order{{AccountId: "alice"}}, start); err != nil || !created {
+			t.Errorf("storeNewOrders() without client order id got created %v, error %v, want a new order", created, err)
+		}
+	}
+}
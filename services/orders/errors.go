@@ -0,0 +1,42 @@
+package ordersservice
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"exchange/services/orders/storage"
+)
+
+var (
+	// Wrapped by the errors of invalid requests, so that they are answered
+	// with InvalidArgument.
+	errBadRequest = status.Error(codes.InvalidArgument, "Bad request")
+
+	// Wrapped by the errors of requests for unknown orders, pairs or sessions,
+	// so that they are answered with NotFound.
+	errNotFound = status.Error(codes.NotFound, "Not found")
+
+	// Wrapped by the errors of requests for the orders of other accounts, so
+	// that they are answered with PermissionDenied.
+	errPermissionDenied = status.Error(codes.PermissionDenied, "Permission denied")
+
+	// Wrapped by the errors of streams that fell behind, so that they are
+	// answered with Aborted and resumed by the clients.
+	errAborted = status.Error(codes.Aborted, "Aborted")
+)
+
+// getOrder returns a stored order, with an error wrapping errNotFound if it
+// is unknown.
+func (s *Service) getOrder(orderID string) (*storage.Order, error) {
+	t, err := s.store.GetOrder(orderID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("order %q: %w", orderID, errNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting order: %w", err)
+	}
+
+	return t, nil
+}
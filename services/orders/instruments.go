@@ -0,0 +1,43 @@
+package ordersservice
+
+import (
+	"context"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+)
+
+// instrumentStatuses are the client statuses of the market statuses.
+var instrumentStatuses = map[engineserver.MarketStatus]exchangepb.Instrument_Status{
+	engineserver.MarketTrading: exchangepb.Instrument_TRADING,
+	engineserver.MarketHalted:  exchangepb.Instrument_HALTED,
+}
+
+// ListInstruments returns the markets listed in the exchange, in the order
+// the service was created with.
+func (s *Service) ListInstruments(ctx context.Context, req *exchangepb.ListInstrumentsRequest) (*exchangepb.ListInstrumentsResponse, error) {
+	res := &exchangepb.ListInstrumentsResponse{}
+	for _, ms := range s.markets {
+		res.Instruments = append(res.Instruments, instrumentToPB(&ms))
+	}
+
+	return res, nil
+}
+
+func instrumentToPB(ms *engineserver.MarketSymbol) *exchangepb.Instrument {
+	pb := &exchangepb.Instrument{
+		Pair:     ms.Name(),
+		Base:     ms.Base,
+		Trade:    ms.Trade,
+		Status:   instrumentStatuses[ms.Status],
+		TickSize: ms.TickSize(),
+		LotSize:  max(ms.LotSize, 1),
+	}
+
+	if ms.PriceRange != nil {
+		pb.MinPrice = ms.PriceRange.Min
+		pb.MaxPrice = ms.PriceRange.Max
+	}
+
+	return pb
+}
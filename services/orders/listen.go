@@ -0,0 +1,113 @@
+package ordersservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+	"exchange/services/orders/storage"
+)
+
+// updateOrder applies fn to a stored order and publishes the resulting update,
+// ignoring orders that were not created through this service.
+func (s *Service) updateOrder(orderID string, fn storage.UpdateFunc) error {
+	update, err := s.store.UpdateOrder(orderID, fn)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if update != nil {
+		s.subscribers.publish(update)
+	}
+
+	return nil
+}
+
+func (s *Service) processOrderEvent(ev *enginepb.OrderEvent) error {
+	// Mass cancels are summaries, each order has its own ORDER_CANCELLED event.
+	if ev.Type == enginepb.OrderEvent_MASS_CANCELLED {
+		return nil
+	}
+
+	return s.updateOrder(ev.OrderId, func(o *storage.Order) (*exchangepb.OrderUpdate, error) {
+		return applyOrderEvent(o, ev), nil
+	})
+}
+
+func (s *Service) processMatchEvent(ev *enginepb.MatchEvent) error {
+	txnTime := ev.Time.AsTime()
+
+	err := s.updateOrder(ev.TakerOrderId, func(o *storage.Order) (*exchangepb.OrderUpdate, error) {
+		return applyFill(o, ev.TakerMatchType, ev.MatchedVolume, ev.SettlementPrice, txnTime), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.updateOrder(ev.MakerOrderId, func(o *storage.Order) (*exchangepb.OrderUpdate, error) {
+		return applyFill(o, ev.MakerMatchType, ev.MatchedVolume, ev.SettlementPrice, txnTime), nil
+	})
+}
+
+func (s *Service) processEvent(record *kgo.Record) error {
+	parts := strings.Split(record.Topic, ".")
+
+	if len(parts) != 4 {
+		return fmt.Errorf("invalid topic %q", record.Topic)
+	}
+
+	switch parts[3] {
+	case "orders":
+		orderEvent := &enginepb.OrderEvent{}
+		if err := proto.Unmarshal(record.Value, orderEvent); err != nil {
+			return err
+		}
+
+		return s.processOrderEvent(orderEvent)
+	case "matches":
+		matchEvent := &enginepb.MatchEvent{}
+		if err := proto.Unmarshal(record.Value, matchEvent); err != nil {
+			return err
+		}
+
+		return s.processMatchEvent(matchEvent)
+	default:
+		return fmt.Errorf("unsupported topic suffix %q", parts[3])
+	}
+}
+
+// Listen consumes the engine events of every market and keeps the status of
+// the orders up to date.
+func (s *Service) Listen(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			fetches := s.kafka.PollFetches(ctx)
+			if errs := fetches.Errors(); len(errs) > 0 {
+				for _, err := range errs {
+					log.Printf("Error polling Kafka: %v", err)
+				}
+				continue
+			}
+
+			fetches.EachRecord(func(record *kgo.Record) {
+				if err := s.processEvent(record); err != nil {
+					log.Printf("Error processing record: %v", err)
+				}
+			})
+		}
+	}
+}
@@ -2,9 +2,13 @@ package ordersservice
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/twmb/franz-go/pkg/kgo"
 	"google.golang.org/protobuf/proto"
@@ -12,13 +16,34 @@ import (
 
 	exchangepb "exchange/api/v1"
 	enginepb "exchange/engine/api/v1"
+	engineserver "exchange/engine/server"
+	"exchange/services/orders/storage"
 )
 
+// The number of orders returned by ListOrders when no page size is requested.
+const defaultPageSize = 100
+
 type Service struct {
 	exchangepb.UnimplementedOrdersServiceServer
 
-	// temporary, use db instead
-	orders map[string]*exchangepb.Order
+	// Where orders, fills and status transitions are persisted.
+	store storage.Storage
+
+	// The open StreamOrderUpdates streams, by account.
+	subscribers *subscribers
+
+	// The markets of the engine, to cancel orders in all of them.
+	markets []engineserver.MarketSymbol
+
+	// The markets of the engine by pair, which orders are validated against.
+	instruments map[string]*engineserver.MarketSymbol
+
+	// The open sessions, whose orders are cancelled when they time out.
+	sessions *sessions
+
+	// Held while storing new orders, so that concurrent requests with the same
+	// client order ID create a single order.
+	creating sync.Mutex
 
 	kafka *kgo.Client
 }
@@ -26,52 +51,304 @@ type Service struct {
 func (s *Service) CreateOrder(ctx context.Context, req *exchangepb.CreateOrderRequest) (*exchangepb.Order, error) {
 	fmt.Printf("CreateOrder: %+v\n", req.Order)
 
-	parts := strings.Split(req.Order.Pair, "/")
+	if err := authorizeOrder(ctx, req.Order); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateOrder(req.Order); err != nil {
+		return nil, err
+	}
+
+	requestPB, err := createRequest(req.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	done, err := s.sessions.startCreates([]*exchangepb.Order{req.Order})
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	orders, created, err := s.storeNewOrders([]*exchangepb.Order{req.Order}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// A retried order is only produced again if the engine never acknowledged
+	// it, and the engine drops it if it was already created.
+	if !created && orders[0].Status != exchangepb.Order_PENDING_NEW {
+		return orders[0], nil
+	}
+	requestPB.Order = orders[0]
 
+	if err := s.produce(ctx, requestPB.Order.Pair, requestPB); err != nil {
+		s.untrackNew(requestPB.Order)
+		return nil, err
+	}
+
+	return requestPB.Order, nil
+}
+
+// createRequest returns the engine request creating an order.
+func createRequest(o *exchangepb.Order) (*enginepb.OrderRequest, error) {
 	var orderType enginepb.OrderRequest_Type
-	if req.Order.Type == exchangepb.Order_LIMIT {
+	if o.Type == exchangepb.Order_LIMIT {
 		orderType = enginepb.OrderRequest_LIMIT
-	} else if req.Order.Type == exchangepb.Order_MARKET {
+	} else if o.Type == exchangepb.Order_MARKET {
 		orderType = enginepb.OrderRequest_MARKET
+	} else if o.Type == exchangepb.Order_STOP {
+		orderType = enginepb.OrderRequest_STOP
+	} else if o.Type == exchangepb.Order_TRAILING_STOP {
+		orderType = enginepb.OrderRequest_TRAILING_STOP
+	} else if o.Type == exchangepb.Order_PEGGED && o.Peg.GetType() != exchangepb.Peg_PEG_TYPE_UNSPECIFIED {
+		orderType = enginepb.OrderRequest_PEGGED
 	} else {
-		return nil, fmt.Errorf("order type not supported: %v: %w", req.Order.Type, errors.New("Bad request"))
+		return nil, fmt.Errorf("order type not supported: %v: %w", o.Type, errBadRequest)
 	}
 
-	requestPB := &enginepb.OrderRequest{
-		Type:  orderType,
-		Order: req.Order,
-	}
+	return &enginepb.OrderRequest{Type: orderType, Order: o}, nil
+}
 
+// produce sends an order request to the engine topic of the market of pair.
+func (s *Service) produce(ctx context.Context, pair string, requestPB *enginepb.OrderRequest) error {
 	msg, err := proto.Marshal(requestPB)
 	if err != nil {
-		return nil, fmt.Errorf("error serializing proto: %w", err)
+		return fmt.Errorf("error serializing proto: %w", err)
 	}
 
 	r := &kgo.Record{
-		Topic: fmt.Sprintf("engine.%s.%s", parts[0], parts[1]),
+		Topic: engineTopic(pair),
 		Value: msg,
 	}
 
 	if err := s.kafka.ProduceSync(ctx, r).FirstErr(); err != nil {
-		return nil, fmt.Errorf("error producing record: %w", err)
+		return fmt.Errorf("error producing record: %w", err)
 	}
 
-	s.orders[req.Order.Id] = req.Order
+	return nil
+}
 
-	return req.Order, nil
+// engineTopic returns the topic of the order requests of the market of pair.
+func engineTopic(pair string) string {
+	base, trade, _ := strings.Cut(pair, "/")
+	return fmt.Sprintf("engine.%s.%s", base, trade)
 }
 
-func (s *Service) DeleteOrder(ctx context.Context, req *exchangepb.DeleteOrderRequest) (*emptypb.Empty, error) {
-	fmt.Printf("DeleteOrder: %q\n", req.OrderId)
+// groupTypes are the order types accepted for each order of a group, in the
+// order of the request.
+var groupTypes = map[exchangepb.CreateOrderGroupRequest_Type][][]exchangepb.Order_Type{
+	exchangepb.CreateOrderGroupRequest_OCO: {
+		{exchangepb.Order_LIMIT, exchangepb.Order_STOP},
+		{exchangepb.Order_LIMIT, exchangepb.Order_STOP},
+	},
+	exchangepb.CreateOrderGroupRequest_BRACKET: {
+		{exchangepb.Order_LIMIT},
+		{exchangepb.Order_LIMIT},
+		{exchangepb.Order_STOP},
+	},
+}
+
+func (s *Service) CreateOrderGroup(ctx context.Context, req *exchangepb.CreateOrderGroupRequest) (*exchangepb.CreateOrderGroupResponse, error) {
+	fmt.Printf("CreateOrderGroup: %v %+v\n", req.Type, req.Orders)
 
-	order, ok := s.orders[req.OrderId]
+	types, ok := groupTypes[req.Type]
 	if !ok {
-		return nil, fmt.Errorf("order with id %q not found: %w", req.OrderId, errors.New("not found"))
+		return nil, fmt.Errorf("order group type not supported: %v: %w", req.Type, errBadRequest)
+	}
+
+	if len(req.Orders) != len(types) {
+		return nil, fmt.Errorf("%v order group with %d orders, want %d: %w", req.Type, len(req.Orders), len(types), errBadRequest)
+	}
+
+	for i, o := range req.Orders {
+		if err := authorizeOrder(ctx, o); err != nil {
+			return nil, err
+		}
+
+		if err := s.validateOrder(o); err != nil {
+			return nil, fmt.Errorf("%v order group, order %d: %w", req.Type, i, err)
+		}
+
+		if !slices.Contains(types[i], o.Type) {
+			return nil, fmt.Errorf("%v order group, order %d type %v, want one of %v: %w", req.Type, i, o.Type, types[i], errBadRequest)
+		}
+
+		if o.Pair != req.Orders[0].Pair {
+			return nil, fmt.Errorf("%v order group with pairs %q and %q: %w", req.Type, req.Orders[0].Pair, o.Pair, errBadRequest)
+		}
+	}
+
+	orderType := enginepb.OrderRequest_OCO
+	if req.Type == exchangepb.CreateOrderGroupRequest_BRACKET {
+		orderType = enginepb.OrderRequest_BRACKET
+	}
+
+	done, err := s.sessions.startCreates(req.Orders)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	orders, created, err := s.storeNewOrders(req.Orders, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &exchangepb.CreateOrderGroupResponse{Orders: orders}
+	if !created && orders[0].Status != exchangepb.Order_PENDING_NEW {
+		return res, nil
 	}
 
 	requestPB := &enginepb.OrderRequest{
+		Type:   orderType,
+		Order:  orders[0],
+		Linked: orders[1:],
+	}
+
+	if err := s.produce(ctx, orders[0].Pair, requestPB); err != nil {
+		for _, o := range orders {
+			s.untrackNew(o)
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// untrack forgets an order that never reached the engine.
+func (s *Service) untrack(orderID string) {
+	if err := s.store.DeleteOrder(orderID); err != nil {
+		log.Printf("Error deleting order %q: %v", orderID, err)
+	}
+}
+
+func (s *Service) DeleteOrder(ctx context.Context, req *exchangepb.DeleteOrderRequest) (*emptypb.Empty, error) {
+	fmt.Printf("DeleteOrder: %q\n", req.OrderId)
+
+	requestPB, err := s.cancelRequest(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.produce(ctx, requestPB.Order.Pair, requestPB); err != nil {
+		return nil, err
+	}
+
+	// The order stays tracked until the engine confirms the cancellation.
+	return &emptypb.Empty{}, nil
+}
+
+// cancelRequest returns the engine request cancelling an open order.
+func (s *Service) cancelRequest(ctx context.Context, orderID string) (*enginepb.OrderRequest, error) {
+	t, err := s.getOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, t.Order.AccountId); err != nil {
+		return nil, err
+	}
+
+	if isTerminal(t.Order.Status) {
+		return nil, fmt.Errorf("order with id %q is %v: %w", orderID, t.Order.Status, errBadRequest)
+	}
+
+	return &enginepb.OrderRequest{
 		Type:  enginepb.OrderRequest_CANCEL,
-		Order: order,
+		Order: t.Order,
+	}, nil
+}
+
+// AmendOrder sends the amendment of an open order to the engine. The order is
+// updated once the engine confirms it, with an AMENDED update, or rejects it,
+// with an AMEND_REJECTED update.
+func (s *Service) AmendOrder(ctx context.Context, req *exchangepb.AmendOrderRequest) (*emptypb.Empty, error) {
+	fmt.Printf("AmendOrder: %+v\n", req)
+
+	requestPB, err := s.amendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.produce(ctx, requestPB.Order.Pair, requestPB); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// amendRequest returns the engine request amending an open order, with the
+// volume to keep filled in.
+func (s *Service) amendRequest(ctx context.Context, req *exchangepb.AmendOrderRequest) (*enginepb.OrderRequest, error) {
+	t, err := s.getOrder(req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, t.Order.AccountId); err != nil {
+		return nil, err
+	}
+
+	if isTerminal(t.Order.Status) {
+		return nil, fmt.Errorf("order with id %q is %v: %w", req.OrderId, t.Order.Status, errBadRequest)
+	}
+
+	amend := &exchangepb.AmendOrderRequest{
+		OrderId: req.OrderId,
+		Price:   req.Price,
+		Volume:  req.Volume,
+	}
+	if amend.Volume == 0 {
+		amend.Volume = t.Order.Volume
+	}
+
+	if amend.Price == 0 && amend.Volume == t.Order.Volume {
+		return nil, fmt.Errorf("amendment of order %q without changes: %w", req.OrderId, errBadRequest)
+	}
+
+	if err := s.validateAmend(t.Order, req.Price, req.Volume); err != nil {
+		return nil, err
+	}
+
+	return &enginepb.OrderRequest{
+		Type:  enginepb.OrderRequest_AMEND,
+		Order: t.Order,
+		Amend: amend,
+	}, nil
+}
+
+// CancelAllOrders sends a mass cancel to the market of the requested pair, or
+// to every market. Like DeleteOrder, the orders stay tracked until the engine
+// confirms each cancellation.
+func (s *Service) CancelAllOrders(ctx context.Context, req *exchangepb.CancelAllOrdersRequest) (*emptypb.Empty, error) {
+	fmt.Printf("CancelAllOrders: %+v\n", req)
+
+	req.AccountId = callerAccount(ctx, req.AccountId)
+	if err := authorize(ctx, req.AccountId); err != nil {
+		return nil, err
+	}
+
+	if req.MaxPrice != 0 && req.MinPrice > req.MaxPrice {
+		return nil, fmt.Errorf("min price %d above max price %d: %w", req.MinPrice, req.MaxPrice, errBadRequest)
+	}
+
+	topics := []string{}
+	if req.Pair == "" {
+		for _, market := range s.markets {
+			topics = append(topics, market.Topic())
+		}
+	} else {
+		ms, err := s.instrument(req.Pair)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, ms.Topic())
+	}
+
+	requestPB := &enginepb.OrderRequest{
+		Type:      enginepb.OrderRequest_CANCEL_ALL,
+		CancelAll: req,
 	}
 
 	msg, err := proto.Marshal(requestPB)
@@ -79,32 +356,109 @@ func (s *Service) DeleteOrder(ctx context.Context, req *exchangepb.DeleteOrderRe
 		return nil, fmt.Errorf("error serializing proto: %w", err)
 	}
 
-	parts := strings.Split(order.Pair, "/")
-	r := &kgo.Record{
-		Topic: fmt.Sprintf("engine.%s.%s", parts[0], parts[1]),
-		Value: msg,
+	records := []*kgo.Record{}
+	for _, topic := range topics {
+		records = append(records, &kgo.Record{Topic: topic, Value: msg})
 	}
 
-	if err := s.kafka.ProduceSync(ctx, r).FirstErr(); err != nil {
-		return nil, fmt.Errorf("error producing record: %w", err)
+	if err := s.kafka.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return nil, fmt.Errorf("error producing records: %w", err)
 	}
 
-	delete(s.orders, order.Id)
-
 	return &emptypb.Empty{}, nil
 }
 
-func New() (*Service, error) {
+func (s *Service) GetOrder(ctx context.Context, req *exchangepb.GetOrderRequest) (*exchangepb.Order, error) {
+	t, err := s.getOrder(req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, t.Order.AccountId); err != nil {
+		return nil, err
+	}
+
+	return t.Order, nil
+}
+
+func (s *Service) ListOrders(ctx context.Context, req *exchangepb.ListOrdersRequest) (*exchangepb.ListOrdersResponse, error) {
+	req.AccountId = callerAccount(ctx, req.AccountId)
+	if err := authorize(ctx, req.AccountId); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	// The page token is the sequence of the last order of the previous page.
+	var after uint64
+	if req.PageToken != "" {
+		var err error
+		after, err = strconv.ParseUint(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token %q: %w", req.PageToken, errBadRequest)
+		}
+	}
+
+	filter := storage.Filter{AccountID: req.AccountId, Pair: req.Pair, Status: req.Status}
+
+	// Fetch one extra order to know if there is a next page.
+	orders, err := s.store.ListOrders(filter, after, pageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("error listing orders: %w", err)
+	}
+
+	res := &exchangepb.ListOrdersResponse{}
+	for i, o := range orders {
+		if i == pageSize {
+			res.NextPageToken = strconv.FormatUint(orders[i-1].Sequence, 10)
+			break
+		}
+
+		res.Orders = append(res.Orders, o.Order)
+	}
+
+	return res, nil
+}
+
+// New returns an orders service for the markets, cancelling the orders of the
+// sessions without heartbeats for longer than sessionTimeout, see
+// WatchSessions.
+func New(markets []engineserver.MarketSymbol, store storage.Storage, sessionTimeout time.Duration) (*Service, error) {
+	if sessionTimeout < MinSessionTimeout {
+		return nil, fmt.Errorf("session timeout %v must be at least %v", sessionTimeout, MinSessionTimeout)
+	}
+
+	topics := []string{}
+	for _, market := range markets {
+		topics = append(topics,
+			market.Topic()+".orders",
+			market.Topic()+".matches",
+		)
+	}
+
 	cl, err := kgo.NewClient(
 		kgo.SeedBrokers("localhost:9092"),
+		kgo.ConsumeTopics(topics...),
+		kgo.ConsumerGroup("orders"),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	s := &Service{
-		kafka:  cl,
-		orders: map[string]*exchangepb.Order{},
+		kafka:       cl,
+		store:       store,
+		subscribers: newSubscribers(),
+		markets:     markets,
+		instruments: map[string]*engineserver.MarketSymbol{},
+		sessions:    newSessions(sessionTimeout),
+	}
+
+	for i := range markets {
+		s.instruments[markets[i].Name()] = &markets[i]
 	}
 
 	return s, nil
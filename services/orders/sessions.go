@@ -0,0 +1,258 @@
+package ordersservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	exchangepb "exchange/api/v1"
+)
+
+// MinSessionTimeout is the shortest session timeout of New, leaving clients
+// the time to send their heartbeats and WatchSessions a positive interval.
+const MinSessionTimeout = time.Second
+
+// session is a connection of an account, kept alive by its heartbeats.
+type session struct {
+	accountID string
+
+	lastHeartbeat time.Time
+
+	// The orders of the session being created, not yet produced to the engine.
+	creating int
+
+	// Whether the session timed out. It is kept until its orders are cancelled.
+	expired bool
+}
+
+// sessions are the open sessions of the accounts. They only live in memory, so
+// clients open new sessions when the service restarts.
+type sessions struct {
+	mu sync.Mutex
+
+	// How long a session lives without heartbeats.
+	timeout time.Duration
+
+	byID map[string]*session
+}
+
+func newSessions(timeout time.Duration) *sessions {
+	return &sessions{
+		timeout: timeout,
+		byID:    map[string]*session{},
+	}
+}
+
+// open starts a session for the account and returns its ID.
+func (s *sessions) open(accountID string, now time.Time) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byID[id] = &session{accountID: accountID, lastHeartbeat: now}
+
+	return id, nil
+}
+
+// get returns the session with the given ID, if it is still alive.
+func (s *sessions) get(id string) (*session, error) {
+	ss, ok := s.byID[id]
+	if !ok || ss.expired {
+		return nil, fmt.Errorf("session %q not found or expired: %w", id, errNotFound)
+	}
+
+	return ss, nil
+}
+
+// accountID returns the account of a session, if it is still alive.
+func (s *sessions) accountID(id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ss, err := s.get(id)
+	if err != nil {
+		return "", err
+	}
+
+	return ss.accountID, nil
+}
+
+func (s *sessions) heartbeat(id string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ss, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	ss.lastHeartbeat = now
+
+	return nil
+}
+
+// startCreate checks that an order of the account can be tagged with the
+// session, and holds the session back from expiring until doneCreate, so that
+// the order reaches the engine before the cancellation of the session.
+func (s *sessions) startCreate(id string, accountID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ss, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	if ss.accountID != accountID {
+		return fmt.Errorf("session %q belongs to another account: %w", id, errBadRequest)
+	}
+	ss.creating++
+
+	return nil
+}
+
+func (s *sessions) doneCreate(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ss, ok := s.byID[id]; ok {
+		ss.creating--
+	}
+}
+
+// startCreates calls startCreate for the orders tagged with a session, and
+// returns the function calling doneCreate for them.
+func (s *sessions) startCreates(orders []*exchangepb.Order) (func(), error) {
+	started := []string{}
+	done := func() {
+		for _, id := range started {
+			s.doneCreate(id)
+		}
+	}
+
+	for _, o := range orders {
+		if o.SessionId == "" {
+			continue
+		}
+
+		if err := s.startCreate(o.SessionId, o.AccountId); err != nil {
+			done()
+			return nil, err
+		}
+		started = append(started, o.SessionId)
+	}
+
+	return done, nil
+}
+
+// expire marks the sessions without heartbeats for longer than the timeout as
+// expired, and returns every expired session whose orders are not cancelled
+// yet, by ID.
+func (s *sessions) expire(now time.Time) map[string]*session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := map[string]*session{}
+	for id, ss := range s.byID {
+		if ss.creating > 0 || now.Sub(ss.lastHeartbeat) <= s.timeout {
+			continue
+		}
+
+		ss.expired = true
+		expired[id] = ss
+	}
+
+	return expired
+}
+
+// close forgets an expired session once its orders are cancelled.
+func (s *sessions) close(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.byID, id)
+}
+
+// OpenSession starts a session for the account. Orders created with its ID
+// are cancelled once the session goes without a Heartbeat for longer than the
+// timeout in the response.
+func (s *Service) OpenSession(ctx context.Context, req *exchangepb.OpenSessionRequest) (*exchangepb.Session, error) {
+	req.AccountId = callerAccount(ctx, req.AccountId)
+	if req.AccountId == "" {
+		return nil, fmt.Errorf("account id is required: %w", errBadRequest)
+	}
+
+	if err := authorize(ctx, req.AccountId); err != nil {
+		return nil, err
+	}
+
+	id, err := s.sessions.open(req.AccountId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error opening session: %w", err)
+	}
+
+	return &exchangepb.Session{
+		Id:        id,
+		AccountId: req.AccountId,
+		Timeout:   durationpb.New(s.sessions.timeout),
+	}, nil
+}
+
+func (s *Service) Heartbeat(ctx context.Context, req *exchangepb.HeartbeatRequest) (*emptypb.Empty, error) {
+	accountID, err := s.sessions.accountID(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessions.heartbeat(req.SessionId, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// WatchSessions cancels the orders of the sessions that time out, in every
+// market. A session whose cancellation fails to be produced is retried on the
+// next check.
+func (s *Service) WatchSessions(ctx context.Context) error {
+	// Check often enough to cancel within a fraction of the timeout.
+	ticker := time.NewTicker(s.sessions.timeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			for id, ss := range s.sessions.expire(now) {
+				req := &exchangepb.CancelAllOrdersRequest{
+					Id:        "session." + id,
+					AccountId: ss.accountID,
+					SessionId: id,
+				}
+
+				if _, err := s.CancelAllOrders(ctx, req); err != nil {
+					log.Printf("Error cancelling the orders of session %q: %v", id, err)
+					continue
+				}
+
+				s.sessions.close(id)
+			}
+		}
+	}
+}
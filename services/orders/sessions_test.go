@@ -0,0 +1,121 @@
+package ordersservice
+
+import (
+	"testing"
+	"time"
+
+	exchangepb "exchange/api/v1"
+)
+
+func Test_Sessions(t *testing.T) {
+	start := time.Now()
+	s := newSessions(10 * time.Second)
+
+	alive, err := s.open("alice", start)
+	if err != nil {
+		t.Fatalf("open() unexpected error: %v", err)
+	}
+
+	silent, err := s.open("alice", start)
+	if err != nil {
+		t.Fatalf("open() unexpected error: %v", err)
+	}
+
+	creating, err := s.open("bob", start)
+	if err != nil {
+		t.Fatalf("open() unexpected error: %v", err)
+	}
+
+	if err := s.startCreate(silent, "bob"); err == nil {
+		t.Errorf("startCreate() for another account want error, got nil")
+	}
+
+	done, err := s.startCreates([]*exchangepb.Order{{SessionId: creating, AccountId: "bob"}, {AccountId: "bob"}})
+	if err != nil {
+		t.Fatalf("startCreates() unexpected error: %v", err)
+	}
+
+	if err := s.heartbeat(alive, start.Add(8*time.Second)); err != nil {
+		t.Fatalf("heartbeat() unexpected error: %v", err)
+	}
+
+	// Sessions with orders being created wait for them.
+	expired := s.expire(start.Add(15 * time.Second))
+	if _, ok := expired[silent]; !ok || len(expired) != 1 {
+		t.Errorf("expire() want only %q, got %v", silent, expired)
+	}
+
+	if err := s.heartbeat(silent, start.Add(15*time.Second)); err == nil {
+		t.Errorf("heartbeat() of an expired session want error, got nil")
+	}
+
+	// Expired sessions are returned until closed.
+	done()
+	expired = s.expire(start.Add(16 * time.Second))
+	if _, ok := expired[creating]; !ok || len(expired) != 2 {
+		t.Errorf("expire() want %q and %q, got %v", silent, creating, expired)
+	}
+
+	s.close(silent)
+	s.close(creating)
+	if err := s.startCreate(alive, "alice"); err != nil {
+		t.Errorf("startCreate() unexpected error: %v", err)
+	}
+
+	if len(s.byID) != 1 {
+		t.Errorf("want 1 session left, got %d", len(s.byID))
+	}
+}
+
+func Test_Sessions_Bracket(t *testing.T) {
+	start := time.Now()
+	s := newSessions(10 * time.Second)
+
+	id, err := s.open("alice", start)
+	if err != nil {
+		t.Fatalf("open() unexpected error: %v", err)
+	}
+
+	other, err := s.open("bob", start)
+	if err != nil {
+		t.Fatalf("open() unexpected error: %v", err)
+	}
+
+	// A leg tagged with the session of another account releases the others.
+	bracket := []*exchangepb.Order{
+		{SessionId: id, AccountId: "alice"},
+		{SessionId: id, AccountId: "alice"},
+		{SessionId: other, AccountId: "alice"},
+	}
+	if _, err := s.startCreates(bracket); err == nil {
+		t.Errorf("startCreates() want error, got nil")
+	}
+	if got := s.byID[id].creating; got != 0 {
+		t.Errorf("want 0 orders being created, got %d", got)
+	}
+
+	// The session waits for the entry and both legs to reach the engine, so
+	// that its cancellation finds the whole bracket.
+	bracket[2].SessionId = id
+	done, err := s.startCreates(bracket)
+	if err != nil {
+		t.Fatalf("startCreates() unexpected error: %v", err)
+	}
+
+	if expired := s.expire(start.Add(15 * time.Second)); len(expired) != 1 {
+		t.Errorf("expire() want only %q, got %v", other, expired)
+	}
+
+	done()
+	if _, ok := s.expire(start.Add(15 * time.Second))[id]; !ok {
+		t.Errorf("expire() want %q, got nil", id)
+	}
+}
+
+func Test_New_SessionTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second, 3 * time.Nanosecond, MinSessionTimeout - time.Nanosecond} {
+		if _, err := New(nil, nil, timeout); err == nil {
+			t.Errorf("New() with session timeout %v want error, got nil", timeout)
+		}
+	}
+}
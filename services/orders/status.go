@@ -0,0 +1,142 @@
+package ordersservice
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+	"exchange/services/orders/storage"
+)
+
+// newTrackedOrder prepares an order received from a client to be stored, with
+// the fields owned by the service reset.
+func newTrackedOrder(o *exchangepb.Order) *storage.Order {
+	o.Status = exchangepb.Order_PENDING_NEW
+	o.FilledVolume = 0
+	o.RemainingVolume = o.Volume
+	o.AveragePrice = 0
+
+	return &storage.Order{Order: o}
+}
+
+// isTerminal returns whether the order can no longer change status.
+func isTerminal(status exchangepb.Order_Status) bool {
+	switch status {
+	case exchangepb.Order_FILLED, exchangepb.Order_CANCELLED, exchangepb.Order_REJECTED, exchangepb.Order_EXPIRED:
+		return true
+	}
+
+	return false
+}
+
+// updateTypes are the client updates sent when an order event moves an order
+// to a status.
+var updateTypes = map[exchangepb.Order_Status]exchangepb.OrderUpdate_Type{
+	exchangepb.Order_OPEN:      exchangepb.OrderUpdate_ACCEPTED,
+	exchangepb.Order_CANCELLED: exchangepb.OrderUpdate_CANCELLED,
+	exchangepb.Order_REJECTED:  exchangepb.OrderUpdate_REJECTED,
+	exchangepb.Order_EXPIRED:   exchangepb.OrderUpdate_EXPIRED,
+}
+
+// applyOrderEvent updates the status from an engine order event, and returns
+// the update for clients if the status changed.
+//
+// The .orders and .matches topics are consumed independently, so fills may be
+// applied before the event that opened the order. Statuses never regress.
+func applyOrderEvent(t *storage.Order, ev *enginepb.OrderEvent) *exchangepb.OrderUpdate {
+	if isTerminal(t.Order.Status) {
+		return nil
+	}
+
+	// The engine sets the price of pegged orders.
+	if ev.Price != 0 {
+		t.Order.Price = ev.Price
+	}
+
+	if ev.Type == enginepb.OrderEvent_ORDER_AMENDED {
+		// Fills may still be applied after the amendment, so the volume left
+		// is counted from the fills applied so far.
+		if ev.Volume != 0 {
+			t.Order.Volume = ev.Volume
+			t.Order.RemainingVolume = ev.Volume - t.Order.FilledVolume
+		}
+
+		return &exchangepb.OrderUpdate{
+			Type: exchangepb.OrderUpdate_AMENDED,
+			Time: ev.Time,
+		}
+	}
+
+	if ev.Type == enginepb.OrderEvent_AMEND_REJECTED {
+		return &exchangepb.OrderUpdate{
+			Type:   exchangepb.OrderUpdate_AMEND_REJECTED,
+			Reason: ev.Reason,
+			Time:   ev.Time,
+		}
+	}
+
+	prevStatus := t.Order.Status
+	switch ev.Type {
+	case enginepb.OrderEvent_MAKER_ORDER_INSERTED, enginepb.OrderEvent_ORDER_PENDING:
+		// Stop orders and bracket exits are open while the engine holds them.
+		if t.Order.Status == exchangepb.Order_PENDING_NEW {
+			t.Order.Status = exchangepb.Order_OPEN
+		}
+	case enginepb.OrderEvent_ORDER_CANCELLED, enginepb.OrderEvent_TAKER_ORDER_UNFULFILLED:
+		// An unfulfilled taker order has its missing volume dropped by the engine.
+		t.Order.Status = exchangepb.Order_CANCELLED
+	case enginepb.OrderEvent_ORDER_REJECTED:
+		t.Order.Status = exchangepb.Order_REJECTED
+	case enginepb.OrderEvent_ORDER_EXPIRED:
+		t.Order.Status = exchangepb.Order_EXPIRED
+	}
+
+	if t.Order.Status == prevStatus {
+		return nil
+	}
+
+	return &exchangepb.OrderUpdate{
+		Type:   updateTypes[t.Order.Status],
+		Reason: ev.Reason,
+		Time:   ev.Time,
+	}
+}
+
+// applyFill updates the filled volume, average price and status from one side
+// of an engine match event, and returns the update for clients.
+func applyFill(t *storage.Order, matchType enginepb.MatchType, volume uint64, price uint64, txnTime time.Time) *exchangepb.OrderUpdate {
+	if volume > t.Order.RemainingVolume {
+		volume = t.Order.RemainingVolume
+	}
+
+	if volume == 0 {
+		return nil
+	}
+
+	t.Order.FilledVolume += volume
+	t.Order.RemainingVolume -= volume
+	t.FilledNotional += volume * price
+	t.Order.AveragePrice = t.FilledNotional / t.Order.FilledVolume
+
+	if t.Order.Status != exchangepb.Order_FILLED {
+		if matchType == enginepb.MatchType_ORDER_FULFILLED || t.Order.RemainingVolume == 0 {
+			t.Order.Status = exchangepb.Order_FILLED
+		} else if !isTerminal(t.Order.Status) {
+			t.Order.Status = exchangepb.Order_PARTIALLY_FILLED
+		}
+	}
+
+	updateType := exchangepb.OrderUpdate_PARTIALLY_FILLED
+	if t.Order.Status == exchangepb.Order_FILLED {
+		updateType = exchangepb.OrderUpdate_FILLED
+	}
+
+	return &exchangepb.OrderUpdate{
+		Type:       updateType,
+		FillVolume: volume,
+		FillPrice:  price,
+		Time:       timestamppb.New(txnTime),
+	}
+}
@@ -0,0 +1,213 @@
+package ordersservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	enginepb "exchange/engine/api/v1"
+)
+
+func Test_TrackedOrder(t *testing.T) {
+	// event is either an order event or a match event where the tracked order
+	// is the maker.
+	type event struct {
+		order *enginepb.OrderEvent
+		match *enginepb.MatchEvent
+	}
+
+	testCases := []struct {
+		name   string
+		volume uint64
+		events []event
+		want   *exchangepb.Order
+	}{
+		{
+			name:   "pending_new",
+			volume: 10,
+			want:   &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_PENDING_NEW, RemainingVolume: 10},
+		},
+		{
+			name:   "open",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_OPEN, RemainingVolume: 10},
+		},
+		{
+			name:   "partially_filled",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 4, SettlementPrice: 5}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_PARTIALLY_FILLED, FilledVolume: 4, RemainingVolume: 6, AveragePrice: 5},
+		},
+		{
+			name:   "filled_average_price",
+			volume: 10,
+			events: []event{
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 5, SettlementPrice: 10}},
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_FULFILLED, MatchedVolume: 5, SettlementPrice: 20}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_FILLED, FilledVolume: 10, RemainingVolume: 0, AveragePrice: 15},
+		},
+		{
+			name:   "fill_before_insertion",
+			volume: 10,
+			events: []event{
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 4, SettlementPrice: 5}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_PARTIALLY_FILLED, FilledVolume: 4, RemainingVolume: 6, AveragePrice: 5},
+		},
+		{
+			name:   "cancelled_after_partial_fill",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 4, SettlementPrice: 5}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_CANCELLED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_CANCELLED, FilledVolume: 4, RemainingVolume: 6, AveragePrice: 5},
+		},
+		{
+			name:   "filled_is_terminal",
+			volume: 10,
+			events: []event{
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_FULFILLED, MatchedVolume: 10, SettlementPrice: 5}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_CANCELLED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_FILLED, FilledVolume: 10, AveragePrice: 5},
+		},
+		{
+			name:   "rejected",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_REJECTED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_REJECTED, RemainingVolume: 10},
+		},
+		{
+			name:   "taker_unfulfilled",
+			volume: 10,
+			events: []event{
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 3, SettlementPrice: 5}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_TAKER_ORDER_UNFULFILLED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_CANCELLED, FilledVolume: 3, RemainingVolume: 7, AveragePrice: 5},
+		},
+		{
+			name:   "expired_after_partial_fill",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 4, SettlementPrice: 5}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_EXPIRED}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_CANCELLED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_EXPIRED, FilledVolume: 4, RemainingVolume: 6, AveragePrice: 5},
+		},
+		{
+			name:   "triggered_stop",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_PENDING}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_TRIGGERED}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_OPEN, RemainingVolume: 10},
+		},
+		{
+			name:   "cancelled_linked_order",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_PENDING}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_CANCELLED, Reason: "a linked order was filled or cancelled"}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_CANCELLED, RemainingVolume: 10},
+		},
+		{
+			name:   "amended_pegged_order",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED, Price: 10}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_AMENDED, Price: 11}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Price: 11, Status: exchangepb.Order_OPEN, RemainingVolume: 10},
+		},
+		{
+			name:   "amended_before_earlier_fill",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_AMENDED, Price: 9, Volume: 6}},
+				{match: &enginepb.MatchEvent{MakerMatchType: enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, MatchedVolume: 4, SettlementPrice: 10}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 6, Price: 9, Status: exchangepb.Order_PARTIALLY_FILLED, FilledVolume: 4, RemainingVolume: 2, AveragePrice: 10},
+		},
+		{
+			name:   "amend_rejected",
+			volume: 10,
+			events: []event{
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}},
+				{order: &enginepb.OrderEvent{Type: enginepb.OrderEvent_AMEND_REJECTED, Reason: "rejected"}},
+			},
+			want: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_OPEN, RemainingVolume: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracked := newTrackedOrder(&exchangepb.Order{Id: "1", Volume: tc.volume})
+
+			for _, ev := range tc.events {
+				if ev.order != nil {
+					applyOrderEvent(tracked, ev.order)
+				} else {
+					applyFill(tracked, ev.match.MakerMatchType, ev.match.MatchedVolume, ev.match.SettlementPrice, time.Now())
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, tracked.Order, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_TrackedOrderUpdates(t *testing.T) {
+	tracked := newTrackedOrder(&exchangepb.Order{Id: "1", Volume: 10})
+
+	var got []*exchangepb.OrderUpdate
+	got = append(got, applyOrderEvent(tracked, &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}))
+	// Inserting twice does not change the status, so there is no update.
+	got = append(got, applyOrderEvent(tracked, &enginepb.OrderEvent{Type: enginepb.OrderEvent_MAKER_ORDER_INSERTED}))
+	got = append(got, applyFill(tracked, enginepb.MatchType_ORDER_PARTIALLY_FULFILLED, 4, 5, time.Unix(1, 0)))
+	got = append(got, applyFill(tracked, enginepb.MatchType_ORDER_FULFILLED, 6, 5, time.Unix(2, 0)))
+	// Fills beyond the order volume are ignored.
+	got = append(got, applyFill(tracked, enginepb.MatchType_ORDER_FULFILLED, 1, 5, time.Unix(3, 0)))
+
+	want := []*exchangepb.OrderUpdate{
+		{Type: exchangepb.OrderUpdate_ACCEPTED},
+		nil,
+		{Type: exchangepb.OrderUpdate_PARTIALLY_FILLED, FillVolume: 4, FillPrice: 5, Time: timestamppb.New(time.Unix(1, 0))},
+		{Type: exchangepb.OrderUpdate_FILLED, FillVolume: 6, FillPrice: 5, Time: timestamppb.New(time.Unix(2, 0))},
+		nil,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected updates (-want +got):\n%s", diff)
+	}
+
+	rejected := newTrackedOrder(&exchangepb.Order{Id: "2", Volume: 10})
+	update := applyOrderEvent(rejected, &enginepb.OrderEvent{Type: enginepb.OrderEvent_ORDER_REJECTED, Reason: "volume must be positive"})
+	wantRejected := &exchangepb.OrderUpdate{Type: exchangepb.OrderUpdate_REJECTED, Reason: "volume must be positive"}
+	if diff := cmp.Diff(wantRejected, update, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected rejection update (-want +got):\n%s", diff)
+	}
+}
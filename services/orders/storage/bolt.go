@@ -0,0 +1,467 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	exchangepb "exchange/api/v1"
+)
+
+// Bolt is a Storage embedded in a local bbolt database file.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens or creates the database at path and migrates it to the
+// latest schema version.
+func OpenBolt(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", path, err)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating %q: %w", path, err)
+	}
+
+	return &Bolt{db: db}, nil
+}
+
+// uint64Key encodes numbers big endian, so that keys sort numerically.
+func uint64Key(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
+// An order is stored as its filled notional followed by the order proto.
+func encodeOrder(o *Order) ([]byte, error) {
+	msg, err := proto.Marshal(o.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(uint64Key(o.FilledNotional), msg...), nil
+}
+
+func decodeOrder(sequence []byte, v []byte) (*Order, error) {
+	if len(v) < 8 {
+		return nil, fmt.Errorf("corrupted order record of %d bytes", len(v))
+	}
+
+	o := &Order{
+		Order:          &exchangepb.Order{},
+		FilledNotional: binary.BigEndian.Uint64(v),
+		Sequence:       binary.BigEndian.Uint64(sequence),
+	}
+	if err := proto.Unmarshal(v[8:], o.Order); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func encodeFill(f *Fill) []byte {
+	b := make([]byte, 24)
+	binary.BigEndian.PutUint64(b, f.Volume)
+	binary.BigEndian.PutUint64(b[8:], f.Price)
+	binary.BigEndian.PutUint64(b[16:], uint64(f.Time.UnixNano()))
+	return b
+}
+
+func decodeFill(v []byte) Fill {
+	return Fill{
+		Volume: binary.BigEndian.Uint64(v),
+		Price:  binary.BigEndian.Uint64(v[8:]),
+		Time:   time.Unix(0, int64(binary.BigEndian.Uint64(v[16:]))),
+	}
+}
+
+func encodeTransition(t *Transition) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint32(b, uint32(t.From))
+	binary.BigEndian.PutUint32(b[4:], uint32(t.To))
+	binary.BigEndian.PutUint64(b[8:], uint64(t.Time.UnixNano()))
+	return b
+}
+
+func decodeTransition(v []byte) Transition {
+	return Transition{
+		From: exchangepb.Order_Status(binary.BigEndian.Uint32(v)),
+		To:   exchangepb.Order_Status(binary.BigEndian.Uint32(v[4:])),
+		Time: time.Unix(0, int64(binary.BigEndian.Uint64(v[8:]))),
+	}
+}
+
+// getOrder returns the order and its sequence key, or ErrNotFound.
+func getOrder(tx *bolt.Tx, orderID string) (*Order, []byte, error) {
+	seq := tx.Bucket(orderIDsBucket).Get([]byte(orderID))
+	if seq == nil {
+		return nil, nil, fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+	}
+
+	o, err := decodeOrder(seq, tx.Bucket(ordersBucket).Get(seq))
+	if err != nil {
+		return nil, nil, fmt.Errorf("order %q: %w", orderID, err)
+	}
+
+	// bbolt memory is only valid during the transaction.
+	return o, append([]byte{}, seq...), nil
+}
+
+// appendToOrderBucket adds a value to the per-order nested bucket of parent,
+// keyed by an increasing sequence.
+func appendToOrderBucket(tx *bolt.Tx, parent []byte, orderID string, v []byte) error {
+	b, err := tx.Bucket(parent).CreateBucketIfNotExists([]byte(orderID))
+	if err != nil {
+		return err
+	}
+
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	return b.Put(uint64Key(seq), v)
+}
+
+func (s *Bolt) CreateOrder(o *Order) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		ids := tx.Bucket(orderIDsBucket)
+		if ids.Get([]byte(o.Order.Id)) != nil {
+			return fmt.Errorf("order %q: %w", o.Order.Id, ErrExists)
+		}
+
+		orders := tx.Bucket(ordersBucket)
+		seq, err := orders.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		v, err := encodeOrder(o)
+		if err != nil {
+			return err
+		}
+
+		if err := orders.Put(uint64Key(seq), v); err != nil {
+			return err
+		}
+
+		if err := ids.Put([]byte(o.Order.Id), uint64Key(seq)); err != nil {
+			return err
+		}
+
+		if o.Order.ClientOrderId != "" {
+			key := clientKey(o.Order.AccountId, o.Order.ClientOrderId)
+			if err := tx.Bucket(clientIDsBucket).Put([]byte(key), []byte(o.Order.Id)); err != nil {
+				return err
+			}
+		}
+
+		o.Sequence = seq
+		return nil
+	})
+}
+
+func (s *Bolt) DeleteOrder(orderID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		o, seq, err := getOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(ordersBucket).Delete(seq); err != nil {
+			return err
+		}
+
+		if o.Order.ClientOrderId != "" {
+			clientIDs := tx.Bucket(clientIDsBucket)
+			key := []byte(clientKey(o.Order.AccountId, o.Order.ClientOrderId))
+			if string(clientIDs.Get(key)) == orderID {
+				if err := clientIDs.Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Bucket(orderIDsBucket).Delete([]byte(orderID)); err != nil {
+			return err
+		}
+
+		for _, parent := range [][]byte{fillsBucket, transitionsBucket} {
+			err := tx.Bucket(parent).DeleteBucket([]byte(orderID))
+			if err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *Bolt) GetOrder(orderID string) (*Order, error) {
+	var o *Order
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		o, _, err = getOrder(tx, orderID)
+		return err
+	})
+
+	return o, err
+}
+
+func (s *Bolt) GetClientOrder(accountID string, clientOrderID string) (*Order, error) {
+	var o *Order
+	err := s.db.View(func(tx *bolt.Tx) error {
+		orderID := tx.Bucket(clientIDsBucket).Get([]byte(clientKey(accountID, clientOrderID)))
+		if orderID == nil {
+			return fmt.Errorf("client order %q of account %q: %w", clientOrderID, accountID, ErrNotFound)
+		}
+
+		var err error
+		o, _, err = getOrder(tx, string(orderID))
+		return err
+	})
+
+	return o, err
+}
+
+func (s *Bolt) ListOrders(filter Filter, after uint64, limit int) ([]*Order, error) {
+	orders := []*Order{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(ordersBucket).Cursor()
+		for k, v := c.Seek(uint64Key(after + 1)); k != nil && len(orders) < limit; k, v = c.Next() {
+			o, err := decodeOrder(k, v)
+			if err != nil {
+				return err
+			}
+
+			if filter.Match(o.Order) {
+				orders = append(orders, o)
+			}
+		}
+
+		return nil
+	})
+
+	return orders, err
+}
+
+func (s *Bolt) UpdateOrder(orderID string, fn UpdateFunc) (*exchangepb.OrderUpdate, error) {
+	var update *exchangepb.OrderUpdate
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		o, seq, err := getOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		prevStatus := o.Order.Status
+		update, err = fn(o)
+		if err != nil {
+			return err
+		}
+
+		if fill := fillOf(update); fill != nil {
+			if err := appendToOrderBucket(tx, fillsBucket, orderID, encodeFill(fill)); err != nil {
+				return err
+			}
+		}
+
+		if o.Order.Status != prevStatus {
+			t := &Transition{From: prevStatus, To: o.Order.Status, Time: time.Now()}
+			if err := appendToOrderBucket(tx, transitionsBucket, orderID, encodeTransition(t)); err != nil {
+				return err
+			}
+		}
+
+		if update != nil {
+			if err := putUpdate(tx, o, update); err != nil {
+				return err
+			}
+		}
+
+		v, err := encodeOrder(o)
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(ordersBucket).Put(seq, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return update, nil
+}
+
+// putUpdate assigns the update its sequence and stores it, indexed by the
+// account of the order.
+func putUpdate(tx *bolt.Tx, o *Order, update *exchangepb.OrderUpdate) error {
+	updates := tx.Bucket(updatesBucket)
+	seq, err := updates.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	update.Sequence = seq
+	update.Order = proto.Clone(o.Order).(*exchangepb.Order)
+
+	v, err := proto.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	if err := updates.Put(uint64Key(seq), v); err != nil {
+		return err
+	}
+
+	if o.Order.AccountId == "" {
+		return nil
+	}
+
+	account, err := tx.Bucket(accountsBucket).CreateBucketIfNotExists([]byte(o.Order.AccountId))
+	if err != nil {
+		return err
+	}
+
+	return account.Put(uint64Key(seq), nil)
+}
+
+func (s *Bolt) ListUpdates(accountID string, after uint64, limit int) ([]*exchangepb.OrderUpdate, error) {
+	updates := []*exchangepb.OrderUpdate{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if accountID == "" {
+			return nil
+		}
+
+		account := tx.Bucket(accountsBucket).Bucket([]byte(accountID))
+		if account == nil {
+			return nil
+		}
+
+		all := tx.Bucket(updatesBucket)
+		c := account.Cursor()
+		for k, _ := c.Seek(uint64Key(after + 1)); k != nil && len(updates) < limit; k, _ = c.Next() {
+			u := &exchangepb.OrderUpdate{}
+			if err := proto.Unmarshal(all.Get(k), u); err != nil {
+				return fmt.Errorf("update %d: %w", binary.BigEndian.Uint64(k), err)
+			}
+
+			updates = append(updates, u)
+		}
+
+		return nil
+	})
+
+	return updates, err
+}
+
+// forEachInOrderBucket calls fn for every value of the per-order nested bucket
+// of parent, in insertion order.
+func (s *Bolt) forEachInOrderBucket(parent []byte, orderID string, fn func(v []byte)) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(orderIDsBucket).Get([]byte(orderID)) == nil {
+			return fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+		}
+
+		b := tx.Bucket(parent).Bucket([]byte(orderID))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(_, v []byte) error {
+			fn(v)
+			return nil
+		})
+	})
+}
+
+func (s *Bolt) Fills(orderID string) ([]Fill, error) {
+	fills := []Fill{}
+	err := s.forEachInOrderBucket(fillsBucket, orderID, func(v []byte) {
+		fills = append(fills, decodeFill(v))
+	})
+
+	return fills, err
+}
+
+func (s *Bolt) Transitions(orderID string) ([]Transition, error) {
+	transitions := []Transition{}
+	err := s.forEachInOrderBucket(transitionsBucket, orderID, func(v []byte) {
+		transitions = append(transitions, decodeTransition(v))
+	})
+
+	return transitions, err
+}
+
+func (s *Bolt) CreateAPIKey(k *exchangepb.APIKey) error {
+	v, err := proto.Marshal(k)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		keys := tx.Bucket(apiKeysBucket)
+		if keys.Get([]byte(k.Id)) != nil {
+			return fmt.Errorf("API key %q: %w", k.Id, ErrExists)
+		}
+
+		return keys.Put([]byte(k.Id), v)
+	})
+}
+
+func (s *Bolt) GetAPIKey(keyID string) (*exchangepb.APIKey, error) {
+	k := &exchangepb.APIKey{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(apiKeysBucket).Get([]byte(keyID))
+		if v == nil {
+			return fmt.Errorf("API key %q: %w", keyID, ErrNotFound)
+		}
+
+		return proto.Unmarshal(v, k)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func (s *Bolt) ListAPIKeys(accountID string) ([]*exchangepb.APIKey, error) {
+	keys := []*exchangepb.APIKey{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(apiKeysBucket).ForEach(func(_, v []byte) error {
+			k := &exchangepb.APIKey{}
+			if err := proto.Unmarshal(v, k); err != nil {
+				return err
+			}
+
+			if accountID == "" || k.AccountId == accountID {
+				keys = append(keys, k)
+			}
+			return nil
+		})
+	})
+
+	return keys, err
+}
+
+func (s *Bolt) DeleteAPIKey(keyID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		keys := tx.Bucket(apiKeysBucket)
+		if keys.Get([]byte(keyID)) == nil {
+			return fmt.Errorf("API key %q: %w", keyID, ErrNotFound)
+		}
+
+		return keys.Delete([]byte(keyID))
+	})
+}
+
+func (s *Bolt) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	exchangepb "exchange/api/v1"
+)
+
+// Memory is a Storage that keeps everything in memory, meant for tests.
+type Memory struct {
+	mu sync.Mutex
+
+	orders map[string]*Order
+
+	// The order IDs in creation order.
+	orderIDs []string
+
+	// The order IDs by account and client order ID.
+	clientIDs map[string]string
+
+	fills map[string][]Fill
+
+	transitions map[string][]Transition
+
+	updates []*exchangepb.OrderUpdate
+
+	apiKeys map[string]*exchangepb.APIKey
+
+	sequence uint64
+
+	updateSequence uint64
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		orders:      map[string]*Order{},
+		clientIDs:   map[string]string{},
+		fills:       map[string][]Fill{},
+		transitions: map[string][]Transition{},
+		apiKeys:     map[string]*exchangepb.APIKey{},
+	}
+}
+
+// clone returns a deep copy so that callers never share memory with the store.
+func clone(o *Order) *Order {
+	return &Order{
+		Order:          proto.Clone(o.Order).(*exchangepb.Order),
+		FilledNotional: o.FilledNotional,
+		Sequence:       o.Sequence,
+	}
+}
+
+func (m *Memory) CreateOrder(o *Order) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.orders[o.Order.Id]; ok {
+		return fmt.Errorf("order %q: %w", o.Order.Id, ErrExists)
+	}
+
+	m.sequence++
+	o.Sequence = m.sequence
+	m.orders[o.Order.Id] = clone(o)
+	m.orderIDs = append(m.orderIDs, o.Order.Id)
+	if o.Order.ClientOrderId != "" {
+		m.clientIDs[clientKey(o.Order.AccountId, o.Order.ClientOrderId)] = o.Order.Id
+	}
+
+	return nil
+}
+
+func (m *Memory) DeleteOrder(orderID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	o, ok := m.orders[orderID]
+	if !ok {
+		return fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+	}
+
+	key := clientKey(o.Order.AccountId, o.Order.ClientOrderId)
+	if o.Order.ClientOrderId != "" && m.clientIDs[key] == orderID {
+		delete(m.clientIDs, key)
+	}
+
+	delete(m.orders, orderID)
+	delete(m.fills, orderID)
+	delete(m.transitions, orderID)
+	for i, id := range m.orderIDs {
+		if id == orderID {
+			m.orderIDs = append(m.orderIDs[:i], m.orderIDs[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) GetOrder(orderID string) (*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	o, ok := m.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+	}
+
+	return clone(o), nil
+}
+
+func (m *Memory) GetClientOrder(accountID string, clientOrderID string) (*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	orderID, ok := m.clientIDs[clientKey(accountID, clientOrderID)]
+	if !ok {
+		return nil, fmt.Errorf("client order %q of account %q: %w", clientOrderID, accountID, ErrNotFound)
+	}
+
+	return clone(m.orders[orderID]), nil
+}
+
+func (m *Memory) ListOrders(filter Filter, after uint64, limit int) ([]*Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	orders := []*Order{}
+	for _, id := range m.orderIDs {
+		if len(orders) == limit {
+			break
+		}
+
+		o := m.orders[id]
+		if o.Sequence <= after || !filter.Match(o.Order) {
+			continue
+		}
+
+		orders = append(orders, clone(o))
+	}
+
+	return orders, nil
+}
+
+func (m *Memory) UpdateOrder(orderID string, fn UpdateFunc) (*exchangepb.OrderUpdate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+	}
+
+	o := clone(stored)
+	update, err := fn(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if fill := fillOf(update); fill != nil {
+		m.fills[orderID] = append(m.fills[orderID], *fill)
+	}
+
+	if o.Order.Status != stored.Order.Status {
+		m.transitions[orderID] = append(m.transitions[orderID], Transition{
+			From: stored.Order.Status,
+			To:   o.Order.Status,
+			Time: time.Now(),
+		})
+	}
+
+	if update != nil {
+		m.updateSequence++
+		update.Sequence = m.updateSequence
+		update.Order = proto.Clone(o.Order).(*exchangepb.Order)
+		m.updates = append(m.updates, proto.Clone(update).(*exchangepb.OrderUpdate))
+	}
+
+	o.Sequence = stored.Sequence
+	m.orders[orderID] = o
+
+	return update, nil
+}
+
+func (m *Memory) ListUpdates(accountID string, after uint64, limit int) ([]*exchangepb.OrderUpdate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updates := []*exchangepb.OrderUpdate{}
+	for _, u := range m.updates {
+		if len(updates) == limit {
+			break
+		}
+
+		if u.Sequence <= after || accountID == "" || u.Order.AccountId != accountID {
+			continue
+		}
+
+		updates = append(updates, proto.Clone(u).(*exchangepb.OrderUpdate))
+	}
+
+	return updates, nil
+}
+
+func (m *Memory) Fills(orderID string) ([]Fill, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.orders[orderID]; !ok {
+		return nil, fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+	}
+
+	return append([]Fill{}, m.fills[orderID]...), nil
+}
+
+func (m *Memory) Transitions(orderID string) ([]Transition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.orders[orderID]; !ok {
+		return nil, fmt.Errorf("order %q: %w", orderID, ErrNotFound)
+	}
+
+	return append([]Transition{}, m.transitions[orderID]...), nil
+}
+
+func (m *Memory) CreateAPIKey(k *exchangepb.APIKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.apiKeys[k.Id]; ok {
+		return fmt.Errorf("API key %q: %w", k.Id, ErrExists)
+	}
+
+	m.apiKeys[k.Id] = proto.Clone(k).(*exchangepb.APIKey)
+	return nil
+}
+
+func (m *Memory) GetAPIKey(keyID string) (*exchangepb.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, ok := m.apiKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("API key %q: %w", keyID, ErrNotFound)
+	}
+
+	return proto.Clone(k).(*exchangepb.APIKey), nil
+}
+
+func (m *Memory) ListAPIKeys(accountID string) ([]*exchangepb.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := []*exchangepb.APIKey{}
+	for _, k := range m.apiKeys {
+		if accountID == "" || k.AccountId == accountID {
+			keys = append(keys, proto.Clone(k).(*exchangepb.APIKey))
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b *exchangepb.APIKey) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return keys, nil
+}
+
+func (m *Memory) DeleteAPIKey(keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.apiKeys[keyID]; !ok {
+		return fmt.Errorf("API key %q: %w", keyID, ErrNotFound)
+	}
+
+	delete(m.apiKeys, keyID)
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
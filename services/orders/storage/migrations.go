@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	metaBucket        = []byte("meta")
+	ordersBucket      = []byte("orders")
+	orderIDsBucket    = []byte("order_ids")
+	fillsBucket       = []byte("fills")
+	transitionsBucket = []byte("transitions")
+	updatesBucket     = []byte("updates")
+	accountsBucket    = []byte("account_updates")
+	clientIDsBucket   = []byte("client_order_ids")
+	apiKeysBucket     = []byte("api_keys")
+
+	versionKey = []byte("version")
+)
+
+// migrations are applied in order, each in its own transaction. The schema
+// version stored in the meta bucket is the number of migrations applied.
+//
+// Never modify or reorder a migration that has been released, append a new one
+// instead.
+var migrations = []func(tx *bolt.Tx) error{
+	// 1: orders by sequence, an ID index, and fills and transitions by order ID.
+	func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{ordersBucket, orderIDsBucket, fillsBucket, transitionsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	// 2: order updates by sequence, and their sequences by account.
+	func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{updatesBucket, accountsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	// 3: order IDs by account and client order ID.
+	func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(clientIDsBucket)
+		return err
+	},
+	// 4: API keys by ID.
+	func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(apiKeysBucket)
+		return err
+	},
+}
+
+func schemaVersion(tx *bolt.Tx) uint64 {
+	v := tx.Bucket(metaBucket).Get(versionKey)
+	if v == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(v)
+}
+
+// migrate brings the database schema up to date.
+func migrate(db *bolt.DB) error {
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(metaBucket)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var version uint64
+	if err := db.View(func(tx *bolt.Tx) error {
+		version = schemaVersion(tx)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if version > uint64(len(migrations)) {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(migrations))
+	}
+
+	for i := version; i < uint64(len(migrations)); i++ {
+		err := db.Update(func(tx *bolt.Tx) error {
+			if err := migrations[i](tx); err != nil {
+				return err
+			}
+
+			return tx.Bucket(metaBucket).Put(versionKey, uint64Key(i+1))
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
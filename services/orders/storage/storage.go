@@ -0,0 +1,151 @@
+// Package storage persists the orders tracked by the orders service, along with
+// their fills and status transitions, and the API keys of its clients.
+package storage
+
+import (
+	"errors"
+	"time"
+
+	exchangepb "exchange/api/v1"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrExists   = errors.New("already exists")
+)
+
+// Order is the stored state of an order.
+type Order struct {
+	// The order as seen by clients, including its status and fill information.
+	Order *exchangepb.Order
+
+	// The sum of price * volume over every fill, to compute the average price
+	// without accumulating rounding errors.
+	FilledNotional uint64
+
+	// The position of the order in creation order, assigned by the storage.
+	Sequence uint64
+}
+
+// Fill is one execution of an order.
+type Fill struct {
+	Volume uint64
+
+	Price uint64
+
+	Time time.Time
+}
+
+// fillOf returns the fill described by an update, if any.
+func fillOf(u *exchangepb.OrderUpdate) *Fill {
+	if u == nil || u.FillVolume == 0 {
+		return nil
+	}
+
+	return &Fill{Volume: u.FillVolume, Price: u.FillPrice, Time: u.Time.AsTime()}
+}
+
+// Transition is a change in the status of an order.
+type Transition struct {
+	From exchangepb.Order_Status
+
+	To exchangepb.Order_Status
+
+	Time time.Time
+}
+
+// clientKey is the key of an order in the client order ID index.
+func clientKey(accountID string, clientOrderID string) string {
+	return accountID + "\x00" + clientOrderID
+}
+
+// Filter selects orders by their fields. Empty or unspecified fields match
+// every order.
+type Filter struct {
+	AccountID string
+
+	Pair string
+
+	Status exchangepb.Order_Status
+}
+
+// Match returns whether the order is selected by the filter.
+func (f Filter) Match(o *exchangepb.Order) bool {
+	if f.AccountID != "" && o.AccountId != f.AccountID {
+		return false
+	}
+
+	if f.Pair != "" && o.Pair != f.Pair {
+		return false
+	}
+
+	if f.Status != exchangepb.Order_ORDER_STATUS_UNSPECIFIED && o.Status != f.Status {
+		return false
+	}
+
+	return true
+}
+
+// UpdateFunc modifies an order in place and returns the update describing the
+// modification, or nil if there is nothing to tell clients. Returning an error
+// discards the modification.
+type UpdateFunc func(o *Order) (*exchangepb.OrderUpdate, error)
+
+// Storage is where the orders service keeps its orders. Implementations must
+// be safe for concurrent use.
+type Storage interface {
+	// CreateOrder stores a new order and assigns its sequence. Returns ErrExists
+	// if an order with the same ID is already stored. An order with a client
+	// order ID replaces any other of its account in the client order ID index.
+	CreateOrder(o *Order) error
+
+	// DeleteOrder removes an order with its fills and transitions. It is meant
+	// for orders that never reached the engine.
+	DeleteOrder(orderID string) error
+
+	// GetOrder returns the order with the given ID, or ErrNotFound.
+	GetOrder(orderID string) (*Order, error)
+
+	// GetClientOrder returns the last order created by the account with the
+	// client order ID, or ErrNotFound.
+	GetClientOrder(accountID string, clientOrderID string) (*Order, error)
+
+	// ListOrders returns up to limit orders matching the filter, with a
+	// sequence greater than after, in creation order.
+	ListOrders(filter Filter, after uint64, limit int) ([]*Order, error)
+
+	// UpdateOrder applies fn to the order with the given ID and persists the
+	// result in a single transaction, together with the returned update, its
+	// fill if any, and a transition if the status changed.
+	//
+	// The stored update is returned with its sequence assigned and a copy of
+	// the updated order. Returns ErrNotFound if the order is not stored.
+	UpdateOrder(orderID string, fn UpdateFunc) (*exchangepb.OrderUpdate, error)
+
+	// ListUpdates returns up to limit updates of the account's orders, with a
+	// sequence greater than after, oldest first. Orders without an account
+	// have no updates listed.
+	ListUpdates(accountID string, after uint64, limit int) ([]*exchangepb.OrderUpdate, error)
+
+	// Fills returns the fills of an order, oldest first.
+	Fills(orderID string) ([]Fill, error)
+
+	// Transitions returns the status transitions of an order, oldest first.
+	Transitions(orderID string) ([]Transition, error)
+
+	// CreateAPIKey stores a new API key with its secret. Returns ErrExists if
+	// a key with the same ID is already stored.
+	CreateAPIKey(k *exchangepb.APIKey) error
+
+	// GetAPIKey returns the API key with the given ID, or ErrNotFound.
+	GetAPIKey(keyID string) (*exchangepb.APIKey, error)
+
+	// ListAPIKeys returns the API keys of the account, or every key if it is
+	// empty, by ID.
+	ListAPIKeys(accountID string) ([]*exchangepb.APIKey, error)
+
+	// DeleteAPIKey removes an API key, or returns ErrNotFound.
+	DeleteAPIKey(keyID string) error
+
+	Close() error
+}
@@ -0,0 +1,420 @@
+package storage_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	exchangepb "exchange/api/v1"
+	"exchange/services/orders/storage"
+)
+
+// implementations returns a fresh instance of every Storage implementation.
+func implementations(t *testing.T) map[string]storage.Storage {
+	b, err := storage.OpenBolt(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("OpenBolt() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+
+	return map[string]storage.Storage{
+		"memory": storage.NewMemory(),
+		"bolt":   b,
+	}
+}
+
+func Test_CreateGetDelete(t *testing.T) {
+	for name, s := range implementations(t) {
+		t.Run(name, func(t *testing.T) {
+			o := &storage.Order{Order: &exchangepb.Order{Id: "1", Pair: "A/B", Volume: 10}}
+			if err := s.CreateOrder(o); err != nil {
+				t.Fatalf("CreateOrder() unexpected error: %v", err)
+			}
+
+			if o.Sequence == 0 {
+				t.Errorf("CreateOrder() did not assign a sequence")
+			}
+
+			if err := s.CreateOrder(&storage.Order{Order: &exchangepb.Order{Id: "1"}}); !errors.Is(err, storage.ErrExists) {
+				t.Errorf("CreateOrder() duplicate got error %v, want %v", err, storage.ErrExists)
+			}
+
+			got, err := s.GetOrder("1")
+			if err != nil {
+				t.Fatalf("GetOrder() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(o, got, protocmp.Transform()); diff != "" {
+				t.Errorf("GetOrder() unexpected order (-want +got):\n%s", diff)
+			}
+
+			if err := s.DeleteOrder("1"); err != nil {
+				t.Fatalf("DeleteOrder() unexpected error: %v", err)
+			}
+
+			if _, err := s.GetOrder("1"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("GetOrder() after delete got error %v, want %v", err, storage.ErrNotFound)
+			}
+		})
+	}
+}
+
+func Test_GetClientOrder(t *testing.T) {
+	for name, s := range implementations(t) {
+		t.Run(name, func(t *testing.T) {
+			orders := []*exchangepb.Order{
+				{Id: "1", AccountId: "alice", ClientOrderId: "a"},
+				{Id: "2", AccountId: "bob", ClientOrderId: "a"},
+				{Id: "3", AccountId: "alice"},
+			}
+			for _, o := range orders {
+				if err := s.CreateOrder(&storage.Order{Order: o}); err != nil {
+					t.Fatalf("CreateOrder() unexpected error: %v", err)
+				}
+			}
+
+			got, err := s.GetClientOrder("alice", "a")
+			if err != nil {
+				t.Fatalf("GetClientOrder() unexpected error: %v", err)
+			}
+
+			if got.Order.Id != "1" {
+				t.Errorf("GetClientOrder() got order %q, want %q", got.Order.Id, "1")
+			}
+
+			if _, err := s.GetClientOrder("alice", "b"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("GetClientOrder() unknown got error %v, want %v", err, storage.ErrNotFound)
+			}
+
+			// A later order with the same client order ID replaces the first
+			// one in the index, and deleting the first one keeps it.
+			if err := s.CreateOrder(&storage.Order{Order: &exchangepb.Order{Id: "4", AccountId: "alice", ClientOrderId: "a"}}); err != nil {
+				t.Fatalf("CreateOrder() unexpected error: %v", err)
+			}
+
+			if err := s.DeleteOrder("1"); err != nil {
+				t.Fatalf("DeleteOrder() unexpected error: %v", err)
+			}
+
+			got, err = s.GetClientOrder("alice", "a")
+			if err != nil {
+				t.Fatalf("GetClientOrder() unexpected error: %v", err)
+			}
+
+			if got.Order.Id != "4" {
+				t.Errorf("GetClientOrder() got order %q, want %q", got.Order.Id, "4")
+			}
+
+			if err := s.DeleteOrder("4"); err != nil {
+				t.Fatalf("DeleteOrder() unexpected error: %v", err)
+			}
+
+			if _, err := s.GetClientOrder("alice", "a"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("GetClientOrder() after delete got error %v, want %v", err, storage.ErrNotFound)
+			}
+		})
+	}
+}
+
+func Test_ListOrders(t *testing.T) {
+	orders := []*exchangepb.Order{
+		{Id: "1", AccountId: "alice", Pair: "A/B", Status: exchangepb.Order_OPEN},
+		{Id: "2", AccountId: "bob", Pair: "A/B", Status: exchangepb.Order_OPEN},
+		{Id: "3", AccountId: "alice", Pair: "C/D", Status: exchangepb.Order_FILLED},
+		{Id: "4", AccountId: "alice", Pair: "A/B", Status: exchangepb.Order_FILLED},
+	}
+
+	testCases := []struct {
+		name    string
+		filter  storage.Filter
+		after   uint64
+		limit   int
+		wantIDs []string
+	}{
+		{
+			name:    "all",
+			limit:   10,
+			wantIDs: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:    "limit",
+			limit:   2,
+			wantIDs: []string{"1", "2"},
+		},
+		{
+			name:    "after",
+			after:   2,
+			limit:   10,
+			wantIDs: []string{"3", "4"},
+		},
+		{
+			name:    "account",
+			filter:  storage.Filter{AccountID: "alice"},
+			limit:   10,
+			wantIDs: []string{"1", "3", "4"},
+		},
+		{
+			name:    "account_pair_status",
+			filter:  storage.Filter{AccountID: "alice", Pair: "A/B", Status: exchangepb.Order_FILLED},
+			limit:   10,
+			wantIDs: []string{"4"},
+		},
+		{
+			name:    "filter_and_limit",
+			filter:  storage.Filter{Pair: "A/B"},
+			after:   1,
+			limit:   1,
+			wantIDs: []string{"2"},
+		},
+	}
+
+	for name, s := range implementations(t) {
+		for _, o := range orders {
+			if err := s.CreateOrder(&storage.Order{Order: o}); err != nil {
+				t.Fatalf("%s: CreateOrder() unexpected error: %v", name, err)
+			}
+		}
+
+		for _, tc := range testCases {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				got, err := s.ListOrders(tc.filter, tc.after, tc.limit)
+				if err != nil {
+					t.Fatalf("ListOrders() unexpected error: %v", err)
+				}
+
+				gotIDs := []string{}
+				for _, o := range got {
+					gotIDs = append(gotIDs, o.Order.Id)
+				}
+
+				if diff := cmp.Diff(tc.wantIDs, gotIDs); diff != "" {
+					t.Errorf("ListOrders() unexpected IDs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	}
+}
+
+func Test_UpdateOrder(t *testing.T) {
+	fillTime := time.Unix(100, 0)
+
+	for name, s := range implementations(t) {
+		t.Run(name, func(t *testing.T) {
+			o := &storage.Order{Order: &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_PENDING_NEW}}
+			if err := s.CreateOrder(o); err != nil {
+				t.Fatalf("CreateOrder() unexpected error: %v", err)
+			}
+
+			update, err := s.UpdateOrder("1", func(o *storage.Order) (*exchangepb.OrderUpdate, error) {
+				o.Order.Status = exchangepb.Order_PARTIALLY_FILLED
+				o.Order.FilledVolume = 4
+				o.FilledNotional = 20
+				return &exchangepb.OrderUpdate{
+					Type:       exchangepb.OrderUpdate_PARTIALLY_FILLED,
+					FillVolume: 4,
+					FillPrice:  5,
+					Time:       timestamppb.New(fillTime),
+				}, nil
+			})
+			if err != nil {
+				t.Fatalf("UpdateOrder() unexpected error: %v", err)
+			}
+
+			if update.Sequence == 0 || update.Order.GetFilledVolume() != 4 {
+				t.Errorf("UpdateOrder() got update %v, want a sequence and the updated order", update)
+			}
+
+			// A failed update must not persist anything.
+			_, err = s.UpdateOrder("1", func(o *storage.Order) (*exchangepb.OrderUpdate, error) {
+				o.Order.Status = exchangepb.Order_FILLED
+				return &exchangepb.OrderUpdate{FillVolume: 6, FillPrice: 5}, errors.New("failed")
+			})
+			if err == nil {
+				t.Fatalf("UpdateOrder() expected error")
+			}
+
+			_, err = s.UpdateOrder("unknown", func(o *storage.Order) (*exchangepb.OrderUpdate, error) { return nil, nil })
+			if !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("UpdateOrder() unknown got error %v, want %v", err, storage.ErrNotFound)
+			}
+
+			got, err := s.GetOrder("1")
+			if err != nil {
+				t.Fatalf("GetOrder() unexpected error: %v", err)
+			}
+
+			want := &storage.Order{
+				Order:          &exchangepb.Order{Id: "1", Volume: 10, Status: exchangepb.Order_PARTIALLY_FILLED, FilledVolume: 4},
+				FilledNotional: 20,
+				Sequence:       o.Sequence,
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("GetOrder() unexpected order (-want +got):\n%s", diff)
+			}
+
+			fills, err := s.Fills("1")
+			if err != nil {
+				t.Fatalf("Fills() unexpected error: %v", err)
+			}
+
+			wantFills := []storage.Fill{{Volume: 4, Price: 5, Time: fillTime}}
+			if diff := cmp.Diff(wantFills, fills, cmpopts.EquateApproxTime(0)); diff != "" {
+				t.Errorf("Fills() unexpected fills (-want +got):\n%s", diff)
+			}
+
+			transitions, err := s.Transitions("1")
+			if err != nil {
+				t.Fatalf("Transitions() unexpected error: %v", err)
+			}
+
+			wantTransitions := []storage.Transition{{From: exchangepb.Order_PENDING_NEW, To: exchangepb.Order_PARTIALLY_FILLED}}
+			if diff := cmp.Diff(wantTransitions, transitions, cmpopts.IgnoreFields(storage.Transition{}, "Time")); diff != "" {
+				t.Errorf("Transitions() unexpected transitions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ListUpdates(t *testing.T) {
+	for name, s := range implementations(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, o := range []*exchangepb.Order{
+				{Id: "1", AccountId: "alice"},
+				{Id: "2", AccountId: "bob"},
+			} {
+				if err := s.CreateOrder(&storage.Order{Order: o}); err != nil {
+					t.Fatalf("CreateOrder() unexpected error: %v", err)
+				}
+			}
+
+			for _, id := range []string{"1", "2", "1", "1"} {
+				_, err := s.UpdateOrder(id, func(o *storage.Order) (*exchangepb.OrderUpdate, error) {
+					o.Order.FilledVolume++
+					return &exchangepb.OrderUpdate{Type: exchangepb.OrderUpdate_PARTIALLY_FILLED}, nil
+				})
+				if err != nil {
+					t.Fatalf("UpdateOrder() unexpected error: %v", err)
+				}
+			}
+
+			// No update is stored when there is nothing to tell clients.
+			_, err := s.UpdateOrder("1", func(o *storage.Order) (*exchangepb.OrderUpdate, error) { return nil, nil })
+			if err != nil {
+				t.Fatalf("UpdateOrder() unexpected error: %v", err)
+			}
+
+			got, err := s.ListUpdates("alice", 1, 10)
+			if err != nil {
+				t.Fatalf("ListUpdates() unexpected error: %v", err)
+			}
+
+			want := []*exchangepb.OrderUpdate{
+				{Sequence: 3, Type: exchangepb.OrderUpdate_PARTIALLY_FILLED, Order: &exchangepb.Order{Id: "1", AccountId: "alice", FilledVolume: 2}},
+				{Sequence: 4, Type: exchangepb.OrderUpdate_PARTIALLY_FILLED, Order: &exchangepb.Order{Id: "1", AccountId: "alice", FilledVolume: 3}},
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ListUpdates() unexpected updates (-want +got):\n%s", diff)
+			}
+
+			got, err = s.ListUpdates("carol", 0, 10)
+			if err != nil {
+				t.Fatalf("ListUpdates() unexpected error: %v", err)
+			}
+
+			if len(got) != 0 {
+				t.Errorf("ListUpdates() got %d updates for an unknown account, want 0", len(got))
+			}
+		})
+	}
+}
+
+func Test_APIKeys(t *testing.T) {
+	for name, s := range implementations(t) {
+		t.Run(name, func(t *testing.T) {
+			keys := []*exchangepb.APIKey{
+				{Id: "k2", AccountId: "alice", Role: exchangepb.APIKey_TRADER, Secret: "s2"},
+				{Id: "k1", AccountId: "alice", Role: exchangepb.APIKey_TRADER, Secret: "s1"},
+				{Id: "k3", AccountId: "bob", Role: exchangepb.APIKey_ADMIN, Secret: "s3"},
+			}
+			for _, k := range keys {
+				if err := s.CreateAPIKey(k); err != nil {
+					t.Fatalf("CreateAPIKey() unexpected error: %v", err)
+				}
+			}
+
+			if err := s.CreateAPIKey(&exchangepb.APIKey{Id: "k1"}); !errors.Is(err, storage.ErrExists) {
+				t.Errorf("CreateAPIKey() duplicate got error %v, want %v", err, storage.ErrExists)
+			}
+
+			got, err := s.GetAPIKey("k3")
+			if err != nil {
+				t.Fatalf("GetAPIKey() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(keys[2], got, protocmp.Transform()); diff != "" {
+				t.Errorf("GetAPIKey() unexpected key (-want +got):\n%s", diff)
+			}
+
+			listed, err := s.ListAPIKeys("alice")
+			if err != nil {
+				t.Fatalf("ListAPIKeys() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff([]*exchangepb.APIKey{keys[1], keys[0]}, listed, protocmp.Transform()); diff != "" {
+				t.Errorf("ListAPIKeys() unexpected keys (-want +got):\n%s", diff)
+			}
+
+			if err := s.DeleteAPIKey("k1"); err != nil {
+				t.Fatalf("DeleteAPIKey() unexpected error: %v", err)
+			}
+
+			if _, err := s.GetAPIKey("k1"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("GetAPIKey() after delete got error %v, want %v", err, storage.ErrNotFound)
+			}
+
+			if err := s.DeleteAPIKey("k1"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("DeleteAPIKey() twice got error %v, want %v", err, storage.ErrNotFound)
+			}
+
+			listed, err = s.ListAPIKeys("")
+			if err != nil {
+				t.Fatalf("ListAPIKeys() unexpected error: %v", err)
+			}
+
+			if len(listed) != 2 {
+				t.Errorf("ListAPIKeys() of every account got %d keys, want 2", len(listed))
+			}
+		})
+	}
+}
+
+func Test_BoltReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.db")
+
+	s, err := storage.OpenBolt(path)
+	if err != nil {
+		t.Fatalf("OpenBolt() unexpected error: %v", err)
+	}
+
+	if err := s.CreateOrder(&storage.Order{Order: &exchangepb.Order{Id: "1"}}); err != nil {
+		t.Fatalf("CreateOrder() unexpected error: %v", err)
+	}
+	s.Close()
+
+	// Reopening must not run the migrations again nor lose data.
+	s, err = storage.OpenBolt(path)
+	if err != nil {
+		t.Fatalf("OpenBolt() reopen unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	if _, err := s.GetOrder("1"); err != nil {
+		t.Errorf("GetOrder() after reopen unexpected error: %v", err)
+	}
+}
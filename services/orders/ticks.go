@@ -0,0 +1,41 @@
+package ordersservice
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	enginepb "exchange/engine/api/v1"
+)
+
+// How often a TICK order request is sent to every market.
+const tickInterval = time.Second
+
+// TickMarkets sends a TICK order request with the time to every market
+// periodically. The engine expires orders by the time of the order requests
+// only, so the orders of a market without other requests expire within the
+// interval.
+func (s *Service) TickMarkets(ctx context.Context) error {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			req := &enginepb.OrderRequest{
+				Type: enginepb.OrderRequest_TICK,
+				Time: timestamppb.New(now),
+			}
+
+			for _, market := range s.markets {
+				if err := s.produce(ctx, market.Name(), req); err != nil {
+					log.Printf("Error ticking market %q: %v", market.Name(), err)
+				}
+			}
+		}
+	}
+}
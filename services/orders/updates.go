@@ -0,0 +1,129 @@
+package ordersservice
+
+import (
+	"fmt"
+	"sync"
+
+	exchangepb "exchange/api/v1"
+)
+
+const (
+	// How many updates a stream may fall behind before it is disconnected, and
+	// the client has to resume from its last received sequence.
+	subscriberBuffer = 100
+
+	// How many stored updates are read at a time when resuming a stream.
+	replayBatchSize = 100
+)
+
+// subscribers fans out order updates to the streams of each account.
+type subscribers struct {
+	mu sync.Mutex
+
+	byAccount map[string]map[chan *exchangepb.OrderUpdate]struct{}
+}
+
+func newSubscribers() *subscribers {
+	return &subscribers{
+		byAccount: map[string]map[chan *exchangepb.OrderUpdate]struct{}{},
+	}
+}
+
+// subscribe returns a channel receiving the account's updates. The channel is
+// closed if the subscriber falls behind.
+func (s *subscribers) subscribe(accountID string) chan *exchangepb.OrderUpdate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan *exchangepb.OrderUpdate, subscriberBuffer)
+	if s.byAccount[accountID] == nil {
+		s.byAccount[accountID] = map[chan *exchangepb.OrderUpdate]struct{}{}
+	}
+	s.byAccount[accountID][ch] = struct{}{}
+
+	return ch
+}
+
+func (s *subscribers) unsubscribe(accountID string, ch chan *exchangepb.OrderUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.byAccount[accountID], ch)
+	if len(s.byAccount[accountID]) == 0 {
+		delete(s.byAccount, accountID)
+	}
+}
+
+// publish sends the update to the subscribers of its account without blocking,
+// disconnecting those that are too slow to keep up.
+func (s *subscribers) publish(update *exchangepb.OrderUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := update.Order.GetAccountId()
+	for ch := range s.byAccount[accountID] {
+		select {
+		case ch <- update:
+		default:
+			close(ch)
+			delete(s.byAccount[accountID], ch)
+		}
+	}
+}
+
+func (s *Service) StreamOrderUpdates(req *exchangepb.StreamOrderUpdatesRequest, stream exchangepb.OrdersService_StreamOrderUpdatesServer) error {
+	req.AccountId = callerAccount(stream.Context(), req.AccountId)
+	if req.AccountId == "" {
+		return fmt.Errorf("account id is required: %w", errBadRequest)
+	}
+
+	if err := authorize(stream.Context(), req.AccountId); err != nil {
+		return err
+	}
+
+	// Subscribe before replaying, so that no update falls in between. Updates
+	// received both ways are skipped by their sequence.
+	ch := s.subscribers.subscribe(req.AccountId)
+	defer s.subscribers.unsubscribe(req.AccountId, ch)
+
+	last := req.FromSequence
+	if last > 0 {
+		for {
+			updates, err := s.store.ListUpdates(req.AccountId, last, replayBatchSize)
+			if err != nil {
+				return fmt.Errorf("error listing updates: %w", err)
+			}
+
+			for _, u := range updates {
+				if err := stream.Send(u); err != nil {
+					return err
+				}
+				last = u.Sequence
+			}
+
+			if len(updates) < replayBatchSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case u, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("stream fell behind, resume from sequence %d: %w", last, errAborted)
+			}
+
+			if u.Sequence <= last {
+				continue
+			}
+
+			if err := stream.Send(u); err != nil {
+				return err
+			}
+			last = u.Sequence
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ordersservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	exchangepb "exchange/api/v1"
+)
+
+func Test_Subscribers(t *testing.T) {
+	s := newSubscribers()
+
+	alice := s.subscribe("alice")
+	bob := s.subscribe("bob")
+
+	s.publish(&exchangepb.OrderUpdate{Sequence: 1, Order: &exchangepb.Order{AccountId: "alice"}})
+
+	if got := len(alice); got != 1 {
+		t.Errorf("alice received %d updates, want 1", got)
+	}
+
+	if got := len(bob); got != 0 {
+		t.Errorf("bob received %d updates, want 0", got)
+	}
+
+	// Filling the buffer of a subscriber disconnects it.
+	for i := 0; i < subscriberBuffer; i++ {
+		s.publish(&exchangepb.OrderUpdate{Sequence: uint64(i + 2), Order: &exchangepb.Order{AccountId: "alice"}})
+	}
+
+	for range alice {
+	}
+
+	if _, ok := s.byAccount["alice"][alice]; ok {
+		t.Errorf("slow subscriber was not removed")
+	}
+
+	s.unsubscribe("bob", bob)
+	if _, ok := s.byAccount["bob"]; ok {
+		t.Errorf("account without subscribers was not removed")
+	}
+}
+
+// updatesStream is a StreamOrderUpdates stream calling onSend with the updates
+// sent.
+type updatesStream struct {
+	grpc.ServerStream
+
+	onSend func(u *exchangepb.OrderUpdate)
+}
+
+func (s *updatesStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *updatesStream) Send(u *exchangepb.OrderUpdate) error {
+	s.onSend(u)
+	return nil
+}
+
+func Test_StreamOrderUpdates_FellBehind(t *testing.T) {
+	s := &Service{subscribers: newSubscribers()}
+
+	update := func(sequence uint64) *exchangepb.OrderUpdate {
+		return &exchangepb.OrderUpdate{Sequence: sequence, Order: &exchangepb.Order{AccountId: "alice"}}
+	}
+
+	// The first update sent fills the buffer of the stream, which is
+	// disconnected.
+	stream := &updatesStream{}
+	stream.onSend = func(u *exchangepb.OrderUpdate) {
+		if u.Sequence == 1 {
+			for i := range subscriberBuffer + 1 {
+				s.subscribers.publish(update(uint64(i + 2)))
+			}
+		}
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- s.StreamOrderUpdates(&exchangepb.StreamOrderUpdatesRequest{AccountId: "alice"}, stream)
+	}()
+
+	for subscribed := false; !subscribed; time.Sleep(time.Millisecond) {
+		s.subscribers.mu.Lock()
+		subscribed = len(s.subscribers.byAccount["alice"]) > 0
+		s.subscribers.mu.Unlock()
+	}
+	s.subscribers.publish(update(1))
+
+	if err := <-done; status.Code(err) != codes.Aborted {
+		t.Errorf("StreamOrderUpdates() got error %v, want Aborted", err)
+	}
+}
@@ -0,0 +1,138 @@
+package ordersservice
+
+import (
+	"fmt"
+
+	exchangepb "exchange/api/v1"
+	engineserver "exchange/engine/server"
+)
+
+// instrument returns the listed market of pair.
+func (s *Service) instrument(pair string) (*engineserver.MarketSymbol, error) {
+	ms, ok := s.instruments[pair]
+	if !ok {
+		return nil, fmt.Errorf("pair %q not listed: %w", pair, errNotFound)
+	}
+
+	return ms, nil
+}
+
+// tradingInstrument returns the listed market of pair if it accepts new
+// orders.
+func (s *Service) tradingInstrument(pair string) (*engineserver.MarketSymbol, error) {
+	ms, err := s.instrument(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	if ms.Status != engineserver.MarketTrading {
+		return nil, fmt.Errorf("market %q is halted: %w", pair, errBadRequest)
+	}
+
+	return ms, nil
+}
+
+// validateOrder checks an order received from a client against its market,
+// so that the engine never drops it for a malformed request.
+func (s *Service) validateOrder(o *exchangepb.Order) error {
+	if o == nil {
+		return fmt.Errorf("order is required: %w", errBadRequest)
+	}
+
+	ms, err := s.tradingInstrument(o.Pair)
+	if err != nil {
+		return err
+	}
+
+	if o.AccountId == "" {
+		return fmt.Errorf("account id is required: %w", errBadRequest)
+	}
+
+	if o.Side != exchangepb.Side_BUY && o.Side != exchangepb.Side_SELL {
+		return fmt.Errorf("side not supported: %v: %w", o.Side, errBadRequest)
+	}
+
+	if err := checkVolume(ms, o.Volume); err != nil {
+		return err
+	}
+
+	switch o.Type {
+	case exchangepb.Order_LIMIT:
+		if o.Price == 0 {
+			return fmt.Errorf("limit order without a price: %w", errBadRequest)
+		}
+	case exchangepb.Order_STOP:
+		if o.StopPrice == 0 {
+			return fmt.Errorf("stop order without a stop price: %w", errBadRequest)
+		}
+
+		if err := checkPrice(ms, o.StopPrice); err != nil {
+			return err
+		}
+	case exchangepb.Order_TRAILING_STOP:
+		if o.Trail.GetAmount() == 0 && o.Trail.GetBasisPoints() == 0 {
+			return fmt.Errorf("trailing stop order without a trail: %w", errBadRequest)
+		}
+	case exchangepb.Order_PEGGED:
+		if o.Peg.GetType() == exchangepb.Peg_PEG_TYPE_UNSPECIFIED {
+			return fmt.Errorf("pegged order without a peg: %w", errBadRequest)
+		}
+	}
+
+	if o.Price != 0 {
+		if err := checkPrice(ms, o.Price); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateAmend checks the new price and volume of an order, 0 when they do
+// not change, against its market.
+func (s *Service) validateAmend(o *exchangepb.Order, price uint64, volume uint64) error {
+	ms, err := s.tradingInstrument(o.Pair)
+	if err != nil {
+		return err
+	}
+
+	if price != 0 {
+		if err := checkPrice(ms, price); err != nil {
+			return err
+		}
+	}
+
+	if volume != 0 {
+		if err := checkVolume(ms, volume); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPrice checks that a price is in the range of the market, on its tick.
+func checkPrice(ms *engineserver.MarketSymbol, price uint64) error {
+	if ms.PriceRange == nil {
+		return nil
+	}
+
+	if err := ms.PriceRange.Check(price); err != nil {
+		return fmt.Errorf("market %q: %v: %w", ms.Name(), err, errBadRequest)
+	}
+
+	return nil
+}
+
+// checkVolume checks that a volume is positive, in lots of the market.
+func checkVolume(ms *engineserver.MarketSymbol, volume uint64) error {
+	if volume == 0 {
+		return fmt.Errorf("volume must be positive: %w", errBadRequest)
+	}
+
+	if ms.LotSize != 0 && volume%ms.LotSize != 0 {
+		return fmt.Errorf("market %q, volume %d not a multiple of the lot size %d: %w", ms.Name(), volume, ms.LotSize, errBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package ordersservice
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	exchangepb "exchange/api/v1"
+	"exchange/engine/orderbook"
+	engineserver "exchange/engine/server"
+)
+
+func Test_ValidateOrder(t *testing.T) {
+	s := &Service{
+		instruments: map[string]*engineserver.MarketSymbol{
+			"A/B": {Base: "A", Trade: "B", PriceRange: &orderbook.PriceRange{Min: 10, Max: 100, Tick: 5}, LotSize: 10},
+			"C/D": {Base: "C", Trade: "D", Status: engineserver.MarketHalted},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		order    *exchangepb.Order
+		wantCode codes.Code
+	}{
+		{
+			name:     "valid_limit",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 55, Volume: 20},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "valid_market",
+			order:    &exchangepb.Order{Type: exchangepb.Order_MARKET, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_SELL, Volume: 20},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "no_order",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "malformed_pair",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "AB", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 55, Volume: 20},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "unknown_pair",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "B/A", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 55, Volume: 20},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "halted",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "C/D", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 55, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "no_account",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", Side: exchangepb.Side_BUY, Price: 55, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unspecified_side",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Price: 55, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "zero_volume",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 55},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "off_lot",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 55, Volume: 25},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "limit_without_price",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "off_tick",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 56, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "out_of_range",
+			order:    &exchangepb.Order{Type: exchangepb.Order_LIMIT, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Price: 105, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "stop_without_stop_price",
+			order:    &exchangepb.Order{Type: exchangepb.Order_STOP, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "stop_price_off_tick",
+			order:    &exchangepb.Order{Type: exchangepb.Order_STOP, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_BUY, StopPrice: 12, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "trailing_stop_without_trail",
+			order:    &exchangepb.Order{Type: exchangepb.Order_TRAILING_STOP, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_SELL, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "pegged_without_peg",
+			order:    &exchangepb.Order{Type: exchangepb.Order_PEGGED, Pair: "A/B", AccountId: "alice", Side: exchangepb.Side_SELL, Volume: 20},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.validateOrder(tc.order)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("validateOrder() got code %v, want %v, error: %v", got, tc.wantCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+// Package ratelimit limits the rate of the requests of the services with token
+// buckets, per account and method for authenticated requests, and per IP and
+// method for the others. Every request is also limited per IP before it is
+// authenticated.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	authservice "exchange/services/auth"
+)
+
+// The metadata reporting the usage of the limit of a request, sent with the
+// response headers.
+const (
+	// The number of calls in a burst.
+	LimitHeader = "x-ratelimit-limit"
+
+	// The number of calls left in the burst.
+	RemainingHeader = "x-ratelimit-remaining"
+
+	// The milliseconds until the burst is available again.
+	ResetHeader = "x-ratelimit-reset-ms"
+
+	// The milliseconds until the next call is allowed, only sent with
+	// ResourceExhausted errors.
+	RetryAfterHeader = "retry-after-ms"
+)
+
+// ForwardedForHeader is the metadata with the IP of the client, set by the
+// trusted proxies.
+const ForwardedForHeader = "x-forwarded-for"
+
+// How often the buckets that refilled are dropped.
+const sweepInterval = time.Minute
+
+// Limit is the rate of a token bucket. A Limit without a Rate does not limit
+// the calls.
+type Limit struct {
+	// The calls allowed per second.
+	Rate float64
+
+	// The calls allowed in a burst, at least 1.
+	Burst int
+}
+
+// Tier are the limits of a class of clients.
+type Tier struct {
+	// The limit of the methods missing from Methods.
+	Default Limit
+
+	// The limits by full method name, like
+	// exchangepb.OrdersService_CreateOrder_FullMethodName.
+	Methods map[string]Limit
+}
+
+func (t *Tier) limit(method string) Limit {
+	if l, ok := t.Methods[method]; ok {
+		return l
+	}
+
+	return t.Default
+}
+
+// Config are the limits of a Limiter.
+type Config struct {
+	// The tiers by name.
+	Tiers map[string]Tier
+
+	// The tier of the accounts missing from Accounts.
+	DefaultTier string
+
+	// The tiers of the accounts, like the market makers.
+	Accounts map[string]string
+
+	// The limits of the requests without an account, per IP.
+	Public Tier
+
+	// The limit of every request of an IP, whatever its method and account,
+	// taken before the request is authenticated so that a flood of requests
+	// failing to authenticate never reaches the API keys. See
+	// IPUnaryInterceptor.
+	PerIP Limit
+
+	// The IPs of the proxies, like the HTTP gateway, whose requests are
+	// limited by the IP of their ForwardedForHeader.
+	TrustedProxies []string
+}
+
+// Limiter limits the rate of the requests. Its interceptors run after the ones
+// of the authservice.Authenticator, to find the account of the requests, and
+// its IP interceptors before them.
+type Limiter struct {
+	config Config
+
+	mu sync.Mutex
+
+	buckets map[string]*bucket
+
+	// When the buckets that refilled were last dropped.
+	swept time.Time
+
+	now func() time.Time
+}
+
+// New returns a limiter with the limits of config.
+func New(config Config) *Limiter {
+	return &Limiter{
+		config:  config,
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// usage is the outcome of taking a token for a request.
+type usage struct {
+	allowed bool
+
+	limit Limit
+
+	// The tokens left.
+	remaining int
+
+	// How long until the bucket is full again.
+	reset time.Duration
+
+	// How long until a token is available, when not allowed.
+	retryAfter time.Duration
+}
+
+// header returns the metadata reporting the usage.
+func (u *usage) header() metadata.MD {
+	md := metadata.Pairs(
+		LimitHeader, strconv.Itoa(u.limit.Burst),
+		RemainingHeader, strconv.Itoa(u.remaining),
+		ResetHeader, milliseconds(u.reset),
+	)
+
+	if !u.allowed {
+		md.Set(RetryAfterHeader, milliseconds(u.retryAfter))
+	}
+
+	return md
+}
+
+// milliseconds formats a duration in milliseconds, rounded up.
+func milliseconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(float64(d)/float64(time.Millisecond))), 10)
+}
+
+// bucket is a token bucket, holding up to Burst tokens refilled at Rate per
+// second.
+type bucket struct {
+	tokens float64
+
+	// When the tokens were counted.
+	at time.Time
+
+	// When the bucket is full again.
+	full time.Time
+}
+
+// refill adds the tokens since the bucket was last counted.
+func (b *bucket) refill(l Limit, now time.Time) {
+	b.tokens = math.Min(float64(l.Burst), b.tokens+now.Sub(b.at).Seconds()*l.Rate)
+	b.at = now
+}
+
+// take takes a token of the bucket of key, if there is one.
+func (l *Limiter) take(key string, limit Limit) *usage {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	limit.Burst = max(limit.Burst, 1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.swept) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), at: now}
+		l.buckets[key] = b
+	}
+	b.refill(limit, now)
+
+	u := &usage{limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		u.allowed = true
+	} else {
+		u.retryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+
+	u.remaining = int(b.tokens)
+	u.reset = seconds((float64(limit.Burst) - b.tokens) / limit.Rate)
+	b.full = now.Add(u.reset)
+
+	return u
+}
+
+// sweep drops the buckets that are full by now, which are the same as new
+// ones.
+func (l *Limiter) sweep(now time.Time) {
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if !now.Before(b.full) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// allow takes a token for a request to method, returning a ResourceExhausted
+// error if it is limited, and the metadata reporting the usage of its limit.
+func (l *Limiter) allow(ctx context.Context, method string) (metadata.MD, error) {
+	var key string
+	var limit Limit
+	if caller, ok := authservice.CallerFromContext(ctx); ok {
+		tier, ok := l.config.Accounts[caller.AccountID]
+		if !ok {
+			tier = l.config.DefaultTier
+		}
+
+		t := l.config.Tiers[tier]
+		key, limit = "account\x00"+caller.AccountID+"\x00"+method, t.limit(method)
+	} else {
+		key, limit = "ip\x00"+l.clientIP(ctx)+"\x00"+method, l.config.Public.limit(method)
+	}
+
+	u := l.take(key, limit)
+	if u == nil {
+		return nil, nil
+	}
+
+	if !u.allowed {
+		return u.header(), status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded, retry after %v", method, u.retryAfter.Round(time.Millisecond))
+	}
+
+	return u.header(), nil
+}
+
+// allowIP takes a token of the PerIP limit for a request, returning a
+// ResourceExhausted error and the metadata reporting the usage of the limit if
+// it is limited.
+func (l *Limiter) allowIP(ctx context.Context) (metadata.MD, error) {
+	u := l.take("all\x00"+l.clientIP(ctx), l.config.PerIP)
+	if u == nil || u.allowed {
+		// The usage of the limit of the method is reported instead.
+		return nil, nil
+	}
+
+	return u.header(), status.Errorf(codes.ResourceExhausted, "rate limit of your IP exceeded, retry after %v", u.retryAfter.Round(time.Millisecond))
+}
+
+// clientIP returns the IP of the client of a request, as forwarded by a
+// trusted proxy.
+func (l *Limiter) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	trusted := false
+	for _, proxy := range l.config.TrustedProxies {
+		if proxy == ip {
+			trusted = true
+			break
+		}
+	}
+
+	if !trusted {
+		return ip
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(ForwardedForHeader); len(values) > 0 {
+		// The last address was added by the proxy, the others by the client.
+		hops := strings.Split(values[len(values)-1], ",")
+		if forwarded := strings.TrimSpace(hops[len(hops)-1]); forwarded != "" {
+			return forwarded
+		}
+	}
+
+	return ip
+}
+
+// UnaryInterceptor limits the unary requests.
+func (l *Limiter) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, err := l.allow(ctx, info.FullMethod)
+	if md != nil {
+		grpc.SetHeader(ctx, md)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// IPUnaryInterceptor limits the unary requests of every IP by the PerIP limit.
+// It runs before the interceptors authenticating the requests.
+func (l *Limiter) IPUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, err := l.allowIP(ctx)
+	if err != nil {
+		grpc.SetHeader(ctx, md)
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// IPStreamInterceptor limits the streams of every IP by the PerIP limit when
+// they are opened, before their request is received and authenticated.
+func (l *Limiter) IPStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	md, err := l.allowIP(ss.Context())
+	if err != nil {
+		ss.SetHeader(md)
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// StreamInterceptor limits the server streaming requests when their request is
+// received, after it is authenticated. Client streams are not limited.
+func (l *Limiter) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if info.IsClientStream {
+		return handler(srv, ss)
+	}
+
+	return handler(srv, &limitedStream{ServerStream: ss, limiter: l, method: info.FullMethod})
+}
+
+// limitedStream limits the request of a server stream.
+type limitedStream struct {
+	grpc.ServerStream
+
+	limiter *Limiter
+
+	method string
+}
+
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	md, err := s.limiter.allow(s.Context(), s.method)
+	if md != nil {
+		s.SetHeader(md)
+	}
+
+	return err
+}
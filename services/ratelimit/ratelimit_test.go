@@ -0,0 +1,401 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	exchangepb "exchange/api/v1"
+	authservice "exchange/services/auth"
+)
+
+const (
+	createOrder = exchangepb.OrdersService_CreateOrder_FullMethodName
+	getOrder    = exchangepb.OrdersService_GetOrder_FullMethodName
+)
+
+var config = Config{
+	Tiers: map[string]Tier{
+		"default": {
+			Default: Limit{Rate: 10, Burst: 10},
+			Methods: map[string]Limit{createOrder: {Rate: 1, Burst: 2}},
+		},
+		"market_maker": {
+			Default: Limit{Rate: 100, Burst: 100},
+			Methods: map[string]Limit{createOrder: {Rate: 10, Burst: 4}},
+		},
+	},
+	DefaultTier:    "default",
+	Accounts:       map[string]string{"mm": "market_maker"},
+	Public:         Tier{Default: Limit{Rate: 1, Burst: 1}},
+	TrustedProxies: []string{"10.0.0.1"},
+}
+
+func Test_Allow(t *testing.T) {
+	account := func(accountID string) context.Context {
+		return authservice.NewContext(context.Background(), &authservice.Caller{AccountID: accountID})
+	}
+
+	ip := func(addr string, forwarded ...string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 1234}})
+		if len(forwarded) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ForwardedForHeader, forwarded[0]))
+		}
+		return ctx
+	}
+
+	type call struct {
+		ctx    context.Context
+		method string
+		after  time.Duration
+	}
+
+	testCases := []struct {
+		name  string
+		calls []call
+		// The codes of the calls.
+		want []codes.Code
+		// The metadata of the last call.
+		wantMD metadata.MD
+	}{
+		{
+			name: "burst",
+			calls: []call{
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: createOrder},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted},
+			wantMD: metadata.Pairs(
+				LimitHeader, "2",
+				RemainingHeader, "0",
+				ResetHeader, "2000",
+				RetryAfterHeader, "1000",
+			),
+		},
+		{
+			name: "refilled",
+			calls: []call{
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: createOrder, after: 500 * time.Millisecond},
+				{ctx: account("alice"), method: createOrder, after: 500 * time.Millisecond},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted, codes.OK},
+			wantMD: metadata.Pairs(
+				LimitHeader, "2",
+				RemainingHeader, "0",
+				ResetHeader, "2000",
+			),
+		},
+		{
+			name: "per_method",
+			calls: []call{
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: getOrder},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.OK},
+			wantMD: metadata.Pairs(
+				LimitHeader, "10",
+				RemainingHeader, "9",
+				ResetHeader, "100",
+			),
+		},
+		{
+			name: "per_account",
+			calls: []call{
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("alice"), method: createOrder},
+				{ctx: account("bob"), method: createOrder},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.OK},
+			wantMD: metadata.Pairs(
+				LimitHeader, "2",
+				RemainingHeader, "1",
+				ResetHeader, "1000",
+			),
+		},
+		{
+			name: "market_maker",
+			calls: []call{
+				{ctx: account("mm"), method: createOrder},
+				{ctx: account("mm"), method: createOrder},
+				{ctx: account("mm"), method: createOrder},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.OK},
+			wantMD: metadata.Pairs(
+				LimitHeader, "4",
+				RemainingHeader, "1",
+				ResetHeader, "300",
+			),
+		},
+		{
+			name: "per_ip",
+			calls: []call{
+				{ctx: ip("192.0.2.1"), method: getOrder},
+				{ctx: ip("192.0.2.2"), method: getOrder},
+				{ctx: ip("192.0.2.1"), method: getOrder},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted},
+			wantMD: metadata.Pairs(
+				LimitHeader, "1",
+				RemainingHeader, "0",
+				ResetHeader, "1000",
+				RetryAfterHeader, "1000",
+			),
+		},
+		{
+			name: "trusted_proxy",
+			calls: []call{
+				{ctx: ip("10.0.0.1", "192.0.2.1"), method: getOrder},
+				{ctx: ip("10.0.0.1", "192.0.2.2"), method: getOrder},
+				{ctx: ip("10.0.0.1"), method: getOrder},
+				{ctx: ip("10.0.0.1", "198.51.100.1, 192.0.2.1"), method: getOrder},
+			},
+			want: []codes.Code{codes.OK, codes.OK, codes.OK, codes.ResourceExhausted},
+			wantMD: metadata.Pairs(
+				LimitHeader, "1",
+				RemainingHeader, "0",
+				ResetHeader, "1000",
+				RetryAfterHeader, "1000",
+			),
+		},
+		{
+			name: "untrusted_proxy",
+			calls: []call{
+				{ctx: ip("192.0.2.1", "198.51.100.1"), method: getOrder},
+				{ctx: ip("192.0.2.1", "198.51.100.2"), method: getOrder},
+			},
+			want: []codes.Code{codes.OK, codes.ResourceExhausted},
+			wantMD: metadata.Pairs(
+				LimitHeader, "1",
+				RemainingHeader, "0",
+				ResetHeader, "1000",
+				RetryAfterHeader, "1000",
+			),
+		},
+		{
+			name: "unlimited",
+			calls: []call{
+				{ctx: account("nobody"), method: getOrder},
+			},
+			want: []codes.Code{codes.OK},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+
+			c := config
+			if tc.name == "unlimited" {
+				c.DefaultTier = "missing"
+			}
+
+			l := New(c)
+			l.now = func() time.Time { return now }
+
+			var got []codes.Code
+			var md metadata.MD
+			for _, call := range tc.calls {
+				now = now.Add(call.after)
+
+				var err error
+				md, err = l.allow(call.ctx, call.method)
+				got = append(got, status.Code(err))
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("allow() codes mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantMD, md); diff != "" {
+				t.Errorf("allow() metadata mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Sweep(t *testing.T) {
+	now := time.Now()
+	l := New(config)
+	l.now = func() time.Time { return now }
+
+	alice := authservice.NewContext(context.Background(), &authservice.Caller{AccountID: "alice"})
+	for range 2 {
+		l.allow(alice, createOrder)
+	}
+
+	// Refilled, but not swept yet.
+	now = now.Add(10 * time.Second)
+	l.allow(alice, getOrder)
+	if len(l.buckets) != 2 {
+		t.Errorf("got %d buckets, want 2", len(l.buckets))
+	}
+
+	// The empty createOrder bucket refilled and is dropped.
+	now = now.Add(sweepInterval)
+	l.allow(alice, getOrder)
+	if len(l.buckets) != 1 {
+		t.Errorf("got %d buckets, want 1", len(l.buckets))
+	}
+}
+
+// orders answers the requests of any account.
+type orders struct {
+	exchangepb.UnimplementedOrdersServiceServer
+}
+
+func (s *orders) GetOrder(ctx context.Context, req *exchangepb.GetOrderRequest) (*exchangepb.Order, error) {
+	return &exchangepb.Order{Id: req.OrderId}, nil
+}
+
+func (s *orders) StreamOrderUpdates(req *exchangepb.StreamOrderUpdatesRequest, stream exchangepb.OrdersService_StreamOrderUpdatesServer) error {
+	return stream.Send(&exchangepb.OrderUpdate{})
+}
+
+func Test_Interceptors(t *testing.T) {
+	l := New(Config{Public: Tier{Default: Limit{Rate: 1, Burst: 1}}})
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(l.UnaryInterceptor), grpc.StreamInterceptor(l.StreamInterceptor))
+	exchangepb.RegisterOrdersServiceServer(server, &orders{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	client := exchangepb.NewOrdersServiceClient(conn)
+	ctx := context.Background()
+
+	for i, want := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		var header metadata.MD
+		_, err := client.GetOrder(ctx, &exchangepb.GetOrderRequest{OrderId: "1"}, grpc.Header(&header))
+		if status.Code(err) != want {
+			t.Fatalf("GetOrder() call %d got %v, want %v", i, err, want)
+		}
+
+		if got := header.Get(LimitHeader); !cmp.Equal(got, []string{"1"}) {
+			t.Errorf("GetOrder() call %d got %s %v, want [1]", i, LimitHeader, got)
+		}
+
+		if got := len(header.Get(RetryAfterHeader)); got != i {
+			t.Errorf("GetOrder() call %d got %d %s, want %d", i, got, RetryAfterHeader, i)
+		}
+	}
+
+	for i, want := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		stream, err := client.StreamOrderUpdates(ctx, &exchangepb.StreamOrderUpdatesRequest{})
+		if err != nil {
+			t.Fatalf("StreamOrderUpdates() unexpected error: %v", err)
+		}
+
+		if _, err := stream.Recv(); status.Code(err) != want {
+			t.Fatalf("StreamOrderUpdates() call %d got %v, want %v", i, err, want)
+		}
+
+		header, err := stream.Header()
+		if err != nil {
+			t.Fatalf("Header() unexpected error: %v", err)
+		}
+
+		if got := header.Get(RemainingHeader); !cmp.Equal(got, []string{"0"}) {
+			t.Errorf("StreamOrderUpdates() call %d got %s %v, want [0]", i, RemainingHeader, got)
+		}
+	}
+}
+
+func Test_IPInterceptors(t *testing.T) {
+	l := New(Config{PerIP: Limit{Rate: 0.001, Burst: 3}})
+
+	// Stands for the authenticator, failing every request.
+	var authenticated atomic.Int32
+	authenticate := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		authenticated.Add(1)
+		return nil, status.Error(codes.Unauthenticated, "unknown API key")
+	}
+	authenticateStream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authenticated.Add(1)
+		return status.Error(codes.Unauthenticated, "unknown API key")
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(l.IPUnaryInterceptor, authenticate, l.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(l.IPStreamInterceptor, authenticateStream, l.StreamInterceptor),
+	)
+	exchangepb.RegisterOrdersServiceServer(server, &orders{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	client := exchangepb.NewOrdersServiceClient(conn)
+	ctx := context.Background()
+
+	getOrder := func(want codes.Code) {
+		t.Helper()
+
+		var header metadata.MD
+		_, err := client.GetOrder(ctx, &exchangepb.GetOrderRequest{OrderId: "1"}, grpc.Header(&header))
+		if status.Code(err) != want {
+			t.Fatalf("GetOrder() got %v, want %v", err, want)
+		}
+
+		if got := len(header.Get(RetryAfterHeader)) > 0; got != (want == codes.ResourceExhausted) {
+			t.Errorf("GetOrder() got %s %v", RetryAfterHeader, header.Get(RetryAfterHeader))
+		}
+	}
+
+	streamOrderUpdates := func(want codes.Code) {
+		t.Helper()
+
+		stream, err := client.StreamOrderUpdates(ctx, &exchangepb.StreamOrderUpdatesRequest{})
+		if err != nil {
+			t.Fatalf("StreamOrderUpdates() unexpected error: %v", err)
+		}
+
+		if _, err := stream.Recv(); status.Code(err) != want {
+			t.Fatalf("StreamOrderUpdates() got %v, want %v", err, want)
+		}
+	}
+
+	// Unary requests and streams share the limit of the IP, whatever their
+	// method.
+	getOrder(codes.Unauthenticated)
+	getOrder(codes.Unauthenticated)
+	streamOrderUpdates(codes.Unauthenticated)
+	getOrder(codes.ResourceExhausted)
+	streamOrderUpdates(codes.ResourceExhausted)
+
+	// The limited requests never reached the authenticator.
+	if got := authenticated.Load(); got != 3 {
+		t.Errorf("got %d requests authenticated, want 3", got)
+	}
+}
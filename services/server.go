@@ -1,30 +1,154 @@
 package main
 
 import (
+	"context"
 	exchangepb "exchange/api/v1"
+	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	engineserver "exchange/engine/server"
+	authservice "exchange/services/auth"
+	candlesservice "exchange/services/candles"
+	marketdataservice "exchange/services/marketdata"
 	ordersservice "exchange/services/orders"
+	"exchange/services/orders/storage"
+	"exchange/services/ratelimit"
 )
 
+// rateLimits are the limits of the requests, per account and method, or per IP
+// and method for the public ones, and per IP for all of them. Order requests are limited the most, as they
+// are produced to the engine topics.
+var rateLimits = ratelimit.Config{
+	Tiers: map[string]ratelimit.Tier{
+		"default": {
+			Default: ratelimit.Limit{Rate: 20, Burst: 40},
+			Methods: map[string]ratelimit.Limit{
+				exchangepb.OrdersService_CreateOrder_FullMethodName:      {Rate: 10, Burst: 20},
+				exchangepb.OrdersService_CreateOrderGroup_FullMethodName: {Rate: 5, Burst: 10},
+				exchangepb.OrdersService_AmendOrder_FullMethodName:       {Rate: 10, Burst: 20},
+				exchangepb.OrdersService_BatchOrders_FullMethodName:      {Rate: 2, Burst: 4},
+			},
+		},
+		"market_maker": {
+			Default: ratelimit.Limit{Rate: 100, Burst: 200},
+			Methods: map[string]ratelimit.Limit{
+				exchangepb.OrdersService_CreateOrder_FullMethodName:      {Rate: 100, Burst: 200},
+				exchangepb.OrdersService_CreateOrderGroup_FullMethodName: {Rate: 50, Burst: 100},
+				exchangepb.OrdersService_AmendOrder_FullMethodName:       {Rate: 200, Burst: 400},
+				exchangepb.OrdersService_BatchOrders_FullMethodName:      {Rate: 20, Burst: 40},
+			},
+		},
+	},
+	DefaultTier: "default",
+	// The accounts of the market makers are given the "market_maker" tier.
+	Accounts: map[string]string{},
+	Public:   ratelimit.Tier{Default: ratelimit.Limit{Rate: 20, Burst: 40}},
+	// Every request of an IP, checked before authenticating it.
+	PerIP: ratelimit.Limit{Rate: 200, Burst: 400},
+	// The HTTP gateway forwards the IP of its clients.
+	TrustedProxies: []string{"127.0.0.1", "::1"},
+}
+
 func main() {
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	markets := engineserver.Instruments()
+	store, err := storage.OpenBolt("orders.db")
+	if err != nil {
+		log.Fatalf("Failed to open orders storage: %v", err)
+	}
+	defer store.Close()
 
-	orders, err := ordersservice.New()
+	// The first admin key is printed once, to create the keys of the accounts.
+	admin, err := authservice.EnsureAdminKey(store, "admin")
+	if err != nil {
+		log.Fatalf("Failed to create admin API key: %v", err)
+	}
+	if admin != nil {
+		// Written to the standard output, not the log, which is kept and shipped.
+		fmt.Printf("Created admin API key %q with secret %q\n", admin.Id, admin.Secret)
+		log.Printf("Created admin API key %q, its secret was written to the standard output", admin.Id)
+	}
+
+	// Requests are signed within this long of the clock of the server.
+	authenticator := authservice.NewAuthenticator(store, 30*time.Second)
+
+	limiter := ratelimit.New(rateLimits)
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(limiter.IPUnaryInterceptor, authenticator.UnaryInterceptor, limiter.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(limiter.IPStreamInterceptor, authenticator.StreamInterceptor, limiter.StreamInterceptor),
+	)
+	exchangepb.RegisterAdminServiceServer(s, authservice.NewAdminService(store))
+
+	// Orders of sessions without a heartbeat for this long are cancelled.
+	sessionTimeout := 10 * time.Second
+
+	orders, err := ordersservice.New(markets, store, sessionTimeout)
 	if err != nil {
 		log.Fatalf("Failed to create orders service: %v", err)
 	}
 	exchangepb.RegisterOrdersServiceServer(s, orders)
 
+	go func() {
+		if err := orders.Listen(context.Background()); err != nil {
+			log.Printf("Orders service stopped listening: %v", err)
+		}
+	}()
+
+	go func() {
+		if err := orders.WatchSessions(context.Background()); err != nil {
+			log.Printf("Orders service stopped watching sessions: %v", err)
+		}
+	}()
+
+	go func() {
+		if err := orders.TickMarkets(context.Background()); err != nil {
+			log.Printf("Orders service stopped ticking the markets: %v", err)
+		}
+	}()
+
+	marketData, err := marketdataservice.New(markets)
+	if err != nil {
+		log.Fatalf("Failed to create market data service: %v", err)
+	}
+	exchangepb.RegisterMarketDataServiceServer(s, marketData)
+
+	go func() {
+		if err := marketData.Listen(context.Background()); err != nil {
+			log.Printf("Market data service stopped listening: %v", err)
+		}
+	}()
+
+	candleStore, err := candlesservice.OpenStore("candles.db")
+	if err != nil {
+		log.Fatalf("Failed to open candles storage: %v", err)
+	}
+	defer candleStore.Close()
+
+	// The candles of new pairs are backfilled from the first match.
+	candles, err := candlesservice.New(markets, candleStore, 0)
+	if err != nil {
+		log.Fatalf("Failed to create candles service: %v", err)
+	}
+	exchangepb.RegisterCandlesServiceServer(s, candles)
+
+	// The process exits on errors storing the candles, so that it is restarted
+	// and consumes the matches again from the last one stored.
+	go func() {
+		if err := candles.Listen(context.Background()); err != nil {
+			log.Fatalf("Candles service stopped listening: %v", err)
+		}
+	}()
+
 	// enable reflection
 	reflection.Register(s)
 